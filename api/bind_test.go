@@ -72,3 +72,17 @@ func TestBindFormPointer(t *testing.T) {
 	}
 	runTestBindForm(t, dest, validate)
 }
+
+func TestBindFormUnsigned(t *testing.T) {
+	var dest struct {
+		From uint64 `form:"from"`
+		To   uint64 `form:"to"`
+	}
+	req, _ := http.NewRequest("GET", "/?from=32&to=63", nil)
+	assert.NoError(t, Bind(req, &dest))
+	assert.Equal(t, uint64(32), dest.From)
+	assert.Equal(t, uint64(63), dest.To)
+
+	req, _ = http.NewRequest("GET", "/?from=-1", nil)
+	assert.Error(t, Bind(req, &dest))
+}
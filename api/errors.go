@@ -42,3 +42,7 @@ func Error(err error) *ErrorResponse {
 }
 
 var ErrNotFound = &ErrorResponse{Code: 404, Status: "Resource not found."}
+
+var ErrUnauthorized = &ErrorResponse{Code: 401, Status: "Missing or invalid credentials."}
+
+var ErrForbidden = &ErrorResponse{Code: 403, Status: "Insufficient scope."}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+type Backup struct {
+	DB basedb.Database
+}
+
+// Download streams a consistent backup of the node's database, which can be restored
+// with 'ssvnode db restore' while the node is stopped.
+func (h *Backup) Download(w http.ResponseWriter, r *http.Request) error {
+	// Large databases take longer than the server's write timeout.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("could not disable write deadline: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="ssv-backup-%s.bin"`, time.Now().UTC().Format("20060102-150405")))
+
+	bw := &backupWriter{w: w}
+	if _, _, err := operatorstorage.Backup(h.DB, bw); err != nil {
+		if !bw.written {
+			return err
+		}
+		// The response is already underway, so abort it to tell the client that the backup is incomplete.
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
+// backupWriter tracks whether anything was written to the response.
+type backupWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (b *backupWriter) Write(p []byte) (int, error) {
+	b.written = true
+	return b.w.Write(p)
+}
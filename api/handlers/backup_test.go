@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/logging"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestBackup(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	h := &Backup{DB: db}
+	server := httptest.NewServer(api.Handler(h.Download))
+	defer server.Close()
+
+	// The node must have been started.
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+	config := &operatorstorage.ConfigLock{NetworkName: "holesky"}
+	require.NoError(t, nodeStorage.SaveConfig(nil, config))
+	require.NoError(t, nodeStorage.SavePrivateKeyHash("hash"))
+
+	resp, err = http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/octet-stream", resp.Header.Get("Content-Type"))
+
+	target, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer target.Close()
+	_, count, err := operatorstorage.Restore(target, resp.Body, config, "hash")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/api"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+type Clusters struct {
+	Shares     registrystorage.Shares
+	Recipients registrystorage.Recipients
+	History    RegistryHistory
+}
+
+type clusterJSON struct {
+	Owner        api.Hex                `json:"owner"`
+	Operators    []spectypes.OperatorID `json:"operators"`
+	Validators   int                    `json:"validators"`
+	Liquidated   bool                   `json:"liquidated"`
+	FeeRecipient api.Hex                `json:"fee_recipient"`
+
+	// BuilderPolicy is the effective builder policy of the cluster, omitted if it's left to the beacon node.
+	BuilderPolicy *builderPolicyJSON `json:"builder_policy,omitempty"`
+}
+
+type builderPolicyJSON struct {
+	Mode   string `json:"mode"`
+	MinBid string `json:"min_bid,omitempty"`
+}
+
+func newBuilderPolicyJSON(policy *beacon.BuilderPolicy) *builderPolicyJSON {
+	policyJSON := &builderPolicyJSON{Mode: string(policy.Mode)}
+	if policy.MinBid != nil {
+		policyJSON.MinBid = policy.MinBid.String()
+	}
+	return policyJSON
+}
+
+// List returns the clusters which have validators, with the fee recipients of their owners.
+// With at_block, it returns them as they were at the end of the given block.
+func (h *Clusters) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Owners    api.HexSlice    `json:"owners" form:"owners"`
+		Operators api.Uint64Slice `json:"operators" form:"operators"`
+		Clusters  requestClusters `json:"clusters" form:"clusters"`
+		AtBlock   requestBlock    `json:"at_block" form:"at_block"`
+	}
+	var response struct {
+		Data []*clusterJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	var filters []registrystorage.SharesFilter
+	if len(request.Owners) > 0 {
+		filters = append(filters, byOwners(request.Owners))
+	}
+	if len(request.Operators) > 0 {
+		filters = append(filters, byOperators(request.Operators))
+	}
+	if len(request.Clusters) > 0 {
+		filters = append(filters, byClusters(request.Clusters, false))
+	}
+
+	var shares []*types.SSVShare
+	if request.AtBlock.Set {
+		var err error
+		shares, err = sharesAtBlock(h.History, request.AtBlock.Number, filters...)
+		if err != nil {
+			return err
+		}
+	} else {
+		shares = h.Shares.List(nil, filters...)
+	}
+
+	clusters := make(map[string]*clusterJSON)
+	var owners []ethcommon.Address
+	for _, share := range shares {
+		operatorIDs := make([]spectypes.OperatorID, len(share.Committee))
+		for i, operator := range share.Committee {
+			operatorIDs[i] = operator.OperatorID
+		}
+		clusterID := string(types.ComputeClusterIDHash(share.OwnerAddress, operatorIDs))
+		cluster, ok := clusters[clusterID]
+		if !ok {
+			cluster = &clusterJSON{
+				Owner:     share.OwnerAddress.Bytes(),
+				Operators: operatorIDs,
+			}
+			clusters[clusterID] = cluster
+			owners = append(owners, share.OwnerAddress)
+		}
+		cluster.Validators++
+		cluster.Liquidated = cluster.Liquidated || share.Liquidated
+	}
+
+	feeRecipients, err := h.feeRecipients(owners, request.AtBlock)
+	if err != nil {
+		return err
+	}
+	response.Data = make([]*clusterJSON, 0, len(clusters))
+	for _, cluster := range clusters {
+		owner := ethcommon.BytesToAddress(cluster.Owner)
+		if feeRecipient, ok := feeRecipients[owner]; ok {
+			cluster.FeeRecipient = feeRecipient[:]
+		} else {
+			// Owners who didn't set a fee recipient receive the fees themselves.
+			cluster.FeeRecipient = owner.Bytes()
+		}
+		if !request.AtBlock.Set {
+			// Builder policies are local preferences, so they're reported only for the current registry.
+			policy, err := h.Recipients.GetBuilderPolicy(nil, owner, cluster.Operators)
+			if err != nil {
+				return api.Error(fmt.Errorf("failed to get builder policy: %w", err))
+			}
+			if policy != nil && policy.Mode != beacon.BuilderModeDefault {
+				cluster.BuilderPolicy = newBuilderPolicyJSON(policy)
+			}
+		}
+		response.Data = append(response.Data, cluster)
+	}
+	sort.Slice(response.Data, func(i, j int) bool {
+		if c := bytes.Compare(response.Data[i].Owner, response.Data[j].Owner); c != 0 {
+			return c < 0
+		}
+		return lessOperators(response.Data[i].Operators, response.Data[j].Operators)
+	})
+	return api.Render(w, r, response)
+}
+
+// feeRecipients returns the fee recipients which the given owners set, as of the given block if it's set.
+func (h *Clusters) feeRecipients(owners []ethcommon.Address, atBlock requestBlock) (map[ethcommon.Address]bellatrix.ExecutionAddress, error) {
+	if !atBlock.Set {
+		feeRecipients, err := h.Recipients.GetRecipientDataMany(nil, owners)
+		if err != nil {
+			return nil, api.Error(fmt.Errorf("failed to get fee recipients: %w", err))
+		}
+		return feeRecipients, nil
+	}
+
+	versions, err := h.History.GetRecipientsAtBlock(nil, atBlock.Number)
+	if errors.Is(err, operatorstorage.ErrRegistryHistoryUnavailable) {
+		return nil, api.InvalidRequestError(err)
+	}
+	if err != nil {
+		return nil, api.Error(fmt.Errorf("failed to get registry history: %w", err))
+	}
+	feeRecipients := make(map[ethcommon.Address]bellatrix.ExecutionAddress, len(versions))
+	for _, version := range versions {
+		feeRecipients[version.Owner] = version.FeeRecipient
+	}
+	return feeRecipients, nil
+}
+
+func lessOperators(a, b []spectypes.OperatorID) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+type builderPolicyRequest struct {
+	Owner     api.Hex         `json:"owner" form:"owner"`
+	Operators api.Uint64Slice `json:"operators" form:"operators"`
+	Mode      string          `json:"mode" form:"mode"`
+	MinBid    string          `json:"min_bid" form:"min_bid"`
+}
+
+func (req *builderPolicyRequest) owner() (ethcommon.Address, error) {
+	if len(req.Owner) != ethcommon.AddressLength {
+		return ethcommon.Address{}, fmt.Errorf("owner must be a %d-byte address", ethcommon.AddressLength)
+	}
+	return ethcommon.BytesToAddress(req.Owner), nil
+}
+
+// SetBuilderPolicy sets the builder policy of a cluster, or of all the owner's clusters if no operators are given.
+// Cluster policies take precedence over the owner's policy.
+func (h *Clusters) SetBuilderPolicy(w http.ResponseWriter, r *http.Request) error {
+	var request builderPolicyRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	owner, err := request.owner()
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	policy := &beacon.BuilderPolicy{Mode: beacon.BuilderMode(request.Mode)}
+	if request.MinBid != "" {
+		minBid, ok := new(big.Int).SetString(request.MinBid, 10)
+		if !ok {
+			return api.InvalidRequestError(fmt.Errorf("min_bid must be a decimal amount of wei"))
+		}
+		policy.MinBid = minBid
+	}
+	if err := policy.Validate(); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	if err := h.Recipients.SaveBuilderPolicy(nil, owner, request.Operators, policy); err != nil {
+		return api.Error(fmt.Errorf("failed to save builder policy: %w", err))
+	}
+	return api.Render(w, r, newBuilderPolicyJSON(policy))
+}
+
+// DeleteBuilderPolicy removes the builder policy of a cluster, or of the owner if no operators are given.
+func (h *Clusters) DeleteBuilderPolicy(w http.ResponseWriter, r *http.Request) error {
+	var request builderPolicyRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	owner, err := request.owner()
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	if err := h.Recipients.SaveBuilderPolicy(nil, owner, request.Operators, nil); err != nil {
+		return api.Error(fmt.Errorf("failed to delete builder policy: %w", err))
+	}
+	return api.Render(w, r, struct{}{})
+}
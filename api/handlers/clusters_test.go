@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/logging"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestClustersAtBlock(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	owner := common.Address{1}
+	otherOwner := common.Address{2}
+	pubKey := func(b byte) []byte {
+		pk := make([]byte, 48)
+		pk[0] = b
+		return pk
+	}
+
+	// The current registry.
+	share := mockShare(1, 2, 3, 4)
+	share.ValidatorPubKey = pubKey(1)
+	share.OwnerAddress = owner
+	otherShare := mockShare(1, 2, 3, 5)
+	otherShare.ValidatorPubKey = pubKey(3)
+	otherShare.OwnerAddress = otherOwner
+	require.NoError(t, nodeStorage.Shares().Save(nil, share, otherShare))
+	_, err = nodeStorage.SaveRecipientData(nil, &registrystorage.RecipientData{Owner: owner, FeeRecipient: [20]byte{0x22}})
+	require.NoError(t, err)
+
+	// Its history: a second validator was added at block 20 and removed at block 30,
+	// and the owner's fee recipient was set at block 15 and changed at block 25.
+	require.NoError(t, nodeStorage.SaveRegistryHistoryStart(nil, 10))
+	for _, version := range []*operatorstorage.ShareVersion{
+		{ValidatorPubKey: pubKey(1), BlockNumber: 10, Owner: owner, OperatorIDs: []uint64{1, 2, 3, 4}},
+		{ValidatorPubKey: pubKey(3), BlockNumber: 10, Owner: otherOwner, OperatorIDs: []uint64{1, 2, 3, 5}},
+		{ValidatorPubKey: pubKey(2), BlockNumber: 20, Owner: owner, OperatorIDs: []uint64{1, 2, 3, 4}},
+		{ValidatorPubKey: pubKey(2), BlockNumber: 30, Owner: owner, OperatorIDs: []uint64{1, 2, 3, 4}, Removed: true},
+	} {
+		require.NoError(t, nodeStorage.SaveShareVersion(nil, version))
+	}
+	for _, version := range []*operatorstorage.RecipientVersion{
+		{Owner: owner, BlockNumber: 15, FeeRecipient: [20]byte{0x11}},
+		{Owner: owner, BlockNumber: 25, FeeRecipient: [20]byte{0x22}},
+	} {
+		require.NoError(t, nodeStorage.SaveRecipientVersion(nil, version))
+	}
+
+	clusters := &Clusters{Shares: nodeStorage.Shares(), Recipients: nodeStorage, History: nodeStorage}
+	validators := &Validators{Shares: nodeStorage.Shares(), History: nodeStorage}
+	get := func(handler api.HandlerFunc, query string) (int, []map[string]any) {
+		w := httptest.NewRecorder()
+		api.Handler(handler)(w, httptest.NewRequest(http.MethodGet, "/?"+query, nil))
+		var response struct {
+			Data []map[string]any `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+	feeRecipient := func(b byte) string {
+		return common.Bytes2Hex(common.Address{b}.Bytes())
+	}
+
+	code, data := get(clusters.List, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data, 2)
+	require.Equal(t, float64(1), data[0]["validators"])
+	require.Equal(t, feeRecipient(0x22), data[0]["fee_recipient"])
+	require.Equal(t, feeRecipient(2), data[1]["fee_recipient"], "defaults to the owner")
+
+	code, data = get(clusters.List, "at_block=20&clusters=1,2,3,4")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data, 1)
+	require.Equal(t, []any{float64(1), float64(2), float64(3), float64(4)}, data[0]["operators"])
+	require.Equal(t, float64(2), data[0]["validators"])
+	require.Equal(t, feeRecipient(0x11), data[0]["fee_recipient"])
+
+	code, data = get(clusters.List, "at_block=30&owners="+common.Bytes2Hex(owner.Bytes()))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data, 1)
+	require.Equal(t, float64(1), data[0]["validators"])
+	require.Equal(t, feeRecipient(0x22), data[0]["fee_recipient"])
+
+	code, data = get(validators.List, "at_block=25&operators=4")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data, 2)
+
+	code, _ = get(validators.List, "at_block=5")
+	require.Equal(t, http.StatusBadRequest, code, "history starts at block 10")
+}
+
+func TestClustersBuilderPolicy(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	owner := common.Address{1}
+	share := mockShare(1, 2, 3, 4)
+	share.OwnerAddress = owner
+	otherShare := mockShare(1, 2, 3, 5)
+	otherShare.ValidatorPubKey = append([]byte{2}, make([]byte, 47)...)
+	otherShare.OwnerAddress = owner
+	require.NoError(t, nodeStorage.Shares().Save(nil, share, otherShare))
+
+	clusters := &Clusters{Shares: nodeStorage.Shares(), Recipients: nodeStorage, History: nodeStorage}
+	call := func(handler api.HandlerFunc, method, query string) (int, string) {
+		w := httptest.NewRecorder()
+		api.Handler(handler)(w, httptest.NewRequest(method, "/?"+query, nil))
+		return w.Code, w.Body.String()
+	}
+	policies := func() []any {
+		code, body := call(clusters.List, http.MethodGet, "")
+		require.Equal(t, http.StatusOK, code)
+		var response struct {
+			Data []map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &response))
+		var policies []any
+		for _, cluster := range response.Data {
+			policies = append(policies, cluster["builder_policy"])
+		}
+		return policies
+	}
+	ownerHex := common.Bytes2Hex(owner.Bytes())
+
+	require.Equal(t, []any{nil, nil}, policies())
+
+	code, _ := call(clusters.SetBuilderPolicy, http.MethodPut, "owner="+ownerHex+"&mode=local")
+	require.Equal(t, http.StatusOK, code)
+	code, body := call(clusters.SetBuilderPolicy, http.MethodPut, "owner="+ownerHex+"&operators=1,2,3,5&mode=min-bid&min_bid=100000000000000000")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"mode":"min-bid","min_bid":"100000000000000000"}`, body)
+	require.Equal(t, []any{
+		map[string]any{"mode": "local"},
+		map[string]any{"mode": "min-bid", "min_bid": "100000000000000000"},
+	}, policies())
+
+	code, _ = call(clusters.DeleteBuilderPolicy, http.MethodDelete, "owner="+ownerHex)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []any{nil, map[string]any{"mode": "min-bid", "min_bid": "100000000000000000"}}, policies())
+
+	code, _ = call(clusters.SetBuilderPolicy, http.MethodPut, "owner="+ownerHex+"&mode=min-bid")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = call(clusters.SetBuilderPolicy, http.MethodPut, "owner="+ownerHex+"&mode=min-bid&min_bid=0.1")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = call(clusters.SetBuilderPolicy, http.MethodPut, "owner=01&mode=local")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestLessOperators(t *testing.T) {
+	require.True(t, lessOperators([]spectypes.OperatorID{1, 2, 3}, []spectypes.OperatorID{1, 2, 4}))
+	require.True(t, lessOperators([]spectypes.OperatorID{1, 2}, []spectypes.OperatorID{1, 2, 3}))
+	require.False(t, lessOperators([]spectypes.OperatorID{2}, []spectypes.OperatorID{1, 5}))
+}
@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/api"
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/operator/duties/dutystore"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+// maxHistorySlots limits the slot range of a single history or participation request,
+// since every slot is looked up separately for every share and role.
+const maxHistorySlots = 64
+
+// historyRoles are the roles which have QBFT instances to look up in history.
+var historyRoles = []spectypes.BeaconRole{
+	spectypes.BNRoleAttester,
+	spectypes.BNRoleAggregator,
+	spectypes.BNRoleProposer,
+	spectypes.BNRoleSyncCommittee,
+	spectypes.BNRoleSyncCommitteeContribution,
+	spectypes.BNRoleValidatorRegistration,
+	spectypes.BNRoleVoluntaryExit,
+}
+
+type Duties struct {
+	Network           networkconfig.NetworkConfig
+	DutyStore         *dutystore.Store
+	Storage           *ibftstorage.QBFTStores
+	Shares            registrystorage.Shares
+	OperatorDataStore datastore.OperatorDataStore
+}
+
+type dutyJSON struct {
+	Role    string                 `json:"role"`
+	PubKey  api.Hex                `json:"public_key"`
+	Index   phase0.ValidatorIndex  `json:"index"`
+	Epoch   phase0.Epoch           `json:"epoch"`
+	Slot    phase0.Slot            `json:"slot"`
+	Decided bool                   `json:"decided"`
+	Round   specqbft.Round         `json:"round,omitempty"`
+	Signers []spectypes.OperatorID `json:"signers,omitempty"`
+}
+
+type dutiesRequest struct {
+	Roles   requestRoles    `json:"roles" form:"roles"`
+	PubKeys api.HexSlice    `json:"pubkeys" form:"pubkeys"`
+	Indices api.Uint64Slice `json:"indices" form:"indices"`
+	From    phase0.Slot     `json:"from" form:"from"`
+	To      phase0.Slot     `json:"to" form:"to"`
+}
+
+// matches reports whether the given duty passes the request's filters.
+// A zero To is treated as no upper bound.
+func (r *dutiesRequest) matches(role spectypes.BeaconRole, pubKey []byte, index phase0.ValidatorIndex, slot phase0.Slot) bool {
+	if slot < r.From || (r.To != 0 && slot > r.To) {
+		return false
+	}
+	if len(r.Roles) > 0 && !r.Roles.contains(role) {
+		return false
+	}
+	if len(r.PubKeys) > 0 && !containsHex(r.PubKeys, pubKey) {
+		return false
+	}
+	if len(r.Indices) > 0 && !containsUint64(r.Indices, uint64(index)) {
+		return false
+	}
+	return true
+}
+
+// boundRange defaults the request's slot range to the last maxHistorySlots slots up to the current slot,
+// and returns an error if it's wider than maxHistorySlots.
+func (r *dutiesRequest) boundRange(currentSlot phase0.Slot) error {
+	if r.To == 0 {
+		r.To = currentSlot
+	}
+	if r.From == 0 && r.To >= maxHistorySlots {
+		r.From = r.To - maxHistorySlots + 1
+	}
+	if r.From > r.To {
+		return fmt.Errorf("from slot %d is after to slot %d", r.From, r.To)
+	}
+	if r.To-r.From >= maxHistorySlots {
+		return fmt.Errorf("slot range must not exceed %d slots", maxHistorySlots)
+	}
+	return nil
+}
+
+// Current lists the duties of this operator's validators for the current and next epoch,
+// along with whether each of them has already reached a decided QBFT instance.
+func (h *Duties) Current(w http.ResponseWriter, r *http.Request) error {
+	var request dutiesRequest
+	var response struct {
+		Data []*dutyJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	currentEpoch := h.Network.Beacon.EstimatedCurrentEpoch()
+	response.Data = []*dutyJSON{}
+	for _, epoch := range []phase0.Epoch{currentEpoch, currentEpoch + 1} {
+		duties, err := h.epochDuties(&request, epoch)
+		if err != nil {
+			return err
+		}
+		response.Data = append(response.Data, duties...)
+	}
+	sortDuties(response.Data)
+
+	return api.Render(w, r, response)
+}
+
+// History lists the duties of this operator's validators which reached a QBFT instance
+// within the given slot range. Light nodes only persist the highest instance, so their
+// history is limited to the latest instance of each validator and role.
+func (h *Duties) History(w http.ResponseWriter, r *http.Request) error {
+	var request dutiesRequest
+	var response struct {
+		Data []*dutyJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if err := request.boundRange(h.Network.Beacon.EstimatedCurrentSlot()); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	roles := historyRoles
+	if len(request.Roles) > 0 {
+		roles = request.Roles
+	}
+
+	filters := []registrystorage.SharesFilter{registrystorage.ByOperatorID(h.OperatorDataStore.GetOperatorID())}
+	if len(request.PubKeys) > 0 {
+		filters = append(filters, byPubKeys(request.PubKeys))
+	}
+	if len(request.Indices) > 0 {
+		filters = append(filters, byIndices(request.Indices))
+	}
+
+	response.Data = []*dutyJSON{}
+	for _, share := range h.Shares.List(nil, filters...) {
+		var index phase0.ValidatorIndex
+		if share.HasBeaconMetadata() {
+			index = share.BeaconMetadata.Index
+		}
+		for _, role := range roles {
+			instances, err := h.instancesInRange(role, share.ValidatorPubKey, request.From, request.To)
+			if err != nil {
+				return err
+			}
+			for _, instance := range instances {
+				slot := phase0.Slot(instance.State.Height)
+				duty := &dutyJSON{
+					Role:   role.String(),
+					PubKey: api.Hex(share.ValidatorPubKey),
+					Index:  index,
+					Epoch:  h.Network.Beacon.EstimatedEpochAtSlot(slot),
+					Slot:   slot,
+				}
+				setDecided(duty, instance)
+				response.Data = append(response.Data, duty)
+			}
+		}
+	}
+	sortDuties(response.Data)
+
+	return api.Render(w, r, response)
+}
+
+func (h *Duties) epochDuties(request *dutiesRequest, epoch phase0.Epoch) ([]*dutyJSON, error) {
+	var duties []*dutyJSON
+	add := func(role spectypes.BeaconRole, pubKey phase0.BLSPubKey, index phase0.ValidatorIndex, slot phase0.Slot) error {
+		if !request.matches(role, pubKey[:], index, slot) {
+			return nil
+		}
+		duty := &dutyJSON{
+			Role:   role.String(),
+			PubKey: api.Hex(pubKey[:]),
+			Index:  index,
+			Epoch:  epoch,
+			Slot:   slot,
+		}
+		instance, err := h.instance(role, pubKey[:], slot)
+		if err != nil {
+			return err
+		}
+		setDecided(duty, instance)
+		duties = append(duties, duty)
+		return nil
+	}
+
+	for _, d := range h.DutyStore.Attester.CommitteeEpochDuties(epoch) {
+		if err := add(spectypes.BNRoleAttester, d.PubKey, d.ValidatorIndex, d.Slot); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range h.DutyStore.Proposer.CommitteeEpochDuties(epoch) {
+		if err := add(spectypes.BNRoleProposer, d.PubKey, d.ValidatorIndex, d.Slot); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sync committee duties are assigned per period, but performed at every slot of it.
+	period := h.Network.Beacon.EstimatedSyncCommitteePeriodAtEpoch(epoch)
+	firstSlot := h.Network.Beacon.GetEpochFirstSlot(epoch)
+	for _, d := range h.DutyStore.SyncCommittee.CommitteePeriodDuties(period) {
+		for slot := firstSlot; slot < firstSlot+phase0.Slot(h.Network.SlotsPerEpoch()); slot++ {
+			if err := add(spectypes.BNRoleSyncCommittee, d.PubKey, d.ValidatorIndex, slot); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return duties, nil
+}
+
+// instance returns the stored QBFT instance for the given duty, or nil if there is none.
+func (h *Duties) instance(role spectypes.BeaconRole, pubKey []byte, slot phase0.Slot) (*qbftstorage.StoredInstance, error) {
+	store := h.Storage.Get(role)
+	if store == nil {
+		return nil, nil
+	}
+	identifier := spectypes.NewMsgID(h.Network.Domain, pubKey, role)
+	instance, err := store.GetInstance(identifier[:], specqbft.Height(slot))
+	if err != nil || instance != nil {
+		return instance, err
+	}
+
+	// Light nodes only persist the highest instance.
+	highest, err := store.GetHighestInstance(identifier[:])
+	if err != nil {
+		return nil, err
+	}
+	if highest != nil && highest.State.Height == specqbft.Height(slot) {
+		return highest, nil
+	}
+	return nil, nil
+}
+
+// instancesInRange returns the stored QBFT instances of the given validator and role within the slot range.
+func (h *Duties) instancesInRange(role spectypes.BeaconRole, pubKey []byte, from, to phase0.Slot) ([]*qbftstorage.StoredInstance, error) {
+	store := h.Storage.Get(role)
+	if store == nil {
+		return nil, nil
+	}
+	identifier := spectypes.NewMsgID(h.Network.Domain, pubKey, role)
+	return storedInstances(store, identifier[:], from, to)
+}
+
+// storedInstances returns both the historical and the highest instances of the given identifier
+// within the slot range. The highest instance is the only one persisted by light nodes.
+func storedInstances(store qbftstorage.QBFTStore, identifier []byte, from, to phase0.Slot) ([]*qbftstorage.StoredInstance, error) {
+	inRange := func(instance *qbftstorage.StoredInstance) bool {
+		return instance.State.Height >= specqbft.Height(from) && instance.State.Height <= specqbft.Height(to)
+	}
+
+	instances, err := store.GetInstancesInRange(identifier, specqbft.Height(from), specqbft.Height(to))
+	if err != nil {
+		return nil, err
+	}
+
+	highest, err := store.GetHighestInstance(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if highest == nil || !inRange(highest) {
+		return instances, nil
+	}
+	for _, instance := range instances {
+		if instance.State.Height == highest.State.Height {
+			return instances, nil
+		}
+	}
+	return append(instances, highest), nil
+}
+
+func setDecided(duty *dutyJSON, instance *qbftstorage.StoredInstance) {
+	if instance == nil || instance.DecidedMessage == nil {
+		return
+	}
+	duty.Decided = true
+	duty.Round = instance.DecidedMessage.Message.Round
+	duty.Signers = instance.DecidedMessage.Signers
+}
+
+func sortDuties(duties []*dutyJSON) {
+	sort.SliceStable(duties, func(i, j int) bool {
+		if duties[i].Slot != duties[j].Slot {
+			return duties[i].Slot < duties[j].Slot
+		}
+		if duties[i].Role != duties[j].Role {
+			return duties[i].Role < duties[j].Role
+		}
+		return duties[i].Index < duties[j].Index
+	})
+}
+
+func containsHex(list []api.Hex, value []byte) bool {
+	for _, h := range list {
+		if bytes.Equal(h, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUint64(list []uint64, value uint64) bool {
+	for _, n := range list {
+		if n == value {
+			return true
+		}
+	}
+	return false
+}
+
+// requestRoles is a comma-separated list of beacon role names, such as "ATTESTER,PROPOSER".
+type requestRoles []spectypes.BeaconRole
+
+func (rr *requestRoles) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, s := range strings.Split(value, ",") {
+		role, err := message.BeaconRoleFromString(strings.ToUpper(s))
+		if err != nil {
+			return err
+		}
+		*rr = append(*rr, role)
+	}
+	return nil
+}
+
+func (rr requestRoles) contains(role spectypes.BeaconRole) bool {
+	for _, r := range rr {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/operator/duties/dutystore"
+	beacon "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestDutiesCurrent(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	network := networkconfig.TestNetwork
+	epoch := network.Beacon.EstimatedCurrentEpoch()
+	slot := network.Beacon.GetEpochFirstSlot(epoch)
+
+	decidedPubKey := phase0.BLSPubKey{1}
+	pendingPubKey := phase0.BLSPubKey{2}
+
+	store := dutystore.New()
+	store.Attester.Add(epoch, slot, 1, &eth2apiv1.AttesterDuty{PubKey: decidedPubKey, Slot: slot, ValidatorIndex: 1}, true)
+	store.Attester.Add(epoch, slot, 2, &eth2apiv1.AttesterDuty{PubKey: pendingPubKey, Slot: slot, ValidatorIndex: 2}, true)
+	store.Attester.Add(epoch, slot, 3, &eth2apiv1.AttesterDuty{PubKey: phase0.BLSPubKey{3}, Slot: slot, ValidatorIndex: 3}, false)
+	store.Proposer.Add(epoch+1, slot+phase0.Slot(network.SlotsPerEpoch()), 2, &eth2apiv1.ProposerDuty{PubKey: pendingPubKey, Slot: slot + phase0.Slot(network.SlotsPerEpoch()), ValidatorIndex: 2}, true)
+
+	storageMap := ibftstorage.NewStoresFromRoles(db, spectypes.BNRoleAttester, spectypes.BNRoleProposer)
+	identifier := spectypes.NewMsgID(network.Domain, decidedPubKey[:], spectypes.BNRoleAttester)
+	require.NoError(t, storageMap.Get(spectypes.BNRoleAttester).SaveHighestInstance(&qbftstorage.StoredInstance{
+		State: &specqbft.State{
+			ID:     identifier[:],
+			Height: specqbft.Height(slot),
+		},
+		DecidedMessage: &specqbft.SignedMessage{
+			Signers: []spectypes.OperatorID{1, 2, 3},
+			Message: specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     specqbft.Height(slot),
+				Round:      specqbft.FirstRound,
+				Identifier: identifier[:],
+			},
+		},
+	}))
+
+	h := &Duties{
+		Network:   network,
+		DutyStore: store,
+		Storage:   storageMap,
+	}
+
+	request := func(query string) []dutyJSON {
+		r := httptest.NewRequest(http.MethodGet, "/v1/duties"+query, nil)
+		w := httptest.NewRecorder()
+		require.NoError(t, h.Current(w, r))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []dutyJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	duties := request("")
+	require.Len(t, duties, 3)
+	require.Equal(t, "ATTESTER", duties[0].Role)
+	require.Equal(t, phase0.ValidatorIndex(1), duties[0].Index)
+	require.True(t, duties[0].Decided)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3}, duties[0].Signers)
+	require.Equal(t, phase0.ValidatorIndex(2), duties[1].Index)
+	require.False(t, duties[1].Decided)
+	require.Equal(t, "PROPOSER", duties[2].Role)
+	require.Equal(t, epoch+1, duties[2].Epoch)
+
+	duties = request("?roles=proposer")
+	require.Len(t, duties, 1)
+	require.Equal(t, "PROPOSER", duties[0].Role)
+
+	duties = request("?indices=1")
+	require.Len(t, duties, 1)
+	require.Equal(t, phase0.ValidatorIndex(1), duties[0].Index)
+
+	duties = request("?to=" + strconv.FormatUint(uint64(slot), 10))
+	require.Len(t, duties, 2)
+}
+
+func TestDutiesRequestBoundRange(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from, to phase0.Slot
+		current  phase0.Slot
+		expected [2]phase0.Slot
+		err      string
+	}{
+		{name: "default", current: 1000, expected: [2]phase0.Slot{1000 - maxHistorySlots + 1, 1000}},
+		{name: "default near genesis", current: 10, expected: [2]phase0.Slot{0, 10}},
+		{name: "to only", to: 500, current: 1000, expected: [2]phase0.Slot{500 - maxHistorySlots + 1, 500}},
+		{name: "full range", from: 100, to: 100 + maxHistorySlots - 1, current: 1000, expected: [2]phase0.Slot{100, 100 + maxHistorySlots - 1}},
+		{name: "too wide", from: 100, to: 100 + maxHistorySlots, current: 1000, err: "slot range must not exceed"},
+		{name: "from without to", from: 100, current: 1000, err: "slot range must not exceed"},
+		{name: "reversed", from: 200, to: 100, current: 1000, err: "from slot 200 is after to slot 100"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := dutiesRequest{From: tc.from, To: tc.to}
+			err := request.boundRange(tc.current)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, [2]phase0.Slot{request.From, request.To})
+		})
+	}
+}
+
+func TestDutiesHistory(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	network := networkconfig.TestNetwork
+	currentSlot := network.Beacon.EstimatedCurrentSlot()
+
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	ownPubKey := phase0.BLSPubKey{1}
+	otherPubKey := phase0.BLSPubKey{2}
+	for i, pubKey := range []phase0.BLSPubKey{ownPubKey, otherPubKey} {
+		pubKey := pubKey
+		require.NoError(t, shares.Save(nil, &types.SSVShare{
+			Share: spectypes.Share{
+				OperatorID:      spectypes.OperatorID(i + 1),
+				ValidatorPubKey: pubKey[:],
+				SharePubKey:     []byte{byte(i + 1)},
+				Committee:       []*spectypes.Operator{{OperatorID: spectypes.OperatorID(i + 1)}},
+			},
+			Metadata: types.Metadata{
+				BeaconMetadata: &beacon.ValidatorMetadata{Index: phase0.ValidatorIndex(i + 1)},
+			},
+		}))
+	}
+
+	storageMap := ibftstorage.NewStoresFromRoles(db, spectypes.BNRoleAttester, spectypes.BNRoleProposer)
+	saveInstance := func(role spectypes.BeaconRole, pubKey phase0.BLSPubKey, slot phase0.Slot, highest bool) {
+		identifier := spectypes.NewMsgID(network.Domain, pubKey[:], role)
+		instance := &qbftstorage.StoredInstance{
+			State: &specqbft.State{
+				ID:     identifier[:],
+				Height: specqbft.Height(slot),
+			},
+			DecidedMessage: &specqbft.SignedMessage{
+				Signers: []spectypes.OperatorID{1, 2, 3},
+				Message: specqbft.Message{
+					MsgType:    specqbft.CommitMsgType,
+					Height:     specqbft.Height(slot),
+					Round:      specqbft.FirstRound,
+					Identifier: identifier[:],
+				},
+			},
+		}
+		if highest {
+			require.NoError(t, storageMap.Get(role).SaveHighestInstance(instance))
+			return
+		}
+		require.NoError(t, storageMap.Get(role).SaveInstance(instance))
+	}
+	oldSlot := currentSlot - maxHistorySlots
+	recentSlot := currentSlot - 10
+	highestSlot := currentSlot - 1
+	saveInstance(spectypes.BNRoleAttester, ownPubKey, oldSlot, false)
+	saveInstance(spectypes.BNRoleAttester, ownPubKey, recentSlot, false)
+	saveInstance(spectypes.BNRoleAttester, ownPubKey, highestSlot, true)
+	saveInstance(spectypes.BNRoleProposer, ownPubKey, recentSlot, false)
+	saveInstance(spectypes.BNRoleAttester, otherPubKey, recentSlot, false)
+
+	h := &Duties{
+		Network:           network,
+		Storage:           storageMap,
+		Shares:            shares,
+		OperatorDataStore: datastore.New(&registrystorage.OperatorData{ID: 1}),
+	}
+
+	request := func(query string) []dutyJSON {
+		r := httptest.NewRequest(http.MethodGet, "/v1/duties/history"+query, nil)
+		w := httptest.NewRecorder()
+		require.NoError(t, h.History(w, r))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []dutyJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+	formatSlot := func(slot phase0.Slot) string {
+		return strconv.FormatUint(uint64(slot), 10)
+	}
+
+	// By default, only the last maxHistorySlots slots are listed, so the old instance is left out.
+	duties := request("")
+	require.Len(t, duties, 3)
+	require.Equal(t, "ATTESTER", duties[0].Role)
+	require.Equal(t, recentSlot, duties[0].Slot)
+	require.Equal(t, phase0.ValidatorIndex(1), duties[0].Index)
+	require.Equal(t, api.Hex(ownPubKey[:]), duties[0].PubKey)
+	require.True(t, duties[0].Decided)
+	require.Equal(t, specqbft.FirstRound, duties[0].Round)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3}, duties[0].Signers)
+	require.Equal(t, "PROPOSER", duties[1].Role)
+	require.Equal(t, recentSlot, duties[1].Slot)
+	require.Equal(t, "ATTESTER", duties[2].Role)
+	require.Equal(t, highestSlot, duties[2].Slot)
+	require.Equal(t, network.Beacon.EstimatedEpochAtSlot(highestSlot), duties[2].Epoch)
+
+	// A range which ends earlier defaults to start maxHistorySlots before its end.
+	duties = request("?to=" + formatSlot(recentSlot))
+	require.Len(t, duties, 3)
+	require.Equal(t, oldSlot, duties[0].Slot)
+
+	duties = request("?from=" + formatSlot(oldSlot) + "&to=" + formatSlot(oldSlot))
+	require.Len(t, duties, 1)
+	require.Equal(t, oldSlot, duties[0].Slot)
+
+	duties = request("?roles=proposer")
+	require.Len(t, duties, 1)
+	require.Equal(t, "PROPOSER", duties[0].Role)
+
+	duties = request("?pubkeys=" + hex.EncodeToString(otherPubKey[:]))
+	require.Empty(t, duties)
+
+	for _, query := range []string{
+		"?from=" + formatSlot(recentSlot) + "&to=" + formatSlot(oldSlot),
+		"?from=" + formatSlot(oldSlot-1) + "&to=" + formatSlot(currentSlot),
+		"?from=" + formatSlot(oldSlot-maxHistorySlots),
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/v1/duties/history"+query, nil)
+		err := h.History(httptest.NewRecorder(), r)
+		var errResponse *api.ErrorResponse
+		require.True(t, errors.As(err, &errResponse), query)
+		require.Equal(t, http.StatusBadRequest, errResponse.Code, query)
+	}
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/operator/validatorevents"
+)
+
+// eventsKeepAliveInterval is the interval at which comments are sent to idle
+// event streams, so that proxies don't close them.
+const eventsKeepAliveInterval = 15 * time.Second
+
+type Events struct {
+	Feed *validatorevents.Feed
+}
+
+type eventJSON struct {
+	Type      validatorevents.Type   `json:"type"`
+	Time      time.Time              `json:"time"`
+	PubKey    api.Hex                `json:"public_key"`
+	Owner     api.Hex                `json:"owner"`
+	Committee []spectypes.OperatorID `json:"committee"`
+	Status    string                 `json:"status,omitempty"`
+}
+
+// Stream streams validator lifecycle events as Server-Sent Events until the client disconnects.
+func (h *Events) Stream(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Types    requestEventTypes `json:"types" form:"types"`
+		Owners   api.HexSlice      `json:"owners" form:"owners"`
+		Clusters requestClusters   `json:"clusters" form:"clusters"`
+		PubKeys  api.HexSlice      `json:"pubkeys" form:"pubkeys"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	matches := func(event validatorevents.Event) bool {
+		if len(request.Types) > 0 && !request.Types.contains(event.Type) {
+			return false
+		}
+		if len(request.Owners) > 0 && !containsHex(request.Owners, event.Owner[:]) {
+			return false
+		}
+		if len(request.Clusters) > 0 && !request.Clusters.contains(event.Committee) {
+			return false
+		}
+		if len(request.PubKeys) > 0 && !containsHex(request.PubKeys, event.PubKey) {
+			return false
+		}
+		return true
+	}
+
+	// Streams outlive the server's write timeout.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("could not disable write deadline: %w", err)
+	}
+
+	events, unsubscribe := h.Feed.Subscribe(validatorevents.DefaultBufferSize)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return nil
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !matches(event) {
+				continue
+			}
+			data, err := json.Marshal(eventJSON{
+				Type:      event.Type,
+				Time:      event.Time,
+				PubKey:    api.Hex(event.PubKey),
+				Owner:     api.Hex(event.Owner[:]),
+				Committee: event.Committee,
+				Status:    event.Status,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
+
+// requestEventTypes is a comma-separated list of event types, such as "started,stopped".
+type requestEventTypes []validatorevents.Type
+
+func (rt *requestEventTypes) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, s := range strings.Split(value, ",") {
+		switch t := validatorevents.Type(strings.ToLower(s)); t {
+		case validatorevents.Started,
+			validatorevents.Stopped,
+			validatorevents.Liquidated,
+			validatorevents.MetadataUpdated,
+			validatorevents.Exited,
+			validatorevents.StatusChanged:
+			*rt = append(*rt, t)
+		default:
+			return fmt.Errorf("unknown event type: %s", s)
+		}
+	}
+	return nil
+}
+
+func (rt requestEventTypes) contains(t validatorevents.Type) bool {
+	for _, v := range rt {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether any of the clusters exactly matches the given committee.
+func (c requestClusters) contains(committee []spectypes.OperatorID) bool {
+	for _, cluster := range c {
+		if len(cluster) != len(committee) {
+			continue
+		}
+		equal := true
+		for i := range cluster {
+			if cluster[i] != committee[i] {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/operator/validatorevents"
+)
+
+func TestEventsStream(t *testing.T) {
+	feed := validatorevents.New()
+	h := &Events{Feed: feed}
+	server := httptest.NewServer(api.Handler(h.Stream))
+	defer server.Close()
+
+	owner := common.HexToAddress("0x1")
+	resp, err := http.Get(server.URL + "?types=started,status_changed&owners=" + strings.TrimPrefix(owner.Hex(), "0x"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	require.Eventually(t, func() bool { return feed.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	committee := []spectypes.OperatorID{1, 2, 3, 4}
+	feed.Publish(validatorevents.Event{Type: validatorevents.Started, PubKey: []byte{1}, Owner: common.HexToAddress("0x2"), Committee: committee})
+	feed.Publish(validatorevents.Event{Type: validatorevents.Stopped, PubKey: []byte{2}, Owner: owner, Committee: committee})
+	feed.Publish(validatorevents.Event{Type: validatorevents.StatusChanged, PubKey: []byte{3}, Owner: owner, Committee: committee, Status: "ready"})
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "event: status_changed\n", line)
+
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "data: "))
+
+	var event eventJSON
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+	require.Equal(t, api.Hex{3}, event.PubKey)
+	require.Equal(t, "ready", event.Status)
+	require.Equal(t, committee, event.Committee)
+}
+
+func TestEventsStreamInvalidType(t *testing.T) {
+	h := &Events{Feed: validatorevents.New()}
+	r := httptest.NewRequest(http.MethodGet, "/v1/events?types=unknown", nil)
+	w := httptest.NewRecorder()
+	api.Handler(h.Stream)(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/eth/eventhandler"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+)
+
+type FailedTaskQueue interface {
+	FailedTasks() ([]*operatorstorage.FailedTask, error)
+	RetryFailedTask(id uint64) error
+	DiscardFailedTask(id uint64) error
+}
+
+type FailedTasks struct {
+	Queue FailedTaskQueue
+}
+
+type failedTaskJSON struct {
+	ID             uint64       `json:"id"`
+	Type           string       `json:"type"`
+	BlockNumber    uint64       `json:"block_number"`
+	PubKeys        api.HexSlice `json:"public_keys,omitempty"`
+	Owner          api.Hex      `json:"owner,omitempty"`
+	OperatorIDs    []uint64     `json:"operator_ids,omitempty"`
+	Recipient      api.Hex      `json:"recipient,omitempty"`
+	ValidatorIndex uint64       `json:"validator_index,omitempty"`
+	Attempts       int          `json:"attempts"`
+	LastError      string       `json:"last_error"`
+	CreatedAt      time.Time    `json:"created_at"`
+	NextAttempt    *time.Time   `json:"next_attempt,omitempty"`
+	Dead           bool         `json:"dead"`
+}
+
+// List returns the failed tasks of registry events, which are either pending a retry or dead.
+func (h *FailedTasks) List(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data []*failedTaskJSON `json:"data"`
+	}
+	tasks, err := h.Queue.FailedTasks()
+	if err != nil {
+		return err
+	}
+	response.Data = make([]*failedTaskJSON, 0, len(tasks))
+	for _, task := range tasks {
+		response.Data = append(response.Data, failedTaskFromStorage(task))
+	}
+	return api.Render(w, r, response)
+}
+
+// Retry retries the given failed task now, even if it's dead.
+func (h *FailedTasks) Retry(w http.ResponseWriter, r *http.Request) error {
+	id, err := bindFailedTaskID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.Queue.RetryFailedTask(id); err != nil {
+		if errors.Is(err, eventhandler.ErrFailedTaskNotFound) {
+			return api.ErrNotFound
+		}
+		return api.Error(fmt.Errorf("retry failed: %w", err))
+	}
+	return api.Render(w, r, struct{}{})
+}
+
+// Discard deletes the given failed task without retrying it.
+func (h *FailedTasks) Discard(w http.ResponseWriter, r *http.Request) error {
+	id, err := bindFailedTaskID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.Queue.DiscardFailedTask(id); err != nil {
+		if errors.Is(err, eventhandler.ErrFailedTaskNotFound) {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return api.Render(w, r, struct{}{})
+}
+
+func bindFailedTaskID(r *http.Request) (uint64, error) {
+	var request struct {
+		ID uint64 `json:"id" form:"id"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return 0, api.InvalidRequestError(err)
+	}
+	if request.ID == 0 {
+		return 0, api.InvalidRequestError(fmt.Errorf("id is required"))
+	}
+	return request.ID, nil
+}
+
+func failedTaskFromStorage(task *operatorstorage.FailedTask) *failedTaskJSON {
+	t := &failedTaskJSON{
+		ID:             task.ID,
+		Type:           task.Type,
+		BlockNumber:    task.BlockNumber,
+		OperatorIDs:    task.OperatorIDs,
+		ValidatorIndex: task.ValidatorIndex,
+		Attempts:       task.Attempts,
+		LastError:      task.LastError,
+		CreatedAt:      task.CreatedAt,
+		Dead:           task.Dead,
+	}
+	for _, pubKey := range task.ValidatorPubKeys {
+		t.PubKeys = append(t.PubKeys, pubKey)
+	}
+	if task.Owner != (ethcommon.Address{}) {
+		t.Owner = task.Owner.Bytes()
+	}
+	if task.Recipient != (ethcommon.Address{}) {
+		t.Recipient = task.Recipient.Bytes()
+	}
+	if !task.Dead {
+		nextAttempt := task.NextAttempt
+		t.NextAttempt = &nextAttempt
+	}
+	return t
+}
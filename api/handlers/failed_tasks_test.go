@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/eth/eventhandler"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+)
+
+type testFailedTaskQueue struct {
+	tasks   []*operatorstorage.FailedTask
+	retried []uint64
+}
+
+func (q *testFailedTaskQueue) FailedTasks() ([]*operatorstorage.FailedTask, error) {
+	return q.tasks, nil
+}
+
+func (q *testFailedTaskQueue) RetryFailedTask(id uint64) error {
+	if _, ok := q.find(id); !ok {
+		return eventhandler.ErrFailedTaskNotFound
+	}
+	q.retried = append(q.retried, id)
+	return nil
+}
+
+func (q *testFailedTaskQueue) DiscardFailedTask(id uint64) error {
+	i, ok := q.find(id)
+	if !ok {
+		return eventhandler.ErrFailedTaskNotFound
+	}
+	q.tasks = append(q.tasks[:i], q.tasks[i+1:]...)
+	return nil
+}
+
+func (q *testFailedTaskQueue) find(id uint64) (int, bool) {
+	for i, task := range q.tasks {
+		if task.ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func TestFailedTasks(t *testing.T) {
+	queue := &testFailedTaskQueue{tasks: []*operatorstorage.FailedTask{
+		{ID: 1, Type: eventhandler.TaskUpdateFeeRecipient, Owner: common.Address{1}, Recipient: common.Address{2}, Attempts: 1},
+		{ID: 2, Type: eventhandler.TaskStopValidator, ValidatorPubKeys: [][]byte{{1, 2}}, Attempts: 10, Dead: true},
+	}}
+	h := &FailedTasks{Queue: queue}
+
+	t.Run("list", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		api.Handler(h.List)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		require.Equal(t, "0100000000000000000000000000000000000000", response.Data[0]["owner"])
+		require.Contains(t, response.Data[0], "next_attempt")
+		require.Equal(t, []any{"0102"}, response.Data[1]["public_keys"])
+		require.Equal(t, true, response.Data[1]["dead"])
+		require.NotContains(t, response.Data[1], "next_attempt")
+	})
+
+	post := func(handler api.HandlerFunc, method, id string) int {
+		w := httptest.NewRecorder()
+		api.Handler(handler)(w, httptest.NewRequest(method, "/?"+url.Values{"id": {id}}.Encode(), nil))
+		return w.Code
+	}
+
+	t.Run("retry", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, post(h.Retry, http.MethodPost, "2"))
+		require.Equal(t, []uint64{2}, queue.retried)
+		require.Equal(t, http.StatusNotFound, post(h.Retry, http.MethodPost, "3"))
+		require.Equal(t, http.StatusBadRequest, post(h.Retry, http.MethodPost, ""))
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, post(h.Discard, http.MethodDelete, "1"))
+		require.Len(t, queue.tasks, 1)
+		require.Equal(t, http.StatusNotFound, post(h.Discard, http.MethodDelete, "1"))
+	})
+}
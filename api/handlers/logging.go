@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/logging"
+)
+
+const (
+	defaultLogFilterTTL = 10 * time.Minute
+	maxLogFilterTTL     = 24 * time.Hour
+)
+
+type Logging struct {
+	Levels *logging.LevelController
+}
+
+type loggingJSON struct {
+	Level   string            `json:"level"`
+	Loggers map[string]string `json:"loggers"`
+	Names   []string          `json:"names"`
+	Filter  *logFilterJSON    `json:"filter"`
+}
+
+type logFilterJSON struct {
+	PubKey  string    `json:"public_key,omitempty"`
+	DutyID  string    `json:"duty_id,omitempty"`
+	Level   string    `json:"level"`
+	Expires time.Time `json:"expires"`
+}
+
+// Get returns the current log levels and filter.
+func (h *Logging) Get(w http.ResponseWriter, r *http.Request) error {
+	return api.Render(w, r, h.state())
+}
+
+// SetLevel sets the global log level, or the level of a single logger if one is given.
+// An empty level resets the logger to the global level.
+func (h *Logging) SetLevel(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Level  string `json:"level" form:"level"`
+		Logger string `json:"logger" form:"logger"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	if request.Level == "" {
+		if request.Logger == "" {
+			return api.InvalidRequestError(fmt.Errorf("level is required"))
+		}
+		h.Levels.ResetNamedLevel(request.Logger)
+		return api.Render(w, r, h.state())
+	}
+
+	level, err := zapcore.ParseLevel(request.Level)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if request.Logger == "" {
+		h.Levels.SetLevel(level)
+	} else if err := h.Levels.SetNamedLevel(request.Logger, level); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	return api.Render(w, r, h.state())
+}
+
+// SetFilter enables logs at the given level (debug by default) for a single validator
+// or duty, until the TTL expires.
+func (h *Logging) SetFilter(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PubKey api.Hex `json:"public_key" form:"public_key"`
+		DutyID string  `json:"duty_id" form:"duty_id"`
+		Level  string  `json:"level" form:"level"`
+		TTL    string  `json:"ttl" form:"ttl"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	level := zapcore.DebugLevel
+	if request.Level != "" {
+		var err error
+		if level, err = zapcore.ParseLevel(request.Level); err != nil {
+			return api.InvalidRequestError(err)
+		}
+	}
+
+	ttl := defaultLogFilterTTL
+	if request.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(request.TTL); err != nil {
+			return api.InvalidRequestError(err)
+		}
+		if ttl <= 0 || ttl > maxLogFilterTTL {
+			return api.InvalidRequestError(fmt.Errorf("ttl must be positive and at most %s", maxLogFilterTTL))
+		}
+	}
+
+	var pubKey string
+	if len(request.PubKey) > 0 {
+		pubKey = hex.EncodeToString(request.PubKey)
+	}
+	err := h.Levels.SetFilter(logging.Filter{
+		PubKey:  pubKey,
+		DutyID:  request.DutyID,
+		Level:   level,
+		Expires: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	return api.Render(w, r, h.state())
+}
+
+// ResetFilter removes the log filter.
+func (h *Logging) ResetFilter(w http.ResponseWriter, r *http.Request) error {
+	h.Levels.ResetFilter()
+	return api.Render(w, r, h.state())
+}
+
+func (h *Logging) state() *loggingJSON {
+	state := &loggingJSON{
+		Level:   h.Levels.Level().String(),
+		Loggers: map[string]string{},
+		Names:   logging.Names(),
+	}
+	for name, level := range h.Levels.NamedLevels() {
+		state.Loggers[name] = level.String()
+	}
+	if filter := h.Levels.Filter(); filter != nil {
+		state.Filter = &logFilterJSON{
+			PubKey:  filter.PubKey,
+			DutyID:  filter.DutyID,
+			Level:   filter.Level.String(),
+			Expires: filter.Expires,
+		}
+	}
+	return state
+}
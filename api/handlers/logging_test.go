@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestLogging(t *testing.T) {
+	h := &Logging{Levels: logging.NewLevelController(zapcore.InfoLevel)}
+
+	request := func(handler func(http.ResponseWriter, *http.Request) error, method, query string) (*loggingJSON, error) {
+		r := httptest.NewRequest(method, "/v1/admin/logging"+query, nil)
+		w := httptest.NewRecorder()
+		if err := handler(w, r); err != nil {
+			return nil, err
+		}
+		require.Equal(t, http.StatusOK, w.Code)
+		var response loggingJSON
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return &response, nil
+	}
+
+	state, err := request(h.SetLevel, http.MethodPut, "?level=warn")
+	require.NoError(t, err)
+	require.Equal(t, "warn", state.Level)
+
+	state, err = request(h.SetLevel, http.MethodPut, "?level=debug&logger="+logging.NameP2PNetwork)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{logging.NameP2PNetwork: "debug"}, state.Loggers)
+
+	_, err = request(h.SetLevel, http.MethodPut, "?level=debug&logger=Unknown")
+	require.Error(t, err)
+	_, err = request(h.SetLevel, http.MethodPut, "?level=loud")
+	require.Error(t, err)
+
+	state, err = request(h.SetLevel, http.MethodPut, "?logger="+logging.NameP2PNetwork)
+	require.NoError(t, err)
+	require.Empty(t, state.Loggers)
+
+	_, err = request(h.SetFilter, http.MethodPut, "?ttl=1m")
+	require.Error(t, err)
+	_, err = request(h.SetFilter, http.MethodPut, "?duty_id=PROPOSER&ttl=48h")
+	require.Error(t, err)
+
+	state, err = request(h.SetFilter, http.MethodPut, "?public_key=abcd&ttl=1m")
+	require.NoError(t, err)
+	require.NotNil(t, state.Filter)
+	require.Equal(t, "abcd", state.Filter.PubKey)
+	require.Equal(t, "debug", state.Filter.Level)
+	require.WithinDuration(t, time.Now().Add(time.Minute), state.Filter.Expires, 5*time.Second)
+
+	state, err = request(h.ResetFilter, http.MethodDelete, "")
+	require.NoError(t, err)
+	require.Nil(t, state.Filter)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/bloxapp/ssv/api"
 	networkpeers "github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/nodeprobe"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -23,6 +24,10 @@ type TopicIndex interface {
 	PeersByTopic() ([]peer.ID, map[string][]peer.ID)
 }
 
+type UnknownEventsSource interface {
+	UnknownEvents() ([]*operatorstorage.UnknownEvent, error)
+}
+
 type AllPeersAndTopicsJSON struct {
 	AllPeers     []peer.ID        `json:"all_peers"`
 	PeersByTopic []topicIndexJSON `json:"peers_by_topic"`
@@ -66,18 +71,27 @@ func (h healthStatus) MarshalJSON() ([]byte, error) {
 }
 
 type healthCheckJSON struct {
-	P2P           healthStatus `json:"p2p"`
-	BeaconNode    healthStatus `json:"beacon_node"`
-	ExecutionNode healthStatus `json:"execution_node"`
-	EventSyncer   healthStatus `json:"event_syncer"`
-	Advanced      struct {
-		Peers           int      `json:"peers"`
-		InboundConns    int      `json:"inbound_conns"`
-		OutboundConns   int      `json:"outbound_conns"`
-		ListenAddresses []string `json:"p2p_listen_addresses"`
+	P2P            healthStatus `json:"p2p"`
+	BeaconNode     healthStatus `json:"beacon_node"`
+	ExecutionNode  healthStatus `json:"execution_node"`
+	EventSyncer    healthStatus `json:"event_syncer"`
+	RegistryEvents healthStatus `json:"registry_events"`
+	Advanced       struct {
+		Peers                 int                 `json:"peers"`
+		InboundConns          int                 `json:"inbound_conns"`
+		OutboundConns         int                 `json:"outbound_conns"`
+		ListenAddresses       []string            `json:"p2p_listen_addresses"`
+		UnknownRegistryEvents []*unknownEventJSON `json:"unknown_registry_events,omitempty"`
 	} `json:"advanced"`
 }
 
+type unknownEventJSON struct {
+	Topic       api.Hex `json:"topic"`
+	BlockNumber uint64  `json:"block_number"`
+	TxHash      api.Hex `json:"tx_hash"`
+	LogIndex    uint    `json:"log_index"`
+}
+
 func (hc healthCheckJSON) String() string {
 	b, err := json.MarshalIndent(hc, "", "  ")
 	if err != nil {
@@ -92,6 +106,7 @@ type Node struct {
 	TopicIndex      TopicIndex
 	Network         network.Network
 	NodeProber      *nodeprobe.Prober
+	EventHandler    UnknownEventsSource
 }
 
 func (h *Node) Identity(w http.ResponseWriter, r *http.Request) error {
@@ -162,6 +177,24 @@ func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	resp.ExecutionNode = healthStatus{h.NodeProber.CheckExecutionNodeHealth(ctx)}
 	resp.EventSyncer = healthStatus{(h.NodeProber.CheckEventSyncerHealth(ctx))}
 
+	// Report registry events which the node doesn't know, and so didn't process.
+	if h.EventHandler != nil {
+		unknownEvents, err := h.EventHandler.UnknownEvents()
+		if err != nil {
+			resp.RegistryEvents = healthStatus{fmt.Errorf("could not get unknown events: %w", err)}
+		} else if len(unknownEvents) > 0 {
+			resp.RegistryEvents = healthStatus{fmt.Errorf("%d unknown events weren't processed, the node may have to be upgraded", len(unknownEvents))}
+		}
+		for _, event := range unknownEvents {
+			resp.Advanced.UnknownRegistryEvents = append(resp.Advanced.UnknownRegistryEvents, &unknownEventJSON{
+				Topic:       event.Topic.Bytes(),
+				BlockNumber: event.BlockNumber,
+				TxHash:      event.TxHash.Bytes(),
+				LogIndex:    event.LogIndex,
+			})
+		}
+	}
+
 	return api.Render(w, r, resp)
 }
 
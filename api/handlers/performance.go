@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/api"
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/datastore"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+type Performance struct {
+	Network           networkconfig.NetworkConfig
+	Storage           *ibftstorage.QBFTStores
+	Shares            registrystorage.Shares
+	OperatorDataStore datastore.OperatorDataStore
+}
+
+// participationJSON counts this operator's participation in the QBFT instances of a validator and role.
+//
+// Decided instances are persisted compacted, so prepare messages are only available
+// for instances which still had them at the time they were saved.
+type participationJSON struct {
+	PubKey         api.Hex               `json:"public_key"`
+	Index          phase0.ValidatorIndex `json:"index"`
+	Role           string                `json:"role"`
+	Instances      int                   `json:"instances"`
+	Decided        int                   `json:"decided"`
+	Prepared       int                   `json:"prepared"`
+	Committed      int                   `json:"committed"`
+	Led            int                   `json:"led"`
+	DecidedWithout int                   `json:"decided_without"`
+}
+
+func (p *participationJSON) add(ip instanceParticipation) {
+	p.Instances++
+	if ip.decided {
+		p.Decided++
+	}
+	if ip.prepared {
+		p.Prepared++
+	}
+	if ip.committed {
+		p.Committed++
+	}
+	if ip.led {
+		p.Led++
+	}
+	if ip.decided && !ip.prepared && !ip.committed {
+		p.DecidedWithout++
+	}
+}
+
+// Participation counts this operator's participation in the QBFT instances of its validators
+// within the given slot range, per validator and role. The range is bounded like that of History.
+func (h *Performance) Participation(w http.ResponseWriter, r *http.Request) error {
+	var request dutiesRequest
+	var response struct {
+		From  phase0.Slot          `json:"from"`
+		To    phase0.Slot          `json:"to"`
+		Total *participationJSON   `json:"total"`
+		Data  []*participationJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if err := request.boundRange(h.Network.Beacon.EstimatedCurrentSlot()); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	roles := historyRoles
+	if len(request.Roles) > 0 {
+		roles = request.Roles
+	}
+
+	operatorID := h.OperatorDataStore.GetOperatorID()
+	filters := []registrystorage.SharesFilter{registrystorage.ByOperatorID(operatorID)}
+	if len(request.PubKeys) > 0 {
+		filters = append(filters, byPubKeys(request.PubKeys))
+	}
+	if len(request.Indices) > 0 {
+		filters = append(filters, byIndices(request.Indices))
+	}
+
+	response.From = request.From
+	response.To = request.To
+	response.Total = &participationJSON{Role: "ALL"}
+	response.Data = []*participationJSON{}
+	for _, share := range h.Shares.List(nil, filters...) {
+		var index phase0.ValidatorIndex
+		if share.HasBeaconMetadata() {
+			index = share.BeaconMetadata.Index
+		}
+		for _, role := range roles {
+			store := h.Storage.Get(role)
+			if store == nil {
+				continue
+			}
+			identifier := spectypes.NewMsgID(h.Network.Domain, share.ValidatorPubKey, role)
+			instances, err := storedInstances(store, identifier[:], request.From, request.To)
+			if err != nil {
+				return err
+			}
+			if len(instances) == 0 {
+				continue
+			}
+
+			p := &participationJSON{
+				PubKey: api.Hex(share.ValidatorPubKey),
+				Index:  index,
+				Role:   role.String(),
+			}
+			for _, instance := range instances {
+				ip := participation(instance, operatorID)
+				p.add(ip)
+				response.Total.add(ip)
+			}
+			response.Data = append(response.Data, p)
+		}
+	}
+
+	return api.Render(w, r, response)
+}
+
+type instanceParticipation struct {
+	decided   bool
+	prepared  bool
+	committed bool
+	led       bool
+}
+
+// participation reports how the given operator participated in the given instance.
+func participation(instance *qbftstorage.StoredInstance, operatorID spectypes.OperatorID) instanceParticipation {
+	var ip instanceParticipation
+	state := instance.State
+	if state == nil {
+		return ip
+	}
+
+	ip.prepared = containerHasSigner(state.PrepareContainer, operatorID)
+	ip.committed = containerHasSigner(state.CommitContainer, operatorID)
+
+	round := state.Round
+	if instance.DecidedMessage != nil {
+		ip.decided = true
+		round = instance.DecidedMessage.Message.Round
+		for _, signer := range instance.DecidedMessage.Signers {
+			if signer == operatorID {
+				ip.committed = true
+			}
+		}
+	}
+
+	if state.Share != nil && len(state.Share.Committee) > 0 && round >= specqbft.FirstRound {
+		ip.led = specqbft.RoundRobinProposer(state, round) == operatorID
+	}
+
+	return ip
+}
+
+func containerHasSigner(container *specqbft.MsgContainer, operatorID spectypes.OperatorID) bool {
+	if container == nil {
+		return false
+	}
+	for _, msgs := range container.Msgs {
+		for _, msg := range msgs {
+			for _, signer := range msg.Signers {
+				if signer == operatorID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
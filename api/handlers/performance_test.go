@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+)
+
+func TestParticipation(t *testing.T) {
+	share := mockShare(1, 2, 3, 4)
+	newInstance := func(height specqbft.Height, round specqbft.Round, prepares []spectypes.OperatorID, decidedSigners []spectypes.OperatorID) *qbftstorage.StoredInstance {
+		prepareContainer := specqbft.NewMsgContainer()
+		for _, signer := range prepares {
+			prepareContainer.Msgs[round] = append(prepareContainer.Msgs[round], &specqbft.SignedMessage{
+				Signers: []spectypes.OperatorID{signer},
+				Message: specqbft.Message{MsgType: specqbft.PrepareMsgType, Height: height, Round: round},
+			})
+		}
+		instance := &qbftstorage.StoredInstance{
+			State: &specqbft.State{
+				Share:            &share.Share,
+				Height:           height,
+				Round:            round,
+				PrepareContainer: prepareContainer,
+				CommitContainer:  specqbft.NewMsgContainer(),
+			},
+		}
+		if decidedSigners != nil {
+			instance.DecidedMessage = &specqbft.SignedMessage{
+				Signers: decidedSigners,
+				Message: specqbft.Message{MsgType: specqbft.CommitMsgType, Height: height, Round: round},
+			}
+		}
+		return instance
+	}
+
+	testCases := []struct {
+		name     string
+		instance *qbftstorage.StoredInstance
+		operator spectypes.OperatorID
+		expected instanceParticipation
+	}{
+		{
+			name:     "committed and led",
+			instance: newInstance(4, specqbft.FirstRound, nil, []spectypes.OperatorID{1, 2, 3}),
+			operator: 1,
+			expected: instanceParticipation{decided: true, committed: true, led: true},
+		},
+		{
+			name:     "decided without operator",
+			instance: newInstance(4, specqbft.FirstRound, nil, []spectypes.OperatorID{1, 2, 3}),
+			operator: 4,
+			expected: instanceParticipation{decided: true},
+		},
+		{
+			name:     "prepared only",
+			instance: newInstance(4, specqbft.FirstRound, []spectypes.OperatorID{2, 4}, []spectypes.OperatorID{1, 2, 3}),
+			operator: 4,
+			expected: instanceParticipation{decided: true, prepared: true},
+		},
+		{
+			name:     "leader of round change",
+			instance: newInstance(4, 2, nil, []spectypes.OperatorID{1, 2, 3}),
+			operator: 2,
+			expected: instanceParticipation{decided: true, committed: true, led: true},
+		},
+		{
+			name:     "undecided",
+			instance: newInstance(5, specqbft.FirstRound, []spectypes.OperatorID{3}, nil),
+			operator: 3,
+			expected: instanceParticipation{prepared: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, participation(tc.instance, tc.operator))
+		})
+	}
+
+	var total participationJSON
+	for _, tc := range testCases {
+		total.add(participation(tc.instance, tc.operator))
+	}
+	require.Equal(t, participationJSON{Instances: 5, Decided: 4, Prepared: 2, Committed: 2, Led: 2, DecidedWithout: 1}, total)
+}
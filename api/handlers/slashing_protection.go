@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/datastore"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+// maxInterchangeSize limits the size of imported interchange files.
+const maxInterchangeSize = 64 << 20
+
+// defaultHistoryEpochs is the number of epochs returned by History when no range is given.
+const defaultHistoryEpochs = 32
+
+// maxHistoryEpochs limits the epoch range of a single History request,
+// since the history of every share is read for the whole range.
+const maxHistoryEpochs = 256
+
+type SlashingProtection struct {
+	Network           networkconfig.NetworkConfig
+	KeyManager        ekm.StorageProvider
+	Shares            registrystorage.Shares
+	OperatorDataStore datastore.OperatorDataStore
+}
+
+type signedHistoryJSON struct {
+	PubKey       api.Hex                  `json:"public_key"`
+	SharePubKey  api.Hex                  `json:"share_public_key"`
+	Index        phase0.ValidatorIndex    `json:"index"`
+	Attestations []*ekm.SignedAttestation `json:"attestations"`
+	Proposals    []*ekm.SignedProposal    `json:"proposals"`
+}
+
+// Export returns the slashing protection data of all shares in the EIP-3076 interchange format.
+func (h *SlashingProtection) Export(w http.ResponseWriter, r *http.Request) error {
+	interchange, err := h.KeyManager.ExportSlashingProtection()
+	if err != nil {
+		return err
+	}
+	return api.Render(w, r, interchange)
+}
+
+// Import merges an EIP-3076 interchange file from the request body into the slashing protection data.
+func (h *SlashingProtection) Import(w http.ResponseWriter, r *http.Request) error {
+	var interchange ekm.Interchange
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxInterchangeSize)).Decode(&interchange); err != nil {
+		return api.InvalidRequestError(fmt.Errorf("could not decode interchange: %w", err))
+	}
+
+	result, err := h.KeyManager.ImportSlashingProtection(&interchange)
+	if errors.Is(err, ekm.ErrInvalidInterchange) {
+		return api.InvalidRequestError(err)
+	}
+	if err != nil {
+		return err
+	}
+	return api.Render(w, r, result)
+}
+
+// History returns what this operator's shares signed within the given epoch range,
+// according to the slashing protection history. Attestations are matched by their target epoch.
+func (h *SlashingProtection) History(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PubKeys api.HexSlice    `json:"pubkeys" form:"pubkeys"`
+		Indices api.Uint64Slice `json:"indices" form:"indices"`
+		From    phase0.Epoch    `json:"from" form:"from"`
+		To      phase0.Epoch    `json:"to" form:"to"`
+	}
+	var response struct {
+		From phase0.Epoch         `json:"from"`
+		To   phase0.Epoch         `json:"to"`
+		Data []*signedHistoryJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if request.To == 0 {
+		request.To = h.Network.Beacon.EstimatedCurrentEpoch()
+	}
+	if request.From == 0 && request.To >= defaultHistoryEpochs {
+		request.From = request.To - defaultHistoryEpochs + 1
+	}
+	if request.From > request.To {
+		return api.InvalidRequestError(fmt.Errorf("from epoch %d is after to epoch %d", request.From, request.To))
+	}
+	if request.To-request.From >= maxHistoryEpochs {
+		return api.InvalidRequestError(fmt.Errorf("epoch range must not exceed %d epochs", maxHistoryEpochs))
+	}
+
+	filters := []registrystorage.SharesFilter{registrystorage.ByOperatorID(h.OperatorDataStore.GetOperatorID())}
+	if len(request.PubKeys) > 0 {
+		filters = append(filters, byPubKeys(request.PubKeys))
+	}
+	if len(request.Indices) > 0 {
+		filters = append(filters, byIndices(request.Indices))
+	}
+
+	fromSlot := h.Network.Beacon.FirstSlotAtEpoch(request.From)
+	toSlot := h.Network.Beacon.FirstSlotAtEpoch(request.To+1) - 1
+
+	response.From = request.From
+	response.To = request.To
+	response.Data = []*signedHistoryJSON{}
+	for _, share := range h.Shares.List(nil, filters...) {
+		attestations, err := h.KeyManager.ListSignedAttestations(share.SharePubKey, request.From, request.To)
+		if err != nil {
+			return err
+		}
+		proposals, err := h.KeyManager.ListSignedProposals(share.SharePubKey, fromSlot, toSlot)
+		if err != nil {
+			return err
+		}
+
+		history := &signedHistoryJSON{
+			PubKey:       api.Hex(share.ValidatorPubKey),
+			SharePubKey:  api.Hex(share.SharePubKey),
+			Attestations: attestations,
+			Proposals:    proposals,
+		}
+		if history.Attestations == nil {
+			history.Attestations = []*ekm.SignedAttestation{}
+		}
+		if history.Proposals == nil {
+			history.Proposals = []*ekm.SignedProposal{}
+		}
+		if share.HasBeaconMetadata() {
+			history.Index = share.BeaconMetadata.Index
+		}
+		response.Data = append(response.Data, history)
+	}
+
+	return api.Render(w, r, response)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestSlashingProtection(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	km, err := ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, "")
+	require.NoError(t, err)
+	h := &SlashingProtection{KeyManager: km.(ekm.StorageProvider)}
+
+	// Export first to get the network's metadata.
+	r := httptest.NewRequest(http.MethodGet, "/v1/slashing-protection", nil)
+	w := httptest.NewRecorder()
+	require.NoError(t, h.Export(w, r))
+	var exported ekm.Interchange
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exported))
+	require.Empty(t, exported.Data)
+
+	interchange := ekm.Interchange{
+		Metadata: exported.Metadata,
+		Data: []ekm.InterchangeData{{
+			PubKey:             phase0.BLSPubKey{1},
+			SignedBlocks:       []ekm.InterchangeBlock{{Slot: 100}},
+			SignedAttestations: []ekm.InterchangeAttestation{{SourceEpoch: 2, TargetEpoch: 3}},
+		}},
+	}
+	body, err := json.Marshal(interchange)
+	require.NoError(t, err)
+
+	r = httptest.NewRequest(http.MethodPost, "/v1/admin/slashing-protection", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	require.NoError(t, h.Import(w, r))
+	var result ekm.InterchangeImportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, ekm.InterchangeImportResult{Validators: 1, Attestations: 1, Proposals: 1}, result)
+
+	r = httptest.NewRequest(http.MethodGet, "/v1/slashing-protection", nil)
+	w = httptest.NewRecorder()
+	require.NoError(t, h.Export(w, r))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exported))
+	require.Equal(t, interchange.Data, exported.Data)
+
+	interchange.Metadata.InterchangeFormatVersion = "4"
+	body, err = json.Marshal(interchange)
+	require.NoError(t, err)
+	r = httptest.NewRequest(http.MethodPost, "/v1/admin/slashing-protection", bytes.NewReader(body))
+	err = h.Import(httptest.NewRecorder(), r)
+	var errResponse *api.ErrorResponse
+	require.True(t, errors.As(err, &errResponse))
+	require.Equal(t, http.StatusBadRequest, errResponse.Code)
+}
+
+func TestSlashingProtectionHistory(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	network := networkconfig.TestNetwork
+	km, err := ekm.NewETHKeyManagerSigner(logger, db, network, "")
+	require.NoError(t, err)
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+
+	sharePubKey := phase0.BLSPubKey{1}
+	require.NoError(t, shares.Save(nil, &types.SSVShare{
+		Share: spectypes.Share{
+			OperatorID:      1,
+			ValidatorPubKey: []byte{2},
+			SharePubKey:     sharePubKey[:],
+			Committee:       []*spectypes.Operator{{OperatorID: 1}},
+		},
+	}))
+
+	h := &SlashingProtection{
+		Network:           network,
+		KeyManager:        km.(ekm.StorageProvider),
+		Shares:            shares,
+		OperatorDataStore: datastore.New(&registrystorage.OperatorData{ID: 1}),
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/slashing-protection", nil)
+	w := httptest.NewRecorder()
+	require.NoError(t, h.Export(w, r))
+	var exported ekm.Interchange
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exported))
+
+	_, err = km.(ekm.StorageProvider).ImportSlashingProtection(&ekm.Interchange{
+		Metadata: exported.Metadata,
+		Data: []ekm.InterchangeData{{
+			PubKey:             sharePubKey,
+			SignedBlocks:       []ekm.InterchangeBlock{{Slot: 100}},
+			SignedAttestations: []ekm.InterchangeAttestation{{SourceEpoch: 2, TargetEpoch: 3}, {SourceEpoch: 3, TargetEpoch: 10}},
+		}},
+	})
+	require.NoError(t, err)
+
+	requestRange := func(query string) (phase0.Epoch, phase0.Epoch, []signedHistoryJSON) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/slashing-protection/history"+query, nil)
+		w := httptest.NewRecorder()
+		require.NoError(t, h.History(w, r))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			From phase0.Epoch        `json:"from"`
+			To   phase0.Epoch        `json:"to"`
+			Data []signedHistoryJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.From, response.To, response.Data
+	}
+	request := func(query string) []signedHistoryJSON {
+		_, _, data := requestRange(query)
+		return data
+	}
+
+	history := request("?from=0&to=5")
+	require.Len(t, history, 1)
+	require.Equal(t, api.Hex(sharePubKey[:]), history[0].SharePubKey)
+	require.Len(t, history[0].Attestations, 1)
+	require.Equal(t, phase0.Epoch(3), history[0].Attestations[0].TargetEpoch)
+	require.Len(t, history[0].Proposals, 1)
+
+	history = request("?from=4&to=10")
+	require.Len(t, history, 1)
+	require.Len(t, history[0].Attestations, 1)
+	require.Empty(t, history[0].Proposals)
+
+	require.Empty(t, request("?from=0&to=5&pubkeys=03"))
+
+	// The default range has defaultHistoryEpochs epochs up to the given one.
+	from, to, _ := requestRange("?to=100")
+	require.Equal(t, phase0.Epoch(100-defaultHistoryEpochs+1), from)
+	require.Equal(t, phase0.Epoch(100), to)
+
+	// The widest range is allowed.
+	from, to, _ = requestRange(fmt.Sprintf("?from=1&to=%d", maxHistoryEpochs))
+	require.Equal(t, phase0.Epoch(1), from)
+	require.Equal(t, phase0.Epoch(maxHistoryEpochs), to)
+
+	for _, query := range []string{
+		"?from=5&to=4",
+		fmt.Sprintf("?from=1&to=%d", maxHistoryEpochs+1),
+	} {
+		r = httptest.NewRequest(http.MethodGet, "/v1/slashing-protection/history"+query, nil)
+		err = h.History(httptest.NewRecorder(), r)
+		var errResponse *api.ErrorResponse
+		require.True(t, errors.As(err, &errResponse), query)
+		require.Equal(t, http.StatusBadRequest, errResponse.Code, query)
+	}
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,12 +12,20 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
 	"github.com/bloxapp/ssv/api"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
 )
 
+type RegistryHistory interface {
+	GetSharesAtBlock(r basedb.Reader, blockNumber uint64) ([]*operatorstorage.ShareVersion, error)
+	GetRecipientsAtBlock(r basedb.Reader, blockNumber uint64) ([]*operatorstorage.RecipientVersion, error)
+}
+
 type Validators struct {
-	Shares registrystorage.Shares
+	Shares  registrystorage.Shares
+	History RegistryHistory
 }
 
 func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
@@ -26,6 +36,7 @@ func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 		Subclusters requestClusters `json:"subclusters" form:"subclusters"`
 		PubKeys     api.HexSlice    `json:"pubkeys" form:"pubkeys"`
 		Indices     api.Uint64Slice `json:"indices" form:"indices"`
+		AtBlock     requestBlock    `json:"at_block" form:"at_block"`
 	}
 	var response struct {
 		Data []*validatorJSON `json:"data"`
@@ -55,7 +66,16 @@ func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 		filters = append(filters, byIndices(request.Indices))
 	}
 
-	shares := h.Shares.List(nil, filters...)
+	var shares []*types.SSVShare
+	if request.AtBlock.Set {
+		var err error
+		shares, err = sharesAtBlock(h.History, request.AtBlock.Number, filters...)
+		if err != nil {
+			return err
+		}
+	} else {
+		shares = h.Shares.List(nil, filters...)
+	}
 	response.Data = make([]*validatorJSON, len(shares))
 	for i, share := range shares {
 		response.Data[i] = validatorFromShare(share)
@@ -136,6 +156,62 @@ func byIndices(indices []uint64) registrystorage.SharesFilter {
 	}
 }
 
+// sharesAtBlock returns the shares which existed at the given block, as far as the registry's history records them.
+// Their beacon metadata isn't recorded, so it's missing.
+func sharesAtBlock(history RegistryHistory, blockNumber uint64, filters ...registrystorage.SharesFilter) ([]*types.SSVShare, error) {
+	versions, err := history.GetSharesAtBlock(nil, blockNumber)
+	if errors.Is(err, operatorstorage.ErrRegistryHistoryUnavailable) {
+		return nil, api.InvalidRequestError(err)
+	}
+	if err != nil {
+		return nil, api.Error(fmt.Errorf("failed to get registry history: %w", err))
+	}
+
+	var shares []*types.SSVShare
+Versions:
+	for _, version := range versions {
+		share := &types.SSVShare{
+			Share: spectypes.Share{
+				ValidatorPubKey: version.ValidatorPubKey,
+				Committee:       make([]*spectypes.Operator, len(version.OperatorIDs)),
+			},
+			Metadata: types.Metadata{
+				OwnerAddress: version.Owner,
+				Liquidated:   version.Liquidated,
+			},
+		}
+		for i, id := range version.OperatorIDs {
+			share.Committee[i] = &spectypes.Operator{OperatorID: id}
+		}
+		share.Quorum, share.PartialQuorum = types.ComputeQuorumAndPartialQuorum(len(version.OperatorIDs))
+		for _, filter := range filters {
+			if !filter(share) {
+				continue Versions
+			}
+		}
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+// requestBlock is an optional block number.
+type requestBlock struct {
+	Number uint64
+	Set    bool
+}
+
+func (b *requestBlock) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	b.Number, b.Set = n, true
+	return nil
+}
+
 // requestClusters is a space-separated list of comma-separated lists of operator IDs.
 type requestClusters [][]uint64
 
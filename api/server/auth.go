@@ -0,0 +1,129 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+
+	"github.com/bloxapp/ssv/api"
+)
+
+// Scope is a level of access to the API.
+type Scope int
+
+const (
+	// ScopeRead allows reading the node's state.
+	ScopeRead Scope = iota + 1
+	// ScopeAdmin allows changing the node's state. It includes ScopeRead.
+	ScopeAdmin
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeRead:
+		return "read"
+	case ScopeAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+type authenticator struct {
+	// tokens maps the SHA-256 hash of each token to its scope,
+	// so that tokens are compared in constant time regardless of their length.
+	tokens       map[[32]byte]Scope
+	adminClients map[string]struct{}
+	mtls         bool
+}
+
+func newAuthenticator() *authenticator {
+	return &authenticator{
+		tokens:       make(map[[32]byte]Scope),
+		adminClients: make(map[string]struct{}),
+	}
+}
+
+func (a *authenticator) addToken(token string, scope Scope) {
+	if token == "" {
+		return
+	}
+	hash := sha256.Sum256([]byte(token))
+	if scope > a.tokens[hash] {
+		a.tokens[hash] = scope
+	}
+}
+
+// enabled reports whether any credentials are configured.
+// Without credentials, every request is granted the read scope, and none is granted the admin scope.
+func (a *authenticator) enabled() bool {
+	return len(a.tokens) > 0 || a.mtls
+}
+
+// adminEnabled reports whether any credentials are granted the admin scope.
+func (a *authenticator) adminEnabled() bool {
+	for _, scope := range a.tokens {
+		if scope >= ScopeAdmin {
+			return true
+		}
+	}
+	return a.mtls && len(a.adminClients) > 0
+}
+
+// scope returns the highest scope granted to the request's credentials,
+// and whether any credentials were presented at all.
+func (a *authenticator) scope(r *http.Request) (scope Scope, presented bool) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		presented = true
+		if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+			hash := sha256.Sum256([]byte(token))
+			for h, s := range a.tokens {
+				if subtle.ConstantTimeCompare(h[:], hash[:]) == 1 && s > scope {
+					scope = s
+				}
+			}
+		}
+	}
+
+	if a.mtls && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		presented = true
+		clientScope := ScopeRead
+		if _, ok := a.adminClients[r.TLS.VerifiedChains[0][0].Subject.CommonName]; ok {
+			clientScope = ScopeAdmin
+		}
+		if clientScope > scope {
+			scope = clientScope
+		}
+	}
+
+	return scope, presented
+}
+
+// require returns a middleware which rejects requests lacking the given scope.
+func (a *authenticator) require(scope Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !a.enabled() {
+				if scope > ScopeRead {
+					_ = render.Render(w, r, api.ErrForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			granted, presented := a.scope(r)
+			switch {
+			case granted >= scope:
+				next.ServeHTTP(w, r)
+			case !presented || granted == 0:
+				w.Header().Set("WWW-Authenticate", `Bearer realm="ssv"`)
+				_ = render.Render(w, r, api.ErrUnauthorized)
+			default:
+				_ = render.Render(w, r, api.ErrForbidden)
+			}
+		})
+	}
+}
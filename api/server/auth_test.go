@@ -0,0 +1,104 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAuthenticatorRequire(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(a *authenticator, scope Scope, r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		a.require(scope)(ok).ServeHTTP(w, r)
+		return w
+	}
+	withToken := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return r
+	}
+	withClient := func(commonName string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		return r
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		a := newAuthenticator()
+		require.False(t, a.adminEnabled())
+		require.Equal(t, http.StatusOK, serve(a, ScopeRead, httptest.NewRequest(http.MethodGet, "/", nil)).Code)
+		require.Equal(t, http.StatusForbidden, serve(a, ScopeAdmin, httptest.NewRequest(http.MethodGet, "/", nil)).Code)
+		require.Equal(t, http.StatusForbidden, serve(a, ScopeAdmin, withToken("admin")).Code)
+	})
+
+	t.Run("read tokens only", func(t *testing.T) {
+		a := newAuthenticator()
+		a.addToken("reader", ScopeRead)
+		require.False(t, a.adminEnabled())
+		require.Equal(t, http.StatusForbidden, serve(a, ScopeAdmin, withToken("reader")).Code)
+	})
+
+	t.Run("tokens", func(t *testing.T) {
+		a := newAuthenticator()
+		a.addToken("reader", ScopeRead)
+		a.addToken("admin", ScopeAdmin)
+		require.True(t, a.adminEnabled())
+
+		w := serve(a, ScopeRead, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+		require.Equal(t, http.StatusUnauthorized, serve(a, ScopeRead, withToken("wrong")).Code)
+		require.Equal(t, http.StatusOK, serve(a, ScopeRead, withToken("reader")).Code)
+		require.Equal(t, http.StatusForbidden, serve(a, ScopeAdmin, withToken("reader")).Code)
+		require.Equal(t, http.StatusOK, serve(a, ScopeRead, withToken("admin")).Code)
+		require.Equal(t, http.StatusOK, serve(a, ScopeAdmin, withToken("admin")).Code)
+	})
+
+	t.Run("client certificates", func(t *testing.T) {
+		a := newAuthenticator()
+		a.mtls = true
+		require.False(t, a.adminEnabled())
+		a.adminClients["ops"] = struct{}{}
+		require.True(t, a.adminEnabled())
+
+		require.Equal(t, http.StatusUnauthorized, serve(a, ScopeRead, httptest.NewRequest(http.MethodGet, "/", nil)).Code)
+		require.Equal(t, http.StatusOK, serve(a, ScopeRead, withClient("monitoring")).Code)
+		require.Equal(t, http.StatusForbidden, serve(a, ScopeAdmin, withClient("monitoring")).Code)
+		require.Equal(t, http.StatusOK, serve(a, ScopeAdmin, withClient("ops")).Code)
+	})
+}
+
+func TestServerAdminRoutesDefaultConfig(t *testing.T) {
+	s := New(zap.NewNop(), ":0", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router := s.router()
+
+	routes := []struct{ method, path string }{
+		{http.MethodGet, "/v1/admin/backup"},
+		{http.MethodGet, "/v1/admin/logging"},
+		{http.MethodPut, "/v1/admin/logging/level"},
+		{http.MethodPut, "/v1/admin/logging/filter"},
+		{http.MethodDelete, "/v1/admin/logging/filter"},
+		{http.MethodPost, "/v1/admin/slashing-protection"},
+		{http.MethodPost, "/v1/admin/failed-tasks/retry"},
+		{http.MethodDelete, "/v1/admin/failed-tasks"},
+		{http.MethodPut, "/v1/admin/builder-policy"},
+		{http.MethodDelete, "/v1/admin/builder-policy"},
+	}
+	for _, route := range routes {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(route.method, route.path, nil))
+		require.Equal(t, http.StatusForbidden, w.Code, "%s %s", route.method, route.path)
+	}
+}
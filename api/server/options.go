@@ -0,0 +1,38 @@
+package server
+
+// Option defines a Server configuration option.
+type Option func(*Server)
+
+// WithTLS serves the API over TLS with the given certificate and key files,
+// which are reloaded on SIGHUP. If clientCAFile is set, client certificates
+// signed by it are accepted as credentials (mTLS).
+func WithTLS(certFile, keyFile, clientCAFile string) Option {
+	return func(s *Server) {
+		s.tlsCertFile = certFile
+		s.tlsKeyFile = keyFile
+		s.tlsClientCAFile = clientCAFile
+	}
+}
+
+// WithTokens grants the read scope to requests bearing any of readTokens,
+// and both the read and admin scopes to requests bearing any of adminTokens.
+func WithTokens(readTokens, adminTokens []string) Option {
+	return func(s *Server) {
+		for _, token := range readTokens {
+			s.auth.addToken(token, ScopeRead)
+		}
+		for _, token := range adminTokens {
+			s.auth.addToken(token, ScopeAdmin)
+		}
+	}
+}
+
+// WithAdminClients grants the admin scope to mTLS clients whose certificate
+// has one of the given common names. Other verified clients are granted the read scope.
+func WithAdminClients(commonNames []string) Option {
+	return func(s *Server) {
+		for _, cn := range commonNames {
+			s.auth.adminClients[cn] = struct{}{}
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
 	"runtime"
+	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -17,8 +21,21 @@ type Server struct {
 	logger *zap.Logger
 	addr   string
 
-	node       *handlers.Node
-	validators *handlers.Validators
+	tlsCertFile     string
+	tlsKeyFile      string
+	tlsClientCAFile string
+	auth            *authenticator
+
+	node        *handlers.Node
+	validators  *handlers.Validators
+	clusters    *handlers.Clusters
+	duties      *handlers.Duties
+	performance *handlers.Performance
+	events      *handlers.Events
+	logging     *handlers.Logging
+	slashing    *handlers.SlashingProtection
+	backup      *handlers.Backup
+	failedTasks *handlers.FailedTasks
 }
 
 func New(
@@ -26,37 +43,129 @@ func New(
 	addr string,
 	node *handlers.Node,
 	validators *handlers.Validators,
+	clusters *handlers.Clusters,
+	duties *handlers.Duties,
+	performance *handlers.Performance,
+	events *handlers.Events,
+	logging *handlers.Logging,
+	slashing *handlers.SlashingProtection,
+	backup *handlers.Backup,
+	failedTasks *handlers.FailedTasks,
+	opts ...Option,
 ) *Server {
-	return &Server{
-		logger:     logger,
-		addr:       addr,
-		node:       node,
-		validators: validators,
+	s := &Server{
+		logger:      logger,
+		addr:        addr,
+		auth:        newAuthenticator(),
+		node:        node,
+		validators:  validators,
+		clusters:    clusters,
+		duties:      duties,
+		performance: performance,
+		events:      events,
+		logging:     logging,
+		slashing:    slashing,
+		backup:      backup,
+		failedTasks: failedTasks,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.auth.mtls = s.tlsCertFile != "" && s.tlsClientCAFile != ""
+	return s
 }
 
 func (s *Server) Run() error {
+	server := &http.Server{
+		Addr:         s.addr,
+		Handler:      s.router(),
+		ReadTimeout:  12 * time.Second,
+		WriteTimeout: 12 * time.Second,
+	}
+
+	if !s.auth.enabled() {
+		s.logger.Warn("SSV API authentication is disabled, do not expose it publicly")
+	}
+	if !s.auth.adminEnabled() {
+		s.logger.Info("SSV API admin endpoints are disabled until an admin token or client is configured")
+	}
+
+	if s.tlsCertFile == "" {
+		if s.tlsKeyFile != "" || s.tlsClientCAFile != "" {
+			return fmt.Errorf("TLS key or client CA file is set without a TLS certificate file")
+		}
+		s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
+		return server.ListenAndServe()
+	}
+
+	reloader, err := newCertReloader(s.tlsCertFile, s.tlsKeyFile, s.tlsClientCAFile)
+	if err != nil {
+		return fmt.Errorf("could not load TLS certificates: %w", err)
+	}
+	server.TLSConfig = reloader.TLSConfig()
+	go s.reloadOnSignal(reloader)
+
+	s.logger.Info("Serving SSV API over TLS", zap.String("addr", s.addr), zap.Bool("mtls", s.auth.mtls))
+	return server.ListenAndServeTLS("", "")
+}
+
+// router returns the API's routes. Admin routes are forbidden unless an admin credential is configured.
+func (s *Server) router() http.Handler {
 	router := chi.NewRouter()
 	router.Use(middleware.Recoverer)
-	router.Use(middleware.Throttle(runtime.NumCPU() * 4))
-	router.Use(middleware.Compress(5, "application/json"))
 	router.Use(middlewareLogger(s.logger))
+	router.Use(s.auth.require(ScopeRead))
 
-	router.Get("/v1/node/identity", api.Handler(s.node.Identity))
-	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
-	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
-	router.Get("/v1/node/health", api.Handler(s.node.Health))
-	router.Get("/v1/validators", api.Handler(s.validators.List))
+	router.Group(func(router chi.Router) {
+		router.Use(middleware.Throttle(runtime.NumCPU() * 4))
+		router.Use(middleware.Compress(5, "application/json"))
 
-	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
+		router.Get("/v1/node/identity", api.Handler(s.node.Identity))
+		router.Get("/v1/node/peers", api.Handler(s.node.Peers))
+		router.Get("/v1/node/topics", api.Handler(s.node.Topics))
+		router.Get("/v1/node/health", api.Handler(s.node.Health))
+		router.Get("/v1/validators", api.Handler(s.validators.List))
+		router.Get("/v1/clusters", api.Handler(s.clusters.List))
+		router.Get("/v1/duties", api.Handler(s.duties.Current))
+		router.Get("/v1/duties/history", api.Handler(s.duties.History))
+		router.Get("/v1/performance/participation", api.Handler(s.performance.Participation))
+		router.Get("/v1/slashing-protection", api.Handler(s.slashing.Export))
+		router.Get("/v1/slashing-protection/history", api.Handler(s.slashing.History))
+		router.Get("/v1/node/failed-tasks", api.Handler(s.failedTasks.List))
 
-	server := &http.Server{
-		Addr:         s.addr,
-		Handler:      router,
-		ReadTimeout:  12 * time.Second,
-		WriteTimeout: 12 * time.Second,
+		router.Group(func(router chi.Router) {
+			router.Use(s.auth.require(ScopeAdmin))
+
+			router.Get("/v1/admin/logging", api.Handler(s.logging.Get))
+			router.Put("/v1/admin/logging/level", api.Handler(s.logging.SetLevel))
+			router.Put("/v1/admin/logging/filter", api.Handler(s.logging.SetFilter))
+			router.Delete("/v1/admin/logging/filter", api.Handler(s.logging.ResetFilter))
+			router.Post("/v1/admin/slashing-protection", api.Handler(s.slashing.Import))
+			router.Post("/v1/admin/failed-tasks/retry", api.Handler(s.failedTasks.Retry))
+			router.Delete("/v1/admin/failed-tasks", api.Handler(s.failedTasks.Discard))
+			router.Put("/v1/admin/builder-policy", api.Handler(s.clusters.SetBuilderPolicy))
+			router.Delete("/v1/admin/builder-policy", api.Handler(s.clusters.DeleteBuilderPolicy))
+		})
+	})
+
+	// Event streams and backups are long-lived, so they're kept out of the throttled group.
+	router.Get("/v1/events", api.Handler(s.events.Stream))
+	router.With(s.auth.require(ScopeAdmin)).Get("/v1/admin/backup", api.Handler(s.backup.Download))
+
+	return router
+}
+
+// reloadOnSignal reloads the TLS certificates whenever SIGHUP is received.
+func (s *Server) reloadOnSignal(reloader *certReloader) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	for range signals {
+		if err := reloader.Reload(); err != nil {
+			s.logger.Error("could not reload SSV API TLS certificates", zap.Error(err))
+			continue
+		}
+		s.logger.Info("reloaded SSV API TLS certificates")
 	}
-	return server.ListenAndServe()
 }
 
 func middlewareLogger(logger *zap.Logger) func(next http.Handler) http.Handler {
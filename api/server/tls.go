@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// certReloader holds the server's TLS certificate and client CA pool,
+// and allows replacing them while the server is running.
+type certReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+}
+
+func newCertReloader(certFile, keyFile, clientCAFile string) (*certReloader, error) {
+	cr := &certReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	if err := cr.Reload(); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// Reload reads the certificate, key and client CA files again.
+// On failure, the previously loaded ones are kept.
+func (cr *certReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return fmt.Errorf("could not load TLS key pair: %w", err)
+	}
+
+	var clientCA *x509.CertPool
+	if cr.clientCAFile != "" {
+		pem, err := os.ReadFile(cr.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("could not read client CA file: %w", err)
+		}
+		clientCA = x509.NewCertPool()
+		if !clientCA.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", cr.clientCAFile)
+		}
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.cert = &cert
+	cr.clientCA = clientCA
+	return nil
+}
+
+// TLSConfig returns a tls.Config which always uses the latest loaded certificates.
+func (cr *certReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cr.mu.RLock()
+			defer cr.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cr.cert},
+			}
+			if cr.clientCA != nil {
+				// Client certificates are optional, since clients may authenticate with a token instead.
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+				config.ClientCAs = cr.clientCA
+			}
+			return config, nil
+		},
+	}
+}
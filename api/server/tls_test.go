@@ -0,0 +1,69 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "api.crt")
+	keyFile := filepath.Join(dir, "api.key")
+
+	writeSelfSignedCert(t, certFile, keyFile, "first")
+	cr, err := newCertReloader(certFile, keyFile, "")
+	require.NoError(t, err)
+	require.Equal(t, "first", servedCommonName(t, cr))
+
+	writeSelfSignedCert(t, certFile, keyFile, "second")
+	require.NoError(t, cr.Reload())
+	require.Equal(t, "second", servedCommonName(t, cr))
+
+	// A broken certificate keeps the previous one.
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0600))
+	require.Error(t, cr.Reload())
+	require.Equal(t, "second", servedCommonName(t, cr))
+
+	_, err = newCertReloader(certFile, keyFile, "")
+	require.Error(t, err)
+}
+
+func servedCommonName(t *testing.T, cr *certReloader) string {
+	config, err := cr.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.Len(t, config.Certificates, 1)
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	return cert.Subject.CommonName
+}
+
+func writeSelfSignedCert(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+}
@@ -1,6 +1,8 @@
 package goclient
 
 import (
+	"context"
+
 	"encoding/binary"
 	"fmt"
 	"time"
@@ -46,9 +48,11 @@ func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 	}
 
 	aggDataReqStart := time.Now()
-	aggDataResp, err := gc.client.AggregateAttestation(gc.ctx, &api.AggregateAttestationOpts{
-		Slot:                slot,
-		AttestationDataRoot: root,
+	aggDataResp, err := race(gc, gc.ctx, func(ctx context.Context, client Client) (*api.Response[*phase0.Attestation], error) {
+		return client.AggregateAttestation(ctx, &api.AggregateAttestationOpts{
+			Slot:                slot,
+			AttestationDataRoot: root,
+		})
 	})
 	if err != nil {
 		return nil, DataVersionNil, fmt.Errorf("failed to get aggregate attestation: %w", err)
@@ -74,7 +78,9 @@ func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 
 // SubmitSignedAggregateSelectionProof broadcasts a signed aggregator msg
 func (gc *goClient) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
-	return gc.client.SubmitAggregateAttestations(gc.ctx, []*phase0.SignedAggregateAndProof{msg})
+	return gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+		return client.SubmitAggregateAttestations(ctx, []*phase0.SignedAggregateAndProof{msg})
+	})
 }
 
 // IsAggregator returns true if the signature is from the input validator. The committee
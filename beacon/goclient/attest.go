@@ -3,22 +3,26 @@ package goclient
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
-	spectypes "github.com/bloxapp/ssv-spec/types"
 	ssz "github.com/ferranbt/fastssz"
-	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
 )
 
 // AttesterDuties returns attester duties for a given epoch.
 func (gc *goClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error) {
-	resp, err := gc.client.AttesterDuties(ctx, &api.AttesterDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	resp, err := failover(gc, func(client Client) (*api.Response[[]*eth2apiv1.AttesterDuty], error) {
+		return client.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain attester duties: %w", err)
@@ -31,47 +35,134 @@ func (gc *goClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, vali
 }
 
 func (gc *goClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
-	attDataReqStart := time.Now()
-	resp, err := gc.client.AttestationData(gc.ctx, &api.AttestationDataOpts{
-		Slot:           slot,
-		CommitteeIndex: committeeIndex,
-	})
-	if err != nil {
-		return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
+	fetch := func(ctx context.Context, client Client) (*api.Response[*phase0.AttestationData], error) {
+		resp, err := client.AttestationData(ctx, &api.AttestationDataOpts{
+			Slot:           slot,
+			CommitteeIndex: committeeIndex,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil || resp.Data == nil {
+			return nil, fmt.Errorf("attestation data response is nil")
+		}
+		return resp, nil
 	}
-	if resp == nil {
-		return nil, DataVersionNil, fmt.Errorf("attestation data response is nil")
+
+	attDataReqStart := time.Now()
+	var data *phase0.AttestationData
+	if gc.attestationDataCheck == AttestationDataCheckDisabled || len(gc.activeEndpoints()) < 2 {
+		resp, err := race(gc, gc.ctx, fetch)
+		if err != nil {
+			return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
+		}
+		data = resp.Data
+	} else {
+		results, err := fetchAll(gc, gc.ctx, gc.network.SlotDurationSec()/attestationDataCheckSlotFraction, fetch)
+		if err != nil {
+			return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
+		}
+		data, err = gc.checkAttestationData(slot, results)
+		if err != nil {
+			return nil, DataVersionNil, err
+		}
 	}
 
 	metricsAttesterDataRequest.Observe(time.Since(attDataReqStart).Seconds())
 
-	return resp.Data, spec.DataVersionPhase0, nil
+	return data, spec.DataVersionPhase0, nil
 }
 
-// SubmitAttestation implements Beacon interface
-func (gc *goClient) SubmitAttestation(attestation *phase0.Attestation) error {
-	signingRoot, err := gc.getSigningRoot(attestation.Data)
-	if err != nil {
-		return errors.Wrap(err, "failed to get signing root")
+// attestationDataCheckSlotFraction is the fraction of the slot for which the beacon nodes' attestation data
+// is awaited to be compared, so that a slow beacon node doesn't delay the attestation.
+const attestationDataCheckSlotFraction = 6
+
+// checkAttestationData compares the attestation data of the preferred beacon node with the others',
+// reporting any disagreements and refusing the data if the policy says so.
+func (gc *goClient) checkAttestationData(
+	slot phase0.Slot,
+	results []endpointResult[*api.Response[*phase0.AttestationData]],
+) (*phase0.AttestationData, error) {
+	preferred := results[0]
+	data := preferred.result.Data
+	if len(results) < 2 {
+		gc.log.Warn("attestation data couldn't be compared, only one beacon node responded in time",
+			fields.Slot(slot),
+			fields.Address(preferred.endpoint.label))
+		return data, nil
 	}
 
-	if err := gc.slashableAttestationCheck(gc.ctx, signingRoot); err != nil {
-		return errors.Wrap(err, "failed attestation slashing protection check")
+	var disagreements []string
+	for _, other := range results[1:] {
+		otherData := other.result.Data
+		for _, d := range compareAttestationData(data, otherData, gc.attestationDataCheckHead) {
+			metricsAttestationDataDisagreements.WithLabelValues(d.checkpoint).Inc()
+			gc.log.Warn("beacon nodes disagree on attestation data",
+				fields.Slot(slot),
+				zap.String("checkpoint", d.checkpoint),
+				zap.String("preferred_address", preferred.endpoint.label),
+				zap.String("preferred_root", d.root.String()),
+				zap.String("other_address", other.endpoint.label),
+				zap.String("other_root", d.otherRoot.String()))
+			disagreements = append(disagreements, d.checkpoint)
+		}
 	}
 
-	return gc.client.SubmitAttestations(gc.ctx, []*phase0.Attestation{attestation})
+	if len(disagreements) > 0 && gc.attestationDataCheck == AttestationDataCheckRefuse {
+		return nil, fmt.Errorf("beacon nodes disagree on attestation data (%s)", strings.Join(disagreements, ", "))
+	}
+	return data, nil
 }
 
-// getSigningRoot returns signing root
-func (gc *goClient) getSigningRoot(data *phase0.AttestationData) ([32]byte, error) {
-	epoch := gc.network.EstimatedEpochAtSlot(data.Slot)
-	domain, err := gc.DomainData(epoch, spectypes.DomainAttester)
-	if err != nil {
-		return [32]byte{}, err
+// SubmitAttestation implements Beacon interface.
+// Slashing protection is enforced against each share's signing history when the share signs
+// the attestation data (see ekm), since the reconstructed attestation can't be attributed here.
+func (gc *goClient) SubmitAttestation(attestation *phase0.Attestation) error {
+	return gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+		return client.SubmitAttestations(ctx, []*phase0.Attestation{attestation})
+	})
+}
+
+// AttestationDataCheck is the policy for attestation data disagreements between beacon nodes.
+type AttestationDataCheck string
+
+const (
+	// AttestationDataCheckDisabled uses the attestation data of the fastest beacon node.
+	AttestationDataCheckDisabled AttestationDataCheck = ""
+	// AttestationDataCheckFlag reports disagreements, but uses the preferred beacon node's data anyway.
+	AttestationDataCheckFlag AttestationDataCheck = "flag"
+	// AttestationDataCheckRefuse reports disagreements and refuses the data.
+	AttestationDataCheckRefuse AttestationDataCheck = "refuse"
+)
+
+func (c AttestationDataCheck) validate() error {
+	switch c {
+	case AttestationDataCheckDisabled, AttestationDataCheckFlag, AttestationDataCheckRefuse:
+		return nil
+	default:
+		return fmt.Errorf("unknown attestation data check %q", c)
 	}
-	root, err := gc.ComputeSigningRoot(data, domain)
-	if err != nil {
-		return [32]byte{}, err
+}
+
+// attestationDataDisagreement is a checkpoint on which two beacon nodes disagree.
+type attestationDataDisagreement struct {
+	checkpoint string
+	root       phase0.Root
+	otherRoot  phase0.Root
+}
+
+// compareAttestationData returns the checkpoints on which the attestation data disagree.
+// Head block roots are compared only if checkHead is set, since they legitimately differ around late blocks.
+func compareAttestationData(data, other *phase0.AttestationData, checkHead bool) []attestationDataDisagreement {
+	var disagreements []attestationDataDisagreement
+	if data.Source.Epoch != other.Source.Epoch || data.Source.Root != other.Source.Root {
+		disagreements = append(disagreements, attestationDataDisagreement{"source", data.Source.Root, other.Source.Root})
+	}
+	if data.Target.Epoch != other.Target.Epoch || data.Target.Root != other.Target.Root {
+		disagreements = append(disagreements, attestationDataDisagreement{"target", data.Target.Root, other.Target.Root})
+	}
+	if checkHead && data.BeaconBlockRoot != other.BeaconBlockRoot {
+		disagreements = append(disagreements, attestationDataDisagreement{"head", data.BeaconBlockRoot, other.BeaconBlockRoot})
 	}
-	return root, nil
+	return disagreements
 }
@@ -0,0 +1,74 @@
+package goclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testAttestationData(source, target, head byte) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            64,
+		BeaconBlockRoot: phase0.Root{head},
+		Source:          &phase0.Checkpoint{Epoch: 1, Root: phase0.Root{source}},
+		Target:          &phase0.Checkpoint{Epoch: 2, Root: phase0.Root{target}},
+	}
+}
+
+func TestCompareAttestationData(t *testing.T) {
+	data := testAttestationData(1, 2, 3)
+
+	require.Empty(t, compareAttestationData(data, testAttestationData(1, 2, 3), true))
+	require.Empty(t, compareAttestationData(data, testAttestationData(1, 2, 4), false))
+
+	disagreements := compareAttestationData(data, testAttestationData(5, 2, 4), true)
+	require.Equal(t, []attestationDataDisagreement{
+		{checkpoint: "source", root: phase0.Root{1}, otherRoot: phase0.Root{5}},
+		{checkpoint: "head", root: phase0.Root{3}, otherRoot: phase0.Root{4}},
+	}, disagreements)
+
+	other := testAttestationData(1, 2, 3)
+	other.Target.Epoch = 3
+	disagreements = compareAttestationData(data, other, false)
+	require.Len(t, disagreements, 1)
+	require.Equal(t, "target", disagreements[0].checkpoint)
+}
+
+func TestAttestationDataCheck(t *testing.T) {
+	preferred := &fakeClient{data: testAttestationData(1, 2, 3)}
+	other := &fakeClient{data: testAttestationData(1, 2, 4)}
+	gc := newTestGoClient(preferred, other)
+
+	// Flagged disagreements use the preferred beacon node's data.
+	gc.attestationDataCheck = AttestationDataCheckFlag
+	gc.attestationDataCheckHead = true
+	data, _, err := gc.GetAttestationData(64, 0)
+	require.NoError(t, err)
+	require.Equal(t, preferred.data, data)
+	require.EqualValues(t, 1, other.requests.Load())
+
+	// Refused disagreements fail.
+	gc.attestationDataCheck = AttestationDataCheckRefuse
+	_, _, err = gc.GetAttestationData(64, 0)
+	require.ErrorContains(t, err, "beacon nodes disagree on attestation data (head)")
+
+	// Head block roots are ignored unless configured.
+	gc.attestationDataCheckHead = false
+	data, _, err = gc.GetAttestationData(64, 0)
+	require.NoError(t, err)
+	require.Equal(t, preferred.data, data)
+
+	other.data = testAttestationData(1, 5, 3)
+	_, _, err = gc.GetAttestationData(64, 0)
+	require.ErrorContains(t, err, "beacon nodes disagree on attestation data (target)")
+
+	// The data can't be compared if only one beacon node responds.
+	other.err = errors.New("unavailable")
+	data, _, err = gc.GetAttestationData(64, 0)
+	require.NoError(t, err)
+	require.Equal(t, preferred.data, data)
+
+	require.ErrorContains(t, AttestationDataCheck("sometimes").validate(), "unknown attestation data check")
+}
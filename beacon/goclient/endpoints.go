@@ -0,0 +1,381 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+// ErrNoEndpoint is returned when none of the beacon nodes is connected.
+var ErrNoEndpoint = errors.New("no beacon node is connected")
+
+// addrSeparator separates the endpoints in the beacon node address.
+const addrSeparator = ";"
+
+// dialFunc connects to the beacon node at the given address.
+type dialFunc func(ctx context.Context, addr string) (Client, error)
+
+// endpoint is one of the beacon nodes, which is connected lazily if it's unavailable on startup.
+type endpoint struct {
+	addr  string
+	label string // addr without credentials, for logs and metrics
+
+	// dialing serializes the dials, while mu only guards the client,
+	// so that a hanging dial doesn't block the calls to the other endpoints.
+	dialing sync.Mutex
+	mu      sync.Mutex
+	client  Client
+
+	healthy atomic.Bool
+	status  atomic.Int32
+
+	statusMetric   prometheus.Gauge
+	durationMetric prometheus.Observer
+}
+
+func parseEndpoints(nodeAddr string) []*endpoint {
+	var endpoints []*endpoint
+	for _, addr := range strings.Split(nodeAddr, addrSeparator) {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			endpoints = append(endpoints, newEndpoint(addr))
+		}
+	}
+	return endpoints
+}
+
+func newEndpoint(addr string) *endpoint {
+	label := endpointLabel(addr)
+	return &endpoint{
+		addr:           addr,
+		label:          label,
+		statusMetric:   metricsBeaconEndpointStatus.WithLabelValues(label),
+		durationMetric: metricsBeaconEndpointRequest.WithLabelValues(label),
+	}
+}
+
+// endpointLabel strips credentials and query parameters from the address,
+// so that it can be exposed in logs and metrics.
+func endpointLabel(addr string) string {
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		// The address may lack a scheme, such as "user:password@host:port".
+		u, err = url.Parse("http://" + addr)
+		if err != nil || u.Host == "" {
+			return "(invalid address)"
+		}
+		u.Scheme = ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return strings.TrimPrefix(u.String(), "//")
+}
+
+// connect returns the endpoint's client, connecting it if it isn't connected.
+func (e *endpoint) connect(ctx context.Context, dial dialFunc) (Client, error) {
+	if client := e.connected(); client != nil {
+		return client, nil
+	}
+
+	e.dialing.Lock()
+	defer e.dialing.Unlock()
+
+	// Another call may have connected it meanwhile.
+	if client := e.connected(); client != nil {
+		return client, nil
+	}
+	client, err := dial(ctx, e.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.client = client
+	e.mu.Unlock()
+	e.healthy.Store(true)
+	return client, nil
+}
+
+// connected returns the endpoint's client, or nil if it isn't connected.
+func (e *endpoint) connected() Client {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.client
+}
+
+func (e *endpoint) setStatus(status beaconNodeStatus) {
+	e.status.Store(int32(status))
+	e.statusMetric.Set(float64(status))
+}
+
+func (e *endpoint) observe(start time.Time, err error) {
+	if err == nil {
+		e.durationMetric.Observe(time.Since(start).Seconds())
+	}
+}
+
+// checkHealth returns an error if the beacon node doesn't respond, is syncing or is optimistic,
+// along with the status to report.
+func checkHealth(ctx context.Context, client Client) (beaconNodeStatus, error) {
+	nodeSyncingResp, err := client.NodeSyncing(ctx, &api.NodeSyncingOpts{})
+	if err != nil {
+		return statusUnknown, fmt.Errorf("failed to obtain node syncing status: %w", err)
+	}
+	if nodeSyncingResp == nil {
+		return statusUnknown, fmt.Errorf("node syncing response is nil")
+	}
+	if nodeSyncingResp.Data == nil {
+		return statusUnknown, fmt.Errorf("node syncing data is nil")
+	}
+	syncState := nodeSyncingResp.Data
+
+	// TODO: also check if syncState.ElOffline when github.com/attestantio/go-eth2-client supports it
+	if syncState.IsSyncing {
+		return statusSyncing, fmt.Errorf("syncing")
+	}
+	if syncState.IsOptimistic {
+		return statusSyncing, fmt.Errorf("optimistic")
+	}
+	return statusOK, nil
+}
+
+// checkEndpoint connects the endpoint if needed and updates its health.
+func (gc *goClient) checkEndpoint(ctx context.Context, e *endpoint) error {
+	// The client outlives the health check, so it's connected with the client's context.
+	client, err := e.connect(gc.ctx, gc.dial)
+	if err != nil {
+		e.setStatus(statusUnknown)
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	status, err := checkHealth(ctx, client)
+	e.setStatus(status)
+	e.healthy.Store(err == nil)
+	return err
+}
+
+// endpointError labels the error with the endpoint if there are several of them.
+func (gc *goClient) endpointError(e *endpoint, err error) error {
+	if len(gc.endpoints) == 1 {
+		return err
+	}
+	return fmt.Errorf("%s: %w", e.label, err)
+}
+
+// joinErrors joins the endpoints' errors, returning a single error as is.
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// endpointsByHealth returns the connected endpoints, healthy ones first, in the configured order.
+func (gc *goClient) endpointsByHealth() []*endpoint {
+	var healthy, unhealthy []*endpoint
+	for _, e := range gc.endpoints {
+		if e.connected() == nil {
+			continue
+		}
+		if e.healthy.Load() {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// activeEndpoints returns the healthy endpoints, or all connected endpoints if none of them is healthy.
+func (gc *goClient) activeEndpoints() []*endpoint {
+	endpoints := gc.endpointsByHealth()
+	for i, e := range endpoints {
+		if !e.healthy.Load() {
+			if i == 0 {
+				return endpoints
+			}
+			return endpoints[:i]
+		}
+	}
+	return endpoints
+}
+
+// failover calls f on the endpoints one by one, healthy ones first, until it succeeds.
+func failover[T any](gc *goClient, f func(client Client) (T, error)) (T, error) {
+	var zero T
+	endpoints := gc.endpointsByHealth()
+	if len(endpoints) == 0 {
+		return zero, ErrNoEndpoint
+	}
+
+	var errs []error
+	for _, e := range endpoints {
+		start := time.Now()
+		result, err := f(e.connected())
+		e.observe(start, err)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, gc.endpointError(e, err))
+	}
+	return zero, joinErrors(errs)
+}
+
+// race calls f on the active endpoints concurrently and returns the first successful result,
+// cancelling the remaining calls.
+func race[T any](gc *goClient, ctx context.Context, f func(ctx context.Context, client Client) (T, error)) (T, error) {
+	var zero T
+	endpoints := gc.activeEndpoints()
+	switch len(endpoints) {
+	case 0:
+		return zero, ErrNoEndpoint
+	case 1:
+		start := time.Now()
+		result, err := f(ctx, endpoints[0].connected())
+		endpoints[0].observe(start, err)
+		if err != nil {
+			return zero, gc.endpointError(endpoints[0], err)
+		}
+		return result, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type response struct {
+		result T
+		err    error
+	}
+	responses := make(chan response, len(endpoints))
+	for _, e := range endpoints {
+		go func(e *endpoint) {
+			start := time.Now()
+			result, err := f(ctx, e.connected())
+			e.observe(start, err)
+			if err != nil {
+				err = gc.endpointError(e, err)
+			}
+			responses <- response{result: result, err: err}
+		}(e)
+	}
+
+	var errs []error
+	for range endpoints {
+		resp := <-responses
+		if resp.err == nil {
+			return resp.result, nil
+		}
+		errs = append(errs, resp.err)
+	}
+	return zero, joinErrors(errs)
+}
+
+// broadcast calls f on the active endpoints concurrently and succeeds if any of them succeeds.
+func (gc *goClient) broadcast(ctx context.Context, f func(ctx context.Context, client Client) error) error {
+	endpoints := gc.activeEndpoints()
+	if len(endpoints) == 0 {
+		return ErrNoEndpoint
+	}
+
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			err := f(ctx, e.connected())
+			e.observe(start, err)
+			if err != nil {
+				errs[i] = gc.endpointError(e, err)
+			}
+		}(i, e)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return joinErrors(errs)
+}
+
+// endpointResult is the result of a call to one of the endpoints.
+type endpointResult[T any] struct {
+	endpoint *endpoint
+	result   T
+}
+
+// fetchAll calls f on the active endpoints concurrently and returns the successful results
+// in the endpoints' order of preference. It fails only if all calls fail.
+// Once the timeout passes, it returns the results received so far, or else the first one to arrive,
+// and the calls which are still running are left to finish in the background.
+func fetchAll[T any](gc *goClient, ctx context.Context, timeout time.Duration, f func(ctx context.Context, client Client) (T, error)) ([]endpointResult[T], error) {
+	endpoints := gc.activeEndpoints()
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoint
+	}
+
+	type response struct {
+		i      int
+		result T
+		err    error
+	}
+	responses := make(chan response, len(endpoints))
+	for i, e := range endpoints {
+		go func(i int, e *endpoint) {
+			start := time.Now()
+			result, err := f(ctx, e.connected())
+			e.observe(start, err)
+			responses <- response{i: i, result: result, err: err}
+		}(i, e)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	results := make([]*response, len(endpoints))
+	received, succeeded, timedOut := 0, 0, false
+	for received < len(endpoints) && (!timedOut || succeeded == 0) {
+		select {
+		case r := <-responses:
+			results[r.i] = &r
+			received++
+			if r.err == nil {
+				succeeded++
+			}
+		case <-timer.C:
+			timedOut = true
+		}
+	}
+
+	var successful []endpointResult[T]
+	var failed []error
+	for i, e := range endpoints {
+		switch r := results[i]; {
+		case r == nil:
+			gc.log.Debug("beacon node responded too late", fields.Address(e.label))
+		case r.err != nil:
+			gc.log.Warn("beacon node request failed", fields.Address(e.label), zap.Error(r.err))
+			failed = append(failed, gc.endpointError(e, r.err))
+		default:
+			successful = append(successful, endpointResult[T]{endpoint: e, result: r.result})
+		}
+	}
+	if len(successful) == 0 {
+		return nil, joinErrors(failed)
+	}
+	return successful, nil
+}
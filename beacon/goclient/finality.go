@@ -0,0 +1,72 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// finalizedBlock caches the execution block number of the last seen finalized checkpoint,
+// since the checkpoint changes at most once per epoch.
+type finalizedBlock struct {
+	mu          sync.Mutex
+	checkpoint  phase0.Checkpoint
+	blockNumber uint64
+}
+
+// FinalizedExecutionBlockNumber returns the number of the execution block of the finalized checkpoint.
+// The block is fetched only when the finalized checkpoint changes.
+func (gc *goClient) FinalizedExecutionBlockNumber(ctx context.Context) (uint64, error) {
+	finality, err := failover(gc, func(client Client) (*api.Response[*eth2apiv1.Finality], error) {
+		return client.Finality(ctx, &api.FinalityOpts{
+			Common: api.CommonOpts{Timeout: gc.commonTimeout},
+			State:  "head",
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to obtain finality checkpoints: %w", err)
+	}
+	if finality == nil || finality.Data == nil || finality.Data.Finalized == nil {
+		return 0, fmt.Errorf("finality checkpoints response is nil")
+	}
+	checkpoint := *finality.Data.Finalized
+	if checkpoint.Root == (phase0.Root{}) {
+		// Nothing is finalized yet.
+		return 0, nil
+	}
+
+	gc.finalized.mu.Lock()
+	defer gc.finalized.mu.Unlock()
+	if gc.finalized.checkpoint == checkpoint {
+		return gc.finalized.blockNumber, nil
+	}
+
+	resp, err := failover(gc, func(client Client) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+		return client.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Common: api.CommonOpts{Timeout: gc.longTimeout},
+			Block:  checkpoint.Root.String(),
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to obtain finalized block: %w", err)
+	}
+	if resp == nil {
+		return 0, fmt.Errorf("finalized block response is nil")
+	}
+	if resp.Data == nil {
+		return 0, fmt.Errorf("finalized block data is nil")
+	}
+	blockNumber, err := resp.Data.ExecutionBlockNumber()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get finalized execution block number: %w", err)
+	}
+
+	gc.finalized.checkpoint = checkpoint
+	gc.finalized.blockNumber = blockNumber
+	return blockNumber, nil
+}
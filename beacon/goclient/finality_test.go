@@ -0,0 +1,64 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func (c *fakeClient) Finality(ctx context.Context, opts *api.FinalityOpts) (*api.Response[*eth2apiv1.Finality], error) {
+	return &api.Response[*eth2apiv1.Finality]{Data: &eth2apiv1.Finality{Finalized: c.finalized}}, nil
+}
+
+func (c *fakeClient) SignedBeaconBlock(ctx context.Context, opts *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+	c.blockRequests.Add(1)
+	if opts.Block != c.finalized.Root.String() {
+		return nil, api.Error{StatusCode: 404}
+	}
+	return &api.Response[*spec.VersionedSignedBeaconBlock]{Data: &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Body: &capella.BeaconBlockBody{
+					ExecutionPayload: &capella.ExecutionPayload{BlockNumber: c.executionBlock},
+				},
+			},
+		},
+	}}, nil
+}
+
+func TestFinalizedExecutionBlockNumber(t *testing.T) {
+	client := &fakeClient{
+		finalized:      &phase0.Checkpoint{},
+		executionBlock: 100,
+	}
+	gc := newTestGoClient(client)
+
+	// Nothing is finalized yet.
+	blockNumber, err := gc.FinalizedExecutionBlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, blockNumber)
+	require.Zero(t, client.blockRequests.Load())
+
+	// The block is fetched once per finalized checkpoint.
+	client.finalized = &phase0.Checkpoint{Epoch: 1, Root: phase0.Root{1}}
+	for i := 0; i < 2; i++ {
+		blockNumber, err = gc.FinalizedExecutionBlockNumber(context.Background())
+		require.NoError(t, err)
+		require.EqualValues(t, 100, blockNumber)
+		require.EqualValues(t, 1, client.blockRequests.Load())
+	}
+
+	client.finalized = &phase0.Checkpoint{Epoch: 2, Root: phase0.Root{2}}
+	client.executionBlock = 132
+	blockNumber, err = gc.FinalizedExecutionBlockNumber(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 132, blockNumber)
+	require.EqualValues(t, 2, client.blockRequests.Load())
+}
@@ -41,11 +41,27 @@ var (
 	allMetrics = []prometheus.Collector{
 		metricsBeaconNodeStatus,
 		metricsBeaconDataRequest,
+		metricsBeaconEndpointStatus,
+		metricsBeaconEndpointRequest,
+		metricsAttestationDataDisagreements,
 	}
 	metricsBeaconNodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssv_beacon_status",
 		Help: "Status of the connected beacon node",
 	})
+	metricsBeaconEndpointStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_beacon_endpoint_status",
+		Help: "Status of each configured beacon node",
+	}, []string{"endpoint"})
+	metricsBeaconEndpointRequest = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ssv_beacon_endpoint_request_duration_seconds",
+		Help:    "Duration of successful requests to each beacon node (seconds)",
+		Buckets: []float64{0.02, 0.05, 0.1, 0.2, 0.5, 1, 5},
+	}, []string{"endpoint"})
+	metricsAttestationDataDisagreements = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_attestation_data_disagreements",
+		Help: "Count of attestation data disagreements between beacon nodes, by checkpoint",
+	}, []string{"checkpoint"})
 
 	// metricsBeaconDataRequest is located here to avoid including waiting for 1/3 or 2/3 of slot time into request duration.
 	metricsBeaconDataRequest = promauto.NewHistogramVec(prometheus.HistogramOpts{
@@ -106,6 +122,7 @@ type Client interface {
 	eth2client.NodeClientProvider
 	eth2client.SpecProvider
 	eth2client.GenesisProvider
+	eth2client.ForkScheduleProvider
 
 	eth2client.AttestationDataProvider
 	eth2client.AttestationsSubmitter
@@ -123,6 +140,8 @@ type Client interface {
 	eth2client.DomainProvider
 	eth2client.SyncCommitteeMessagesSubmitter
 	eth2client.BeaconBlockRootProvider
+	eth2client.SignedBeaconBlockProvider
+	eth2client.FinalityProvider
 	eth2client.SyncCommitteeContributionProvider
 	eth2client.SyncCommitteeContributionsSubmitter
 	eth2client.ValidatorsProvider
@@ -143,7 +162,8 @@ type goClient struct {
 	log                  *zap.Logger
 	ctx                  context.Context
 	network              beaconprotocol.Network
-	client               Client
+	endpoints            []*endpoint
+	dial                 dialFunc
 	nodeVersion          string
 	nodeClient           NodeClient
 	graffiti             []byte
@@ -154,6 +174,16 @@ type goClient struct {
 	registrationCache    map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration
 	commonTimeout        time.Duration
 	longTimeout          time.Duration
+
+	attestationDataCheck     AttestationDataCheck
+	attestationDataCheckHead bool
+
+	// forkSchedule and genesisValidatorsRoot are fetched once, on the first call to ForkInfo.
+	forkInfoMu            sync.Mutex
+	forkSchedule          []*phase0.Fork
+	genesisValidatorsRoot phase0.Root
+
+	finalized finalizedBlock
 }
 
 // New init new client and go-client instance
@@ -163,8 +193,6 @@ func New(
 	operatorDataStore operatordatastore.OperatorDataStore,
 	slotTickerProvider slotticker.Provider,
 ) (beaconprotocol.BeaconNode, error) {
-	logger.Info("consensus client: connecting", fields.Address(opt.BeaconNodeAddr), fields.Network(string(opt.Network.BeaconNetwork)))
-
 	commonTimeout := opt.CommonTimeout
 	if commonTimeout == 0 {
 		commonTimeout = DefaultCommonTimeout
@@ -174,87 +202,146 @@ func New(
 		longTimeout = DefaultLongTimeout
 	}
 
-	httpClient, err := eth2clienthttp.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		eth2clienthttp.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
-		eth2clienthttp.WithTimeout(commonTimeout),
-		eth2clienthttp.WithReducedMemoryUsage(true),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create http client: %w", err)
+	attestationDataCheck := AttestationDataCheck(opt.AttestationDataCheck)
+	if err := attestationDataCheck.validate(); err != nil {
+		return nil, err
 	}
 
 	client := &goClient{
 		log:               logger,
 		ctx:               opt.Context,
 		network:           opt.Network,
-		client:            httpClient.(*eth2clienthttp.Service),
+		endpoints:         parseEndpoints(opt.BeaconNodeAddr),
 		graffiti:          opt.Graffiti,
 		gasLimit:          opt.GasLimit,
 		operatorDataStore: operatorDataStore,
 		registrationCache: map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
 		commonTimeout:     commonTimeout,
 		longTimeout:       longTimeout,
-	}
 
-	nodeVersionResp, err := client.client.NodeVersion(opt.Context, &api.NodeVersionOpts{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get node version: %w", err)
+		attestationDataCheck:     attestationDataCheck,
+		attestationDataCheckHead: opt.AttestationDataCheckHead,
 	}
-	if nodeVersionResp == nil {
-		return nil, fmt.Errorf("node version response is nil")
+	client.dial = httpDialer(commonTimeout)
+	if len(client.endpoints) == 0 {
+		return nil, fmt.Errorf("no beacon node address provided")
+	}
+
+	// The address may contain credentials, so only the endpoints' labels are logged.
+	labels := make([]string, len(client.endpoints))
+	for i, e := range client.endpoints {
+		labels[i] = e.label
 	}
-	client.nodeVersion = nodeVersionResp.Data
-	client.nodeClient = ParseNodeClient(nodeVersionResp.Data)
+	logger.Info("consensus client: connecting",
+		fields.Address(strings.Join(labels, addrSeparator)),
+		fields.Network(string(opt.Network.BeaconNetwork)))
 
-	logger.Info("consensus client connected",
-		fields.Name(httpClient.Name()),
-		fields.Address(httpClient.Address()),
-		zap.String("client", string(client.nodeClient)),
-		zap.String("version", client.nodeVersion),
-	)
+	if err := client.connect(); err != nil {
+		return nil, err
+	}
 
 	go client.registrationSubmitter(slotTickerProvider)
 
 	return client, nil
 }
 
+// httpDialer returns a dialFunc which connects to beacon nodes over HTTP.
+func httpDialer(timeout time.Duration) dialFunc {
+	return func(ctx context.Context, addr string) (Client, error) {
+		httpClient, err := eth2clienthttp.New(ctx,
+			// WithAddress supplies the address of the beacon node, in host:port format.
+			eth2clienthttp.WithAddress(addr),
+			// LogLevel supplies the level of logging to carry out.
+			eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
+			eth2clienthttp.WithTimeout(timeout),
+			eth2clienthttp.WithReducedMemoryUsage(true),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return httpClient.(*eth2clienthttp.Service), nil
+	}
+}
+
+// connect connects to the beacon nodes, succeeding if any of them is available.
+// The unavailable ones are connected later, on health checks.
+func (gc *goClient) connect() error {
+	var errs []error
+	for _, e := range gc.endpoints {
+		client, err := e.connect(gc.ctx, gc.dial)
+		if err != nil {
+			gc.log.Warn("could not connect to beacon node", fields.Address(e.label), zap.Error(err))
+			e.setStatus(statusUnknown)
+			errs = append(errs, gc.endpointError(e, err))
+			continue
+		}
+
+		nodeVersionResp, err := client.NodeVersion(gc.ctx, &api.NodeVersionOpts{})
+		if err == nil && nodeVersionResp == nil {
+			err = fmt.Errorf("node version response is nil")
+		}
+		if err != nil {
+			gc.log.Warn("could not get beacon node version", fields.Address(e.label), zap.Error(err))
+			e.setStatus(statusUnknown)
+			errs = append(errs, gc.endpointError(e, fmt.Errorf("failed to get node version: %w", err)))
+			continue
+		}
+		// The node client is reported for the preferred beacon node.
+		if gc.nodeVersion == "" {
+			gc.nodeVersion = nodeVersionResp.Data
+			gc.nodeClient = ParseNodeClient(nodeVersionResp.Data)
+		}
+
+		gc.log.Info("consensus client connected",
+			fields.Name(client.Name()),
+			fields.Address(e.label),
+			zap.String("client", string(ParseNodeClient(nodeVersionResp.Data))),
+			zap.String("version", nodeVersionResp.Data),
+		)
+	}
+	if len(errs) == len(gc.endpoints) {
+		return fmt.Errorf("failed to create http client: %w", joinErrors(errs))
+	}
+	return nil
+}
+
 func (gc *goClient) NodeClient() NodeClient {
 	return gc.nodeClient
 }
 
-// Healthy returns if beacon node is currently healthy: responds to requests, not in the syncing state, not optimistic
+// Healthy returns if any of the beacon nodes is currently healthy: responds to requests, not in the syncing state, not optimistic
 // (for optimistic see https://github.com/ethereum/consensus-specs/blob/dev/sync/optimistic.md#block-production).
 func (gc *goClient) Healthy(ctx context.Context) error {
-	nodeSyncingResp, err := gc.client.NodeSyncing(ctx, &api.NodeSyncingOpts{})
-	if err != nil {
-		// TODO: get rid of global variable, pass metrics to goClient
-		metricsBeaconNodeStatus.Set(float64(statusUnknown))
-		return fmt.Errorf("failed to obtain node syncing status: %w", err)
+	errs := make([]error, len(gc.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range gc.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			errs[i] = gc.checkEndpoint(ctx, e)
+		}(i, e)
 	}
-	if nodeSyncingResp == nil {
-		metricsBeaconNodeStatus.Set(float64(statusUnknown))
-		return fmt.Errorf("node syncing response is nil")
-	}
-	if nodeSyncingResp.Data == nil {
-		metricsBeaconNodeStatus.Set(float64(statusUnknown))
-		return fmt.Errorf("node syncing data is nil")
-	}
-	syncState := nodeSyncingResp.Data
+	wg.Wait()
 
-	// TODO: also check if syncState.ElOffline when github.com/attestantio/go-eth2-client supports it
-	metricsBeaconNodeStatus.Set(float64(statusSyncing))
-	if syncState.IsSyncing {
-		return fmt.Errorf("syncing")
+	// The overall status is the status of the best beacon node.
+	status := statusUnknown
+	for _, e := range gc.endpoints {
+		if endpointStatus := beaconNodeStatus(e.status.Load()); endpointStatus > status {
+			status = endpointStatus
+		}
 	}
-	if syncState.IsOptimistic {
-		return fmt.Errorf("optimistic")
+	// TODO: get rid of global variable, pass metrics to goClient
+	metricsBeaconNodeStatus.Set(float64(status))
+
+	// The consensus client is healthy as long as any of the beacon nodes is.
+	var unhealthy []error
+	for i, err := range errs {
+		if err == nil {
+			return nil
+		}
+		unhealthy = append(unhealthy, gc.endpointError(gc.endpoints[i], err))
 	}
-
-	metricsBeaconNodeStatus.Set(float64(statusOK))
-	return nil
+	return joinErrors(unhealthy)
 }
 
 // GetBeaconNetwork returns the beacon network the node is on
@@ -271,5 +358,8 @@ func (gc *goClient) slotStartTime(slot phase0.Slot) time.Time {
 }
 
 func (gc *goClient) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
-	return gc.client.Events(ctx, topics, handler)
+	_, err := failover(gc, func(client Client) (struct{}, error) {
+		return struct{}{}, client.Events(ctx, topics, handler)
+	})
+	return err
 }
@@ -3,6 +3,7 @@ package goclient
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/operator/slotticker"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
 const (
@@ -28,9 +30,11 @@ const (
 
 // ProposerDuties returns proposer duties for the given epoch.
 func (gc *goClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error) {
-	resp, err := gc.client.ProposerDuties(ctx, &api.ProposerDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	resp, err := failover(gc, func(client Client) (*api.Response[[]*eth2apiv1.ProposerDuty], error) {
+		return client.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain proposer duties: %w", err)
@@ -42,8 +46,17 @@ func (gc *goClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, vali
 	return resp.Data, nil
 }
 
+var _ beaconprotocol.BuilderPolicyProposer = (*goClient)(nil)
+
 // GetBeaconBlock returns beacon block by the given slot, graffiti, and randao.
 func (gc *goClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return gc.GetBeaconBlockWithBuilderPolicy(slot, graffitiBytes, randao, nil)
+}
+
+// GetBeaconBlockWithBuilderPolicy returns beacon block by the given slot, graffiti, and randao,
+// choosing between the local and the builder payload according to the policy.
+// A nil policy leaves the choice to the beacon node.
+func (gc *goClient) GetBeaconBlockWithBuilderPolicy(slot phase0.Slot, graffitiBytes, randao []byte, policy *beaconprotocol.BuilderPolicy) (ssz.Marshaler, spec.DataVersion, error) {
 	sig := phase0.BLSSignature{}
 	copy(sig[:], randao[:])
 
@@ -51,24 +64,73 @@ func (gc *goClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 	copy(graffiti[:], graffitiBytes[:])
 
 	reqStart := time.Now()
-	proposalResp, err := gc.client.Proposal(gc.ctx, &api.ProposalOpts{
-		Slot:                   slot,
-		RandaoReveal:           sig,
-		Graffiti:               graffiti,
-		SkipRandaoVerification: false,
+	beaconBlock, err := gc.proposal(slot, sig, graffiti, policy.BoostFactor())
+	if err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	mode := beaconprotocol.BuilderModeDefault
+	if policy != nil {
+		mode = policy.Mode
+	}
+	if mode == beaconprotocol.BuilderModeMinBid && beaconBlock.Blinded && !bidAtLeast(beaconBlock.ExecutionValue, policy.MinBid) {
+		gc.log.Info("builder bid is below the minimum, requesting a local payload",
+			fields.Slot(slot),
+			zap.Stringer("bid", beaconBlock.ExecutionValue),
+			zap.Stringer("min_bid", policy.MinBid))
+
+		localFactor := uint64(0)
+		beaconBlock, err = gc.proposal(slot, sig, graffiti, &localFactor)
+		if err != nil {
+			return nil, DataVersionNil, err
+		}
+	}
+
+	metricsProposerDataRequest.Observe(time.Since(reqStart).Seconds())
+
+	path := "local"
+	if beaconBlock.Blinded {
+		path = "builder"
+	}
+	gc.log.Info("got proposal",
+		fields.Slot(slot),
+		zap.String("builder_mode", string(mode)),
+		zap.String("path", path),
+		zap.Stringer("bid", beaconBlock.ExecutionValue))
+
+	return proposalBlock(beaconBlock)
+}
+
+// proposal requests a proposal with the given builder boost factor, or the beacon node's default if nil.
+func (gc *goClient) proposal(slot phase0.Slot, sig phase0.BLSSignature, graffiti [32]byte, builderBoostFactor *uint64) (*api.VersionedProposal, error) {
+	proposalResp, err := failover(gc, func(client Client) (*api.Response[*api.VersionedProposal], error) {
+		return client.Proposal(gc.ctx, &api.ProposalOpts{
+			Slot:                   slot,
+			RandaoReveal:           sig,
+			Graffiti:               graffiti,
+			SkipRandaoVerification: false,
+			BuilderBoostFactor:     builderBoostFactor,
+		})
 	})
 	if err != nil {
-		return nil, DataVersionNil, fmt.Errorf("failed to get proposal: %w", err)
+		return nil, fmt.Errorf("failed to get proposal: %w", err)
 	}
 	if proposalResp == nil {
-		return nil, DataVersionNil, fmt.Errorf("proposal response is nil")
+		return nil, fmt.Errorf("proposal response is nil")
 	}
 	if proposalResp.Data == nil {
-		return nil, DataVersionNil, fmt.Errorf("proposal data is nil")
+		return nil, fmt.Errorf("proposal data is nil")
 	}
+	return proposalResp.Data, nil
+}
 
-	metricsProposerDataRequest.Observe(time.Since(reqStart).Seconds())
-	beaconBlock := proposalResp.Data
+// bidAtLeast returns whether the bid, in wei, is known and at least min.
+func bidAtLeast(bid, min *big.Int) bool {
+	return bid != nil && bid.Cmp(min) >= 0
+}
+
+// proposalBlock returns the block of the proposal, checking that it's complete.
+func proposalBlock(beaconBlock *api.VersionedProposal) (ssz.Marshaler, spec.DataVersion, error) {
 
 	if beaconBlock.Blinded {
 		switch beaconBlock.Version {
@@ -169,7 +231,9 @@ func (gc *goClient) SubmitBlindedBeaconBlock(block *api.VersionedBlindedProposal
 		Proposal: signedBlock,
 	}
 
-	return gc.client.SubmitBlindedProposal(gc.ctx, opts)
+	return gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+		return client.SubmitBlindedProposal(ctx, opts)
+	})
 }
 
 // SubmitBeaconBlock submit the block to the node
@@ -215,7 +279,9 @@ func (gc *goClient) SubmitBeaconBlock(block *api.VersionedProposal, sig phase0.B
 		Proposal: signedBlock,
 	}
 
-	return gc.client.SubmitProposal(gc.ctx, opts)
+	return gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+		return client.SubmitProposal(ctx, opts)
+	})
 }
 
 func (gc *goClient) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
@@ -230,7 +296,9 @@ func (gc *goClient) SubmitProposalPreparation(feeRecipients map[phase0.Validator
 			FeeRecipient:   recipient,
 		})
 	}
-	return gc.client.SubmitProposalPreparations(gc.ctx, preparations)
+	return gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+		return client.SubmitProposalPreparations(ctx, preparations)
+	})
 }
 
 func (gc *goClient) updateBatchRegistrationCache(registration *api.VersionedSignedValidatorRegistration) error {
@@ -336,7 +404,10 @@ func (gc *goClient) submitBatchedRegistrations(slot phase0.Slot, registrations [
 			bs = len(registrations)
 		}
 
-		if err := gc.client.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs]); err != nil {
+		batch := registrations[0:bs]
+		if err := gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+			return client.SubmitValidatorRegistrations(ctx, batch)
+		}); err != nil {
 			return err
 		}
 
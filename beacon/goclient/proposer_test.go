@@ -0,0 +1,121 @@
+package goclient
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/stretchr/testify/require"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// proposalClient returns a builder proposal with the given bid, unless asked for a local one.
+type proposalClient struct {
+	fakeClient
+
+	bid *big.Int
+
+	mu            sync.Mutex
+	boostFactors  []*uint64
+	localProposal *capella.BeaconBlock
+}
+
+func (c *proposalClient) Proposal(ctx context.Context, opts *api.ProposalOpts) (*api.Response[*api.VersionedProposal], error) {
+	c.mu.Lock()
+	c.boostFactors = append(c.boostFactors, opts.BuilderBoostFactor)
+	c.mu.Unlock()
+
+	if opts.BuilderBoostFactor != nil && *opts.BuilderBoostFactor == 0 {
+		return &api.Response[*api.VersionedProposal]{Data: &api.VersionedProposal{
+			Version: spec.DataVersionCapella,
+			Capella: c.localProposal,
+		}}, nil
+	}
+	return &api.Response[*api.VersionedProposal]{Data: &api.VersionedProposal{
+		Version:        spec.DataVersionCapella,
+		Blinded:        true,
+		ExecutionValue: c.bid,
+		CapellaBlinded: &apiv1capella.BlindedBeaconBlock{
+			Body: &apiv1capella.BlindedBeaconBlockBody{
+				ExecutionPayloadHeader: &capella.ExecutionPayloadHeader{},
+			},
+		},
+	}}, nil
+}
+
+func TestGetBeaconBlockWithBuilderPolicy(t *testing.T) {
+	uint64Ptr := func(v uint64) *uint64 { return &v }
+
+	tests := []struct {
+		name         string
+		policy       *beaconprotocol.BuilderPolicy
+		bid          int64
+		blinded      bool
+		boostFactors []*uint64
+	}{
+		{
+			name:         "default",
+			bid:          100,
+			blinded:      true,
+			boostFactors: []*uint64{nil},
+		},
+		{
+			name:         "always local",
+			policy:       &beaconprotocol.BuilderPolicy{Mode: beaconprotocol.BuilderModeLocal},
+			bid:          100,
+			blinded:      false,
+			boostFactors: []*uint64{uint64Ptr(0)},
+		},
+		{
+			name:         "always builder",
+			policy:       &beaconprotocol.BuilderPolicy{Mode: beaconprotocol.BuilderModeBuilder},
+			bid:          100,
+			blinded:      true,
+			boostFactors: []*uint64{uint64Ptr(math.MaxUint64)},
+		},
+		{
+			name:         "bid above minimum",
+			policy:       &beaconprotocol.BuilderPolicy{Mode: beaconprotocol.BuilderModeMinBid, MinBid: big.NewInt(100)},
+			bid:          100,
+			blinded:      true,
+			boostFactors: []*uint64{nil},
+		},
+		{
+			name:         "bid below minimum",
+			policy:       &beaconprotocol.BuilderPolicy{Mode: beaconprotocol.BuilderModeMinBid, MinBid: big.NewInt(101)},
+			bid:          100,
+			blinded:      false,
+			boostFactors: []*uint64{nil, uint64Ptr(0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &proposalClient{
+				bid: big.NewInt(tt.bid),
+				localProposal: &capella.BeaconBlock{
+					Body: &capella.BeaconBlockBody{ExecutionPayload: &capella.ExecutionPayload{}},
+				},
+			}
+			gc := newTestGoClient(nil)
+			gc.endpoints[0].client = client
+			gc.endpoints[0].healthy.Store(true)
+
+			block, version, err := gc.GetBeaconBlockWithBuilderPolicy(1, nil, nil, tt.policy)
+			require.NoError(t, err)
+			require.Equal(t, spec.DataVersionCapella, version)
+			if tt.blinded {
+				require.IsType(t, &apiv1capella.BlindedBeaconBlock{}, block)
+			} else {
+				require.Equal(t, client.localProposal, block)
+			}
+			require.Equal(t, tt.boostFactors, client.boostFactors)
+		})
+	}
+}
@@ -5,44 +5,63 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"hash"
+	"sort"
 	"sync"
 
 	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
 )
 
-func (gc *goClient) computeVoluntaryExitDomain(ctx context.Context) (phase0.Domain, error) {
-	specResponse, err := gc.client.Spec(gc.ctx, &api.SpecOpts{})
+// capellaForkVersion returns the capella fork version from the network's spec,
+// or from the beacon node if the network's forks aren't known.
+func (gc *goClient) capellaForkVersion() (phase0.Version, error) {
+	if fork, ok := gc.network.Fork("capella"); ok {
+		return fork.Version, nil
+	}
+
+	specResponse, err := failover(gc, func(client Client) (*api.Response[map[string]any], error) {
+		return client.Spec(gc.ctx, &api.SpecOpts{})
+	})
 	if err != nil {
-		return phase0.Domain{}, fmt.Errorf("failed to obtain spec response: %w", err)
+		return phase0.Version{}, fmt.Errorf("failed to obtain spec response: %w", err)
 	}
 	if specResponse == nil {
-		return phase0.Domain{}, fmt.Errorf("spec response is nil")
+		return phase0.Version{}, fmt.Errorf("spec response is nil")
 	}
 	if specResponse.Data == nil {
-		return phase0.Domain{}, fmt.Errorf("spec response data is nil")
+		return phase0.Version{}, fmt.Errorf("spec response data is nil")
 	}
 
-	// TODO: consider storing fork version and genesis validators root in goClient
-	//		instead of fetching it every time
-
 	forkVersionRaw, ok := specResponse.Data["CAPELLA_FORK_VERSION"]
 	if !ok {
-		return phase0.Domain{}, fmt.Errorf("capella fork version not known by chain")
+		return phase0.Version{}, fmt.Errorf("capella fork version not known by chain")
 	}
 	forkVersion, ok := forkVersionRaw.(phase0.Version)
 	if !ok {
-		return phase0.Domain{}, fmt.Errorf("failed to decode capella fork version")
+		return phase0.Version{}, fmt.Errorf("failed to decode capella fork version")
+	}
+	return forkVersion, nil
+}
+
+func (gc *goClient) computeVoluntaryExitDomain(ctx context.Context) (phase0.Domain, error) {
+	forkVersion, err := gc.capellaForkVersion()
+	if err != nil {
+		return phase0.Domain{}, err
 	}
 
 	forkData := &phase0.ForkData{
 		CurrentVersion: forkVersion,
 	}
 
-	genesisResponse, err := gc.client.Genesis(ctx, &api.GenesisOpts{})
+	// TODO: consider storing genesis validators root in goClient
+	//		instead of fetching it every time
+	genesisResponse, err := failover(gc, func(client Client) (*api.Response[*eth2apiv1.Genesis], error) {
+		return client.Genesis(ctx, &api.GenesisOpts{})
+	})
 	if err != nil {
 		return phase0.Domain{}, fmt.Errorf("failed to obtain genesis response: %w", err)
 	}
@@ -84,7 +103,9 @@ func (gc *goClient) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (ph
 		return gc.computeVoluntaryExitDomain(gc.ctx)
 	}
 
-	data, err := gc.client.Domain(gc.ctx, domain, epoch)
+	data, err := failover(gc, func(client Client) (phase0.Domain, error) {
+		return client.Domain(gc.ctx, domain, epoch)
+	})
 	if err != nil {
 		return phase0.Domain{}, err
 	}
@@ -211,3 +232,48 @@ func ToBytes4(x []byte) [4]byte {
 	copy(y[:], x)
 	return y
 }
+
+// ForkInfo returns the fork at the given epoch and the genesis validators root,
+// which remote signers need to compute signature domains.
+func (gc *goClient) ForkInfo(epoch phase0.Epoch) (*phase0.Fork, phase0.Root, error) {
+	gc.forkInfoMu.Lock()
+	defer gc.forkInfoMu.Unlock()
+
+	if gc.forkSchedule == nil {
+		scheduleResponse, err := failover(gc, func(client Client) (*api.Response[[]*phase0.Fork], error) {
+			return client.ForkSchedule(gc.ctx, &api.ForkScheduleOpts{})
+		})
+		if err != nil {
+			return nil, phase0.Root{}, fmt.Errorf("failed to obtain fork schedule: %w", err)
+		}
+		if scheduleResponse == nil || len(scheduleResponse.Data) == 0 {
+			return nil, phase0.Root{}, fmt.Errorf("fork schedule response is empty")
+		}
+
+		genesisResponse, err := failover(gc, func(client Client) (*api.Response[*eth2apiv1.Genesis], error) {
+			return client.Genesis(gc.ctx, &api.GenesisOpts{})
+		})
+		if err != nil {
+			return nil, phase0.Root{}, fmt.Errorf("failed to obtain genesis response: %w", err)
+		}
+		if genesisResponse == nil || genesisResponse.Data == nil {
+			return nil, phase0.Root{}, fmt.Errorf("genesis response is nil")
+		}
+
+		schedule := scheduleResponse.Data
+		sort.Slice(schedule, func(i, j int) bool {
+			return schedule[i].Epoch < schedule[j].Epoch
+		})
+		gc.forkSchedule = schedule
+		gc.genesisValidatorsRoot = genesisResponse.Data.GenesisValidatorsRoot
+	}
+
+	fork := gc.forkSchedule[0]
+	for _, f := range gc.forkSchedule[1:] {
+		if f.Epoch > epoch {
+			break
+		}
+		fork = f
+	}
+	return fork, gc.genesisValidatorsRoot, nil
+}
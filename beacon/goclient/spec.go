@@ -0,0 +1,124 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// forkEpochSuffix is the suffix of the fork epochs' keys in the beacon node's spec, such as CAPELLA_FORK_EPOCH.
+const forkEpochSuffix = "_FORK_EPOCH"
+
+// FetchSpec returns the beacon chain spec of the first available beacon node,
+// before the consensus client is set up, since its timing depends on the spec.
+func FetchSpec(ctx context.Context, logger *zap.Logger, opt beaconprotocol.Options) (beaconprotocol.Spec, error) {
+	timeout := opt.CommonTimeout
+	if timeout == 0 {
+		timeout = DefaultCommonTimeout
+	}
+	dial := httpDialer(timeout)
+
+	endpoints := parseEndpoints(opt.BeaconNodeAddr)
+	if len(endpoints) == 0 {
+		return beaconprotocol.Spec{}, fmt.Errorf("no beacon node address provided")
+	}
+
+	var errs []error
+	for _, e := range endpoints {
+		spec, err := fetchSpec(ctx, dial, e.addr)
+		if err != nil {
+			logger.Warn("could not fetch beacon spec", fields.Address(e.label), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", e.label, err))
+			continue
+		}
+		return spec, nil
+	}
+	return beaconprotocol.Spec{}, joinErrors(errs)
+}
+
+func fetchSpec(ctx context.Context, dial dialFunc, addr string) (beaconprotocol.Spec, error) {
+	client, err := dial(ctx, addr)
+	if err != nil {
+		return beaconprotocol.Spec{}, fmt.Errorf("failed to connect: %w", err)
+	}
+	specResponse, err := client.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return beaconprotocol.Spec{}, fmt.Errorf("failed to obtain spec response: %w", err)
+	}
+	if specResponse == nil {
+		return beaconprotocol.Spec{}, fmt.Errorf("spec response is nil")
+	}
+	if specResponse.Data == nil {
+		return beaconprotocol.Spec{}, fmt.Errorf("spec response data is nil")
+	}
+	return parseSpec(specResponse.Data)
+}
+
+// parseSpec parses the timing and the scheduled forks from the beacon node's spec.
+// Values which the beacon node doesn't provide are left unset.
+func parseSpec(data map[string]any) (beaconprotocol.Spec, error) {
+	var spec beaconprotocol.Spec
+
+	if v, ok := data["SECONDS_PER_SLOT"]; ok {
+		slotDuration, ok := v.(time.Duration)
+		if !ok {
+			return beaconprotocol.Spec{}, fmt.Errorf("failed to decode seconds per slot")
+		}
+		spec.SlotDuration = slotDuration
+	}
+	if v, ok := data["SLOTS_PER_EPOCH"]; ok {
+		slotsPerEpoch, ok := v.(uint64)
+		if !ok {
+			return beaconprotocol.Spec{}, fmt.Errorf("failed to decode slots per epoch")
+		}
+		spec.SlotsPerEpoch = slotsPerEpoch
+	}
+	if v, ok := data["EPOCHS_PER_SYNC_COMMITTEE_PERIOD"]; ok {
+		epochsPerPeriod, ok := v.(uint64)
+		if !ok {
+			return beaconprotocol.Spec{}, fmt.Errorf("failed to decode epochs per sync committee period")
+		}
+		spec.EpochsPerSyncCommitteePeriod = epochsPerPeriod
+	}
+
+	for key, v := range data {
+		if !strings.HasSuffix(key, forkEpochSuffix) {
+			continue
+		}
+		prefix := strings.TrimSuffix(key, forkEpochSuffix)
+		epoch, ok := v.(uint64)
+		if !ok {
+			return beaconprotocol.Spec{}, fmt.Errorf("failed to decode %s fork epoch", prefix)
+		}
+		// Forks at the far future epoch aren't scheduled yet.
+		if phase0.Epoch(epoch) == FarFutureEpoch {
+			continue
+		}
+		version, ok := data[prefix+"_FORK_VERSION"].(phase0.Version)
+		if !ok {
+			return beaconprotocol.Spec{}, fmt.Errorf("failed to decode %s fork version", prefix)
+		}
+		spec.Forks = append(spec.Forks, beaconprotocol.Fork{
+			Name:    strings.ToLower(prefix),
+			Version: version,
+			Epoch:   phase0.Epoch(epoch),
+		})
+	}
+	sort.Slice(spec.Forks, func(i, j int) bool {
+		if spec.Forks[i].Epoch != spec.Forks[j].Epoch {
+			return spec.Forks[i].Epoch < spec.Forks[j].Epoch
+		}
+		return spec.Forks[i].Name < spec.Forks[j].Name
+	})
+
+	return spec, spec.Validate()
+}
@@ -0,0 +1,51 @@
+package goclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestParseSpec(t *testing.T) {
+	spec, err := parseSpec(map[string]any{
+		"SECONDS_PER_SLOT":                 2 * time.Second,
+		"SLOTS_PER_EPOCH":                  uint64(8),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": uint64(4),
+		"GENESIS_FORK_VERSION":             phase0.Version{0x10},
+		"ALTAIR_FORK_EPOCH":                uint64(0),
+		"ALTAIR_FORK_VERSION":              phase0.Version{0x20},
+		"BELLATRIX_FORK_EPOCH":             uint64(0),
+		"BELLATRIX_FORK_VERSION":           phase0.Version{0x30},
+		"CAPELLA_FORK_EPOCH":               uint64(2),
+		"CAPELLA_FORK_VERSION":             phase0.Version{0x40},
+		"DENEB_FORK_EPOCH":                 uint64(FarFutureEpoch),
+		"DENEB_FORK_VERSION":               phase0.Version{0x50},
+	})
+	require.NoError(t, err)
+	require.Equal(t, beaconprotocol.Spec{
+		SlotDuration:                 2 * time.Second,
+		SlotsPerEpoch:                8,
+		EpochsPerSyncCommitteePeriod: 4,
+		Forks: []beaconprotocol.Fork{
+			{Name: "altair", Version: [4]byte{0x20}, Epoch: 0},
+			{Name: "bellatrix", Version: [4]byte{0x30}, Epoch: 0},
+			{Name: "capella", Version: [4]byte{0x40}, Epoch: 2},
+		},
+	}, spec)
+
+	// Values which the beacon node doesn't provide are left unset.
+	spec, err = parseSpec(map[string]any{"SLOTS_PER_EPOCH": uint64(32)})
+	require.NoError(t, err)
+	require.Equal(t, beaconprotocol.Spec{SlotsPerEpoch: 32}, spec)
+
+	_, err = parseSpec(map[string]any{"SLOTS_PER_EPOCH": "32"})
+	require.ErrorContains(t, err, "failed to decode slots per epoch")
+	_, err = parseSpec(map[string]any{"CAPELLA_FORK_EPOCH": uint64(2)})
+	require.ErrorContains(t, err, "failed to decode CAPELLA fork version")
+	_, err = parseSpec(map[string]any{"SLOTS_PER_EPOCH": uint64(2)})
+	require.ErrorContains(t, err, "slots per epoch 2 are less than 4")
+}
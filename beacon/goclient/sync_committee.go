@@ -14,9 +14,11 @@ import (
 
 // SyncCommitteeDuties returns sync committee duties for a given epoch
 func (gc *goClient) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
-	resp, err := gc.client.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	resp, err := failover(gc, func(client Client) (*api.Response[[]*eth2apiv1.SyncCommitteeDuty], error) {
+		return client.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain sync committee duties: %w", err)
@@ -31,8 +33,10 @@ func (gc *goClient) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch,
 // GetSyncMessageBlockRoot returns beacon block root for sync committee
 func (gc *goClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
 	reqStart := time.Now()
-	resp, err := gc.client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
-		Block: "head",
+	resp, err := race(gc, gc.ctx, func(ctx context.Context, client Client) (*api.Response[*phase0.Root], error) {
+		return client.BeaconBlockRoot(ctx, &api.BeaconBlockRootOpts{
+			Block: "head",
+		})
 	})
 	if err != nil {
 		return phase0.Root{}, DataVersionNil, fmt.Errorf("failed to obtain beacon block root: %w", err)
@@ -50,7 +54,9 @@ func (gc *goClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec
 
 // SubmitSyncMessage submits a signed sync committee msg
 func (gc *goClient) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
-	if err := gc.client.SubmitSyncCommitteeMessages(gc.ctx, []*altair.SyncCommitteeMessage{msg}); err != nil {
+	if err := gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+		return client.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{msg})
+	}); err != nil {
 		return err
 	}
 	return nil
@@ -1,6 +1,7 @@
 package goclient
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
@@ -43,8 +44,10 @@ func (gc *goClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 	gc.waitForOneThirdSlotDuration(slot)
 
 	scDataReqStart := time.Now()
-	beaconBlockRootResp, err := gc.client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
-		Block: fmt.Sprint(slot),
+	beaconBlockRootResp, err := race(gc, gc.ctx, func(ctx context.Context, client Client) (*api.Response[*phase0.Root], error) {
+		return client.BeaconBlockRoot(ctx, &api.BeaconBlockRootOpts{
+			Block: fmt.Sprint(slot),
+		})
 	})
 	if err != nil {
 		return nil, DataVersionNil, fmt.Errorf("failed to obtain beacon block root: %w", err)
@@ -70,10 +73,12 @@ func (gc *goClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 	for i := range subnetIDs {
 		index := i
 		g.Go(func() error {
-			syncCommitteeContrResp, err := gc.client.SyncCommitteeContribution(gc.ctx, &api.SyncCommitteeContributionOpts{
-				Slot:              slot,
-				SubcommitteeIndex: subnetIDs[index],
-				BeaconBlockRoot:   *blockRoot,
+			syncCommitteeContrResp, err := race(gc, gc.ctx, func(ctx context.Context, client Client) (*api.Response[*altair.SyncCommitteeContribution], error) {
+				return client.SyncCommitteeContribution(ctx, &api.SyncCommitteeContributionOpts{
+					Slot:              slot,
+					SubcommitteeIndex: subnetIDs[index],
+					BeaconBlockRoot:   *blockRoot,
+				})
 			})
 			if err != nil {
 				return fmt.Errorf("failed to obtain sync committee contribution: %w", err)
@@ -103,7 +108,9 @@ func (gc *goClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 
 // SubmitSignedContributionAndProof broadcasts to the network
 func (gc *goClient) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
-	return gc.client.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{contribution})
+	return gc.broadcast(gc.ctx, func(ctx context.Context, client Client) error {
+		return client.SubmitSyncCommitteeContributions(ctx, []*altair.SignedContributionAndProof{contribution})
+	})
 }
 
 // waitForOneThirdSlotDuration waits until one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot)
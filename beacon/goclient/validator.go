@@ -10,10 +10,12 @@ import (
 
 // GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 func (gc *goClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
-	resp, err := gc.client.Validators(gc.ctx, &api.ValidatorsOpts{
-		State:   "head", // TODO maybe need to get the chainId (head) as var
-		PubKeys: validatorPubKeys,
-		Common:  api.CommonOpts{Timeout: gc.longTimeout},
+	resp, err := failover(gc, func(client Client) (*api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator], error) {
+		return client.Validators(gc.ctx, &api.ValidatorsOpts{
+			State:   "head", // TODO maybe need to get the chainId (head) as var
+			PubKeys: validatorPubKeys,
+			Common:  api.CommonOpts{Timeout: gc.longTimeout},
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain validators: %w", err)
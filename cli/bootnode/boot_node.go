@@ -54,7 +54,12 @@ var StartBootNodeCmd = &cobra.Command{
 
 		logger.Info(fmt.Sprintf("starting %v", commons.GetBuildData()))
 
-		networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.Options.Network)
+		var networkConfig networkconfig.NetworkConfig
+		if cfg.Options.NetworkConfigFile != "" {
+			networkConfig, err = networkconfig.LoadNetworkConfig(cfg.Options.NetworkConfigFile)
+		} else {
+			networkConfig, err = networkconfig.GetNetworkConfigByName(cfg.Options.Network)
+		}
 		if err != nil {
 			logger.Fatal("failed to get network config", zap.Error(err))
 		}
@@ -0,0 +1,198 @@
+package operator
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+var (
+	dbConvertSource       string
+	dbConvertSourceEngine string
+	dbConvertTarget       string
+	dbConvertTargetEngine string
+	dbBackupOutput        string
+	dbRestoreInput        string
+)
+
+// DBCmd is the command to maintain the node's database. The node must not be running.
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Maintains the node's database",
+}
+
+var convertDBCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Copies the database to a new directory with another storage engine",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		source := cfg.DBOptions
+		if dbConvertSource != "" {
+			source.Path = dbConvertSource
+		}
+		if dbConvertSourceEngine != "" {
+			source.Engine = dbConvertSourceEngine
+		}
+		target := basedb.Options{
+			Path:   dbConvertTarget,
+			Engine: dbConvertTargetEngine,
+		}
+		if source.Path == target.Path {
+			logger.Fatal("source and target must be different directories")
+		}
+		logger = logger.With(
+			zap.String("source", source.Path),
+			zap.String("source_engine", source.Engine),
+			zap.String("target", target.Path),
+			zap.String("target_engine", target.Engine))
+
+		// Opening a missing source would create an empty one and convert nothing.
+		if _, err := os.Stat(source.Path); err != nil {
+			logger.Fatal("could not find source db", zap.Error(err))
+		}
+
+		// Disable background work, as it's a one-off run.
+		source.Ctx, target.Ctx = cmd.Context(), cmd.Context()
+		source.GCInterval, source.Reporting = 0, false
+
+		srcDB, err := kv.Open(logger, source)
+		if err != nil {
+			logger.Fatal("could not open source db", zap.Error(err))
+		}
+		defer srcDB.Close()
+
+		dstDB, err := kv.Open(logger, target)
+		if err != nil {
+			logger.Fatal("could not open target db", zap.Error(err))
+		}
+		defer dstDB.Close()
+
+		logger.Info("converting db")
+		count, err := kv.Convert(srcDB, dstDB)
+		if err != nil {
+			logger.Fatal("could not convert db", zap.Error(err))
+		}
+		logger.Info("converted db", zap.Int("items", count))
+	},
+}
+
+var backupDBCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backs up the database to a file (use the /v1/admin/backup API while the node is running)",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		db := openMaintenanceDB(cmd, logger)
+		defer db.Close()
+
+		// nolint: gosec
+		f, err := os.OpenFile(dbBackupOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			logger.Fatal("could not create backup file", zap.Error(err))
+		}
+		header, count, err := operatorstorage.Backup(db, f)
+		if err == nil {
+			err = f.Close()
+		}
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(dbBackupOutput)
+			logger.Fatal("could not back up db", zap.Error(err))
+		}
+
+		logger.Info("backed up db",
+			zap.String("file", dbBackupOutput),
+			zap.String("network", header.Config.NetworkName),
+			zap.Int("items", count))
+	},
+}
+
+var restoreDBCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores a backup into the empty database, if it was made with the same network and operator key",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		operatorPrivKey, operatorPrivKeyText := setupOperatorKey(logger)
+		hash, legacyHash := operatorKeyHashes(logger, operatorPrivKey, operatorPrivKeyText)
+		currentConfig := &operatorstorage.ConfigLock{
+			NetworkName:      networkConfig.Name,
+			UsingLocalEvents: len(cfg.LocalEventsPath) != 0,
+		}
+
+		// nolint: gosec
+		f, err := os.Open(dbRestoreInput)
+		if err != nil {
+			logger.Fatal("could not open backup file", zap.Error(err))
+		}
+		defer f.Close()
+
+		db := openMaintenanceDB(cmd, logger)
+		defer db.Close()
+
+		header, count, err := operatorstorage.Restore(db, f, currentConfig, hash, legacyHash)
+		if err != nil {
+			logger.Fatal("could not restore db, if anything was restored then the db must be removed before retrying",
+				zap.String("path", cfg.DBOptions.Path),
+				zap.Error(err))
+		}
+
+		logger.Info("restored db",
+			zap.String("file", dbRestoreInput),
+			zap.Time("created_at", header.CreatedAt),
+			zap.Int("items", count))
+	},
+}
+
+// openMaintenanceDB opens the configured database without running migrations or background work.
+func openMaintenanceDB(cmd *cobra.Command, logger *zap.Logger) basedb.Database {
+	options := cfg.DBOptions
+	options.Ctx = cmd.Context()
+	options.GCInterval, options.Reporting = 0, false
+	db, err := kv.Open(logger, options)
+	if err != nil {
+		logger.Fatal("could not open db", zap.Error(err))
+	}
+	return db
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, DBCmd)
+
+	convertDBCmd.Flags().StringVar(&dbConvertSource, "source", "", "Path to the source db (defaults to db.Path of the config)")
+	convertDBCmd.Flags().StringVar(&dbConvertSourceEngine, "source-engine", "", "Storage engine of the source db (defaults to db.Engine of the config)")
+	convertDBCmd.Flags().StringVar(&dbConvertTarget, "target", "", "Path to the target db, which must be empty")
+	convertDBCmd.Flags().StringVar(&dbConvertTargetEngine, "target-engine", basedb.EnginePebble, "Storage engine of the target db")
+	_ = convertDBCmd.MarkFlagRequired("target")
+
+	backupDBCmd.Flags().StringVarP(&dbBackupOutput, "output", "o", "", "Path to write the backup to, which must not exist")
+	_ = backupDBCmd.MarkFlagRequired("output")
+
+	restoreDBCmd.Flags().StringVarP(&dbRestoreInput, "input", "i", "", "Path to the backup to restore")
+	_ = restoreDBCmd.MarkFlagRequired("input")
+
+	DBCmd.AddCommand(convertDBCmd)
+	DBCmd.AddCommand(backupDBCmd)
+	DBCmd.AddCommand(restoreDBCmd)
+}
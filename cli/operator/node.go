@@ -16,6 +16,7 @@ import (
 
 	"github.com/bloxapp/ssv/network"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ilyakaznacheev/cleanenv"
@@ -53,6 +54,7 @@ import (
 	"github.com/bloxapp/ssv/operator/slotticker"
 	operatorstorage "github.com/bloxapp/ssv/operator/storage"
 	"github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/operator/validatorevents"
 	"github.com/bloxapp/ssv/operator/validatorsmap"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/types"
@@ -69,6 +71,11 @@ type KeyStore struct {
 	PasswordFile   string `yaml:"PasswordFile" env:"PASSWORD_FILE" env-description:"Password for operator private key file decryption"`
 }
 
+type RemoteSigner struct {
+	URL     string        `yaml:"URL" env:"REMOTE_SIGNER_URL" env-description:"URL of a Web3Signer-compatible remote signer holding the share keys, which also supports SSV_MESSAGE sign requests. Share keys are kept in the local database if empty."`
+	Timeout time.Duration `yaml:"Timeout" env:"REMOTE_SIGNER_TIMEOUT" env-default:"5s" env-description:"Timeout of requests to the remote signer"`
+}
+
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
 	DBOptions                  basedb.Options                   `yaml:"db"`
@@ -77,6 +84,7 @@ type config struct {
 	ConsensusClient            beaconprotocol.Options           `yaml:"eth2"` // TODO: consensus_client in yaml
 	P2pNetworkConfig           p2pv1.Config                     `yaml:"p2p"`
 	KeyStore                   KeyStore                         `yaml:"KeyStore"`
+	RemoteSigner               RemoteSigner                     `yaml:"RemoteSigner"`
 	OperatorPrivateKey         string                           `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	Graffiti                   string                           `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti for block proposals." env-default:"SSV.Network" `
 	MetricsAPIPort             int                              `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port to listen on for the metrics API."`
@@ -85,9 +93,21 @@ type config struct {
 	WsAPIPort                  int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
 	WithPing                   bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
 	SSVAPIPort                 int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
-	LocalEventsPath            string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+	SSVAPITLSCertFile          string                           `yaml:"SSVAPITLSCertFile" env:"SSV_API_TLS_CERT_FILE" env-description:"TLS certificate file for the SSV API, reloaded on SIGHUP. Serves plain HTTP if empty."`
+	SSVAPITLSKeyFile           string                           `yaml:"SSVAPITLSKeyFile" env:"SSV_API_TLS_KEY_FILE" env-description:"TLS private key file for the SSV API."`
+	SSVAPITLSClientCAFile      string                           `yaml:"SSVAPITLSClientCAFile" env:"SSV_API_TLS_CLIENT_CA_FILE" env-description:"CA file for verifying SSV API client certificates (mTLS). Verified clients are granted the read scope."`
+	SSVAPIAdminClients         []string                         `yaml:"SSVAPIAdminClients" env:"SSV_API_ADMIN_CLIENTS" env-description:"Common names of mTLS client certificates granted the admin scope."`
+	SSVAPIReadTokens           []string                         `yaml:"SSVAPIReadTokens" env:"SSV_API_READ_TOKENS" env-description:"Bearer tokens granted the read scope of the SSV API."`
+	SSVAPIAdminTokens          []string                         `yaml:"SSVAPIAdminTokens" env:"SSV_API_ADMIN_TOKENS" env-description:"Bearer tokens granted the admin scope of the SSV API."`
+	SlashingProtectionHistory  uint64                           `yaml:"SlashingProtectionHistory" env:"SLASHING_PROTECTION_HISTORY" env-default:"4096" env-description:"Number of epochs of signed attestations and proposals to keep in the slashing protection history. 0 keeps the entire history."`
+	LocalEventsPath            string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events (YAML, or JSON by its .json extension)"`
+	RegistrySnapshotPath       string                           `yaml:"RegistrySnapshotPath" env:"REGISTRY_SNAPSHOT_PATH" env-description:"Path to a registry snapshot to import instead of syncing the events up to its block. Only used when the registry isn't synced yet."`
+	RegistrySnapshotSigners    []string                         `yaml:"RegistrySnapshotSigners" env:"REGISTRY_SNAPSHOT_SIGNERS" env-description:"Base64 encoded operator public keys trusted to sign registry snapshots."`
 }
 
+// failedTaskRetryInterval is how often failed tasks of registry events are checked for a due retry.
+const failedTaskRetryInterval = 10 * time.Second
+
 var cfg config
 
 var globalArgs global_config.Args
@@ -118,44 +138,17 @@ var StartNodeCmd = &cobra.Command{
 		if err != nil {
 			logger.Fatal("could not setup network", zap.Error(err))
 		}
+		networkConfig.Beacon, err = setupBeaconSpec(cmd.Context(), logger, networkConfig.Beacon.GetNetwork())
+		if err != nil {
+			logger.Fatal("could not setup beacon spec", zap.Error(err))
+		}
 		cfg.DBOptions.Ctx = cmd.Context()
 		db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
 		if err != nil {
 			logger.Fatal("could not setup db", zap.Error(err))
 		}
 
-		var operatorPrivKey keys.OperatorPrivateKey
-		var operatorPrivKeyText string
-		if cfg.KeyStore.PrivateKeyFile != "" {
-			// nolint: gosec
-			encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
-			if err != nil {
-				logger.Fatal("could not read PEM file", zap.Error(err))
-			}
-
-			// nolint: gosec
-			keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
-			if err != nil {
-				logger.Fatal("could not read password file", zap.Error(err))
-			}
-
-			decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
-			if err != nil {
-				logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
-			}
-			operatorPrivKey, err = keys.PrivateKeyFromBytes(decryptedKeystore)
-			if err != nil {
-				logger.Fatal("could not extract operator private key from file", zap.Error(err))
-			}
-
-			operatorPrivKeyText = base64.StdEncoding.EncodeToString(decryptedKeystore)
-		} else {
-			operatorPrivKey, err = keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
-			if err != nil {
-				logger.Fatal("could not decode operator private key", zap.Error(err))
-			}
-			operatorPrivKeyText = cfg.OperatorPrivateKey
-		}
+		operatorPrivKey, operatorPrivKeyText := setupOperatorKey(logger)
 		cfg.P2pNetworkConfig.OperatorSigner = operatorPrivKey
 
 		nodeStorage, operatorData := setupOperatorStorage(logger, db, operatorPrivKey, operatorPrivKeyText)
@@ -170,11 +163,6 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("could not get operator private key hash", zap.Error(err))
 		}
 
-		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey)
-		if err != nil {
-			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
-		}
-
 		cfg.P2pNetworkConfig.Ctx = cmd.Context()
 
 		permissioned := func() bool {
@@ -196,16 +184,32 @@ var StartNodeCmd = &cobra.Command{
 
 		consensusClient := setupConsensusClient(logger, operatorDataStore, slotTickerProvider)
 
-		executionClient, err := executionclient.New(
-			cmd.Context(),
-			cfg.ExecutionClient.Addr,
-			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+		keyManager := setupKeyManager(logger, db, networkConfig, ekmHashedKey, consensusClient)
+
+		executionClientOptions := []executionclient.Option{
 			executionclient.WithLogger(logger),
 			executionclient.WithMetrics(metricsReporter),
-			executionclient.WithFollowDistance(executionclient.DefaultFollowDistance),
+			executionclient.WithFollowDistance(cfg.ExecutionClient.FollowDistance),
+			executionclient.WithQuorum(cfg.ExecutionClient.Quorum),
+			executionclient.WithLogBatchSize(cfg.ExecutionClient.LogBatchSize),
+			executionclient.WithLogFetchWorkers(cfg.ExecutionClient.LogFetchWorkers),
 			executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
 			executionclient.WithReconnectionInitialInterval(executionclient.DefaultReconnectionInitialInterval),
 			executionclient.WithReconnectionMaxInterval(executionclient.DefaultReconnectionMaxInterval),
+		}
+		if cfg.ExecutionClient.SyncFinalized {
+			finalizedBlockProvider, ok := consensusClient.(executionclient.FinalizedBlockProvider)
+			if !ok {
+				logger.Fatal("consensus client doesn't provide the finalized block for syncing up to it")
+			}
+			executionClientOptions = append(executionClientOptions,
+				executionclient.WithFinalizedBlockProvider(finalizedBlockProvider))
+		}
+		executionClient, err := executionclient.New(
+			cmd.Context(),
+			cfg.ExecutionClient.Addr,
+			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			executionClientOptions...,
 		)
 		if err != nil {
 			logger.Fatal("could not connect to execution client", zap.Error(err))
@@ -219,6 +223,7 @@ var StartNodeCmd = &cobra.Command{
 		cfg.P2pNetworkConfig.Network = networkConfig
 
 		validatorsMap := validatorsmap.New(cmd.Context())
+		validatorEvents := validatorevents.New()
 
 		dutyStore := dutystore.New()
 		cfg.SSVOptions.DutyStore = dutyStore
@@ -251,6 +256,7 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.Beacon = consensusClient
 		cfg.SSVOptions.ValidatorOptions.KeyManager = keyManager
 		cfg.SSVOptions.ValidatorOptions.ValidatorsMap = validatorsMap
+		cfg.SSVOptions.ValidatorOptions.ValidatorEvents = validatorEvents
 
 		cfg.SSVOptions.ValidatorOptions.OperatorDataStore = operatorDataStore
 		cfg.SSVOptions.ValidatorOptions.RegistryStorage = nodeStorage
@@ -316,7 +322,7 @@ var StartNodeCmd = &cobra.Command{
 
 		metricsReporter.SSVNodeHealthy()
 
-		eventSyncer := setupEventHandling(
+		eventSyncer, eventHandler := setupEventHandling(
 			cmd.Context(),
 			logger,
 			executionClient,
@@ -351,10 +357,51 @@ var StartNodeCmd = &cobra.Command{
 					Network:         p2pNetwork.(p2pv1.HostProvider).Host().Network(),
 					TopicIndex:      p2pNetwork.(handlers.TopicIndex),
 					NodeProber:      nodeProber,
+					EventHandler:    eventHandler,
 				},
 				&handlers.Validators{
-					Shares: nodeStorage.Shares(),
+					Shares:  nodeStorage.Shares(),
+					History: nodeStorage,
+				},
+				&handlers.Clusters{
+					Shares:     nodeStorage.Shares(),
+					Recipients: nodeStorage,
+					History:    nodeStorage,
+				},
+				&handlers.Duties{
+					Network:           networkConfig,
+					DutyStore:         dutyStore,
+					Storage:           storageMap,
+					Shares:            nodeStorage.Shares(),
+					OperatorDataStore: operatorDataStore,
+				},
+				&handlers.Performance{
+					Network:           networkConfig,
+					Storage:           storageMap,
+					Shares:            nodeStorage.Shares(),
+					OperatorDataStore: operatorDataStore,
+				},
+				&handlers.Events{
+					Feed: validatorEvents,
+				},
+				&handlers.Logging{
+					Levels: logging.Levels(),
+				},
+				&handlers.SlashingProtection{
+					Network:           networkConfig,
+					KeyManager:        keyManager.(ekm.StorageProvider),
+					Shares:            nodeStorage.Shares(),
+					OperatorDataStore: operatorDataStore,
 				},
+				&handlers.Backup{
+					DB: db,
+				},
+				&handlers.FailedTasks{
+					Queue: eventHandler,
+				},
+				apiserver.WithTLS(cfg.SSVAPITLSCertFile, cfg.SSVAPITLSKeyFile, cfg.SSVAPITLSClientCAFile),
+				apiserver.WithTokens(cfg.SSVAPIReadTokens, cfg.SSVAPIAdminTokens),
+				apiserver.WithAdminClients(cfg.SSVAPIAdminClients),
 			)
 			go func() {
 				err := apiServer.Run()
@@ -427,8 +474,8 @@ func setupGlobal() (*zap.Logger, error) {
 	return zap.L(), nil
 }
 
-func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.BadgerDB, error) {
-	db, err := kv.New(logger, cfg.DBOptions)
+func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (basedb.Database, error) {
+	db, err := kv.Open(logger, cfg.DBOptions)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open db")
 	}
@@ -436,7 +483,7 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 		if err := db.Close(); err != nil {
 			return errors.Wrap(err, "failed to close db")
 		}
-		db, err = kv.New(logger, cfg.DBOptions)
+		db, err = kv.Open(logger, cfg.DBOptions)
 		return errors.Wrap(err, "failed to reopen db")
 	}
 
@@ -457,6 +504,9 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	// to reclaim any space that may have been freed up.
 	// Close & reopen the database to trigger any unknown internal
 	// startup/shutdown procedures that the storage engine may have.
+	if _, ok := db.(basedb.GarbageCollector); !ok {
+		return db, nil
+	}
 	start := time.Now()
 	if err := reopenDb(); err != nil {
 		return nil, err
@@ -465,7 +515,7 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	// Run a long garbage collection cycle with a timeout.
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Minute)
 	defer cancel()
-	if err := db.FullGC(ctx); err != nil {
+	if err := db.(basedb.GarbageCollector).FullGC(ctx); err != nil {
 		return nil, errors.Wrap(err, "failed to collect garbage")
 	}
 
@@ -478,6 +528,45 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	return db, nil
 }
 
+// setupOperatorKey loads the operator private key from the keystore file or the config,
+// and also returns it as base64 text.
+func setupOperatorKey(logger *zap.Logger) (keys.OperatorPrivateKey, string) {
+	var operatorPrivKey keys.OperatorPrivateKey
+	var operatorPrivKeyText string
+	var err error
+	if cfg.KeyStore.PrivateKeyFile != "" {
+		// nolint: gosec
+		encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
+		if err != nil {
+			logger.Fatal("could not read PEM file", zap.Error(err))
+		}
+
+		// nolint: gosec
+		keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
+		if err != nil {
+			logger.Fatal("could not read password file", zap.Error(err))
+		}
+
+		decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
+		if err != nil {
+			logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
+		}
+		operatorPrivKey, err = keys.PrivateKeyFromBytes(decryptedKeystore)
+		if err != nil {
+			logger.Fatal("could not extract operator private key from file", zap.Error(err))
+		}
+
+		operatorPrivKeyText = base64.StdEncoding.EncodeToString(decryptedKeystore)
+	} else {
+		operatorPrivKey, err = keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
+		if err != nil {
+			logger.Fatal("could not decode operator private key", zap.Error(err))
+		}
+		operatorPrivKeyText = cfg.OperatorPrivateKey
+	}
+	return operatorPrivKey, operatorPrivKeyText
+}
+
 func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey keys.OperatorPrivateKey, configPrivKeyText string) (operatorstorage.Storage, *registrystorage.OperatorData) {
 	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
 	if err != nil {
@@ -489,22 +578,7 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 		logger.Fatal("could not get hashed private key", zap.Error(err))
 	}
 
-	configStoragePrivKeyHash, err := configPrivKey.StorageHash()
-	if err != nil {
-		logger.Fatal("could not hash private key", zap.Error(err))
-	}
-
-	// Backwards compatibility for the old hashing method,
-	// which was hashing the text from the configuration directly,
-	// whereas StorageHash re-encodes with PEM format.
-	cliPrivKeyDecoded, err := base64.StdEncoding.DecodeString(configPrivKeyText)
-	if err != nil {
-		logger.Fatal("could not decode private key", zap.Error(err))
-	}
-	configStoragePrivKeyLegacyHash, err := rsaencryption.HashRsaKey(cliPrivKeyDecoded)
-	if err != nil {
-		logger.Fatal("could not hash private key", zap.Error(err))
-	}
+	configStoragePrivKeyHash, configStoragePrivKeyLegacyHash := operatorKeyHashes(logger, configPrivKey, configPrivKeyText)
 
 	if !found {
 		if err := nodeStorage.SavePrivateKeyHash(configStoragePrivKeyHash); err != nil {
@@ -538,8 +612,36 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 	return nodeStorage, operatorData
 }
 
+// operatorKeyHashes returns the hashes of the operator private key which may be stored in the database.
+func operatorKeyHashes(logger *zap.Logger, configPrivKey keys.OperatorPrivateKey, configPrivKeyText string) (hash string, legacyHash string) {
+	hash, err := configPrivKey.StorageHash()
+	if err != nil {
+		logger.Fatal("could not hash private key", zap.Error(err))
+	}
+
+	// Backwards compatibility for the old hashing method,
+	// which was hashing the text from the configuration directly,
+	// whereas StorageHash re-encodes with PEM format.
+	cliPrivKeyDecoded, err := base64.StdEncoding.DecodeString(configPrivKeyText)
+	if err != nil {
+		logger.Fatal("could not decode private key", zap.Error(err))
+	}
+	legacyHash, err = rsaencryption.HashRsaKey(cliPrivKeyDecoded)
+	if err != nil {
+		logger.Fatal("could not hash private key", zap.Error(err))
+	}
+
+	return hash, legacyHash
+}
+
 func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
-	networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+	var networkConfig networkconfig.NetworkConfig
+	var err error
+	if cfg.SSVOptions.NetworkConfigFile != "" {
+		networkConfig, err = networkconfig.LoadNetworkConfig(cfg.SSVOptions.NetworkConfigFile)
+	} else {
+		networkConfig, err = networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+	}
 	if err != nil {
 		return networkconfig.NetworkConfig{}, err
 	}
@@ -579,6 +681,28 @@ func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
 	return networkConfig, nil
 }
 
+// setupBeaconSpec takes the beacon chain spec values which the network doesn't configure from the consensus client,
+// and fails if the consensus client's spec conflicts with the configured values.
+func setupBeaconSpec(ctx context.Context, logger *zap.Logger, network beaconprotocol.Network) (beaconprotocol.Network, error) {
+	nodeSpec, err := goclient.FetchSpec(ctx, logger, cfg.ConsensusClient)
+	if err != nil {
+		return beaconprotocol.Network{}, fmt.Errorf("failed to fetch beacon spec: %w", err)
+	}
+	spec, err := network.Spec().Merge(nodeSpec)
+	if err != nil {
+		return beaconprotocol.Network{}, fmt.Errorf("consensus client's spec conflicts with the network config: %w", err)
+	}
+	network = network.WithSpec(spec)
+
+	logger.Info("using beacon spec",
+		zap.Duration("slotDuration", network.SlotDurationSec()),
+		zap.Uint64("slotsPerEpoch", network.SlotsPerEpoch()),
+		zap.Uint64("epochsPerSyncCommitteePeriod", network.EpochsPerSyncCommitteePeriod()),
+		zap.Int("forks", len(spec.Forks)),
+	)
+	return network, nil
+}
+
 func setupP2P(logger *zap.Logger, db basedb.Database, mr metricsreporter.MetricsReporter) network.P2PNetwork {
 	istore := ssv_identity.NewIdentityStore(db)
 	netPrivKey, err := istore.SetupNetworkKey(logger, cfg.NetworkPrivateKey)
@@ -594,6 +718,38 @@ func setupP2P(logger *zap.Logger, db basedb.Database, mr metricsreporter.Metrics
 	return p2pNetwork
 }
 
+func setupKeyManager(
+	logger *zap.Logger,
+	db basedb.Database,
+	networkConfig networkconfig.NetworkConfig,
+	ekmHashedKey string,
+	consensusClient beaconprotocol.BeaconNode,
+) spectypes.KeyManager {
+	historyRetention := ekm.WithHistoryRetention(phase0.Epoch(cfg.SlashingProtectionHistory))
+
+	if cfg.RemoteSigner.URL != "" {
+		forkInfo, ok := consensusClient.(ekm.ForkInfoProvider)
+		if !ok {
+			logger.Fatal("consensus client doesn't provide fork info for the remote signer")
+		}
+		keyManager, err := ekm.NewRemoteKeyManager(logger, db, networkConfig, cfg.RemoteSigner.URL, forkInfo,
+			historyRetention,
+			ekm.WithRemoteSignerTimeout(cfg.RemoteSigner.Timeout),
+		)
+		if err != nil {
+			logger.Fatal("could not create remote signer", zap.Error(err))
+		}
+		logger.Info("using remote signer", zap.String("url", cfg.RemoteSigner.URL))
+		return keyManager
+	}
+
+	keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey, historyRetention)
+	if err != nil {
+		logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+	}
+	return keyManager
+}
+
 func setupConsensusClient(
 	logger *zap.Logger,
 	operatorDataStore operatordatastore.OperatorDataStore,
@@ -619,7 +775,7 @@ func setupEventHandling(
 	nodeStorage operatorstorage.Storage,
 	operatorDataStore operatordatastore.OperatorDataStore,
 	operatorDecrypter keys.OperatorDecrypter,
-) *eventsyncer.EventSyncer {
+) (*eventsyncer.EventSyncer, *eventhandler.EventHandler) {
 	eventFilterer, err := executionClient.Filterer()
 	if err != nil {
 		logger.Fatal("failed to set up event filterer", zap.Error(err))
@@ -627,6 +783,17 @@ func setupEventHandling(
 
 	eventParser := eventparser.New(eventFilterer)
 
+	eventHandlerOptions := []eventhandler.Option{
+		eventhandler.WithFullNode(),
+		eventhandler.WithLogger(logger),
+		eventhandler.WithMetrics(metricsReporter),
+	}
+	if cfg.ExecutionClient.ReorgDepth > 0 {
+		eventHandlerOptions = append(eventHandlerOptions, eventhandler.WithReorgProtection(executionClient, cfg.ExecutionClient.ReorgDepth))
+	}
+	if cfg.ExecutionClient.HaltOnUnknownEvents {
+		eventHandlerOptions = append(eventHandlerOptions, eventhandler.WithHaltOnUnknownEvents())
+	}
 	eventHandler, err := eventhandler.New(
 		nodeStorage,
 		eventParser,
@@ -637,9 +804,7 @@ func setupEventHandling(
 		cfg.SSVOptions.ValidatorOptions.KeyManager,
 		cfg.SSVOptions.ValidatorOptions.Beacon,
 		storageMap,
-		eventhandler.WithFullNode(),
-		eventhandler.WithLogger(logger),
-		eventhandler.WithMetrics(metricsReporter),
+		eventHandlerOptions...,
 	)
 	if err != nil {
 		logger.Fatal("failed to setup event data handler", zap.Error(err))
@@ -677,6 +842,14 @@ func setupEventHandling(
 			logger.Fatal("error occurred while running event data handler", zap.Error(err))
 		}
 	} else {
+		if !found && cfg.RegistrySnapshotPath != "" {
+			snapshotBlock, err := importRegistrySnapshot(ctx, logger, executionClient, eventHandler, networkConfig, nodeStorage, operatorDataStore)
+			if err != nil {
+				logger.Fatal("failed to import registry snapshot", zap.Error(err))
+			}
+			fromBlock = new(big.Int).SetUint64(snapshotBlock + 1)
+		}
+
 		// Sync historical registry events.
 		logger.Debug("syncing historical registry events", zap.Uint64("fromBlock", fromBlock.Uint64()))
 		lastProcessedBlock, err := eventSyncer.SyncHistory(ctx, fromBlock.Uint64())
@@ -729,7 +902,10 @@ func setupEventHandling(
 		}()
 	}
 
-	return eventSyncer
+	// Retry the tasks of registry events which failed to execute.
+	go eventHandler.RetryFailedTasks(ctx, failedTaskRetryInterval)
+
+	return eventSyncer, eventHandler
 }
 
 func startMetricsHandler(ctx context.Context, logger *zap.Logger, db basedb.Database, metricsReporter metricsreporter.MetricsReporter, port int, enableProf bool) {
@@ -0,0 +1,269 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/eth/eventhandler"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	"github.com/bloxapp/ssv/networkconfig"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+var registrySnapshotOutput string
+
+// RegistryCmd is the command to work with the node's registry of operators and validators.
+var RegistryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Works with the registry of operators and validators",
+}
+
+var exportSnapshotCmd = &cobra.Command{
+	Use:   "export-snapshot",
+	Short: "Exports a registry snapshot signed by the operator key, which other nodes can import instead of syncing (the node must be stopped)",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		operatorPrivKey, _ := setupOperatorKey(logger)
+
+		db := openMaintenanceDB(cmd, logger)
+		defer db.Close()
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		if err != nil {
+			logger.Fatal("could not open node storage", zap.Error(err))
+		}
+		config, found, err := nodeStorage.GetConfig(nil)
+		if err != nil {
+			logger.Fatal("could not get config", zap.Error(err))
+		}
+		if !found || config.NetworkName != networkConfig.Name || config.UsingLocalEvents {
+			logger.Fatal("database wasn't synced from the configured network's contract")
+		}
+
+		snapshot, err := nodeStorage.ExportRegistrySnapshot(networkConfig.Name)
+		if err != nil {
+			logger.Fatal("could not export registry snapshot", zap.Error(err))
+		}
+
+		executionClient, err := executionclient.New(
+			cmd.Context(),
+			cfg.ExecutionClient.Addr,
+			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			executionclient.WithLogger(logger),
+			executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
+		)
+		if err != nil {
+			logger.Fatal("could not connect to execution client", zap.Error(err))
+		}
+		defer executionClient.Close()
+
+		header, err := executionClient.HeaderByNumber(cmd.Context(), new(big.Int).SetUint64(snapshot.BlockNumber))
+		if err != nil {
+			logger.Fatal("could not get header of snapshot block", zap.Error(err))
+		}
+		snapshot.BlockHash = header.Hash()
+
+		signed, err := operatorstorage.SignRegistrySnapshot(snapshot, operatorPrivKey)
+		if err != nil {
+			logger.Fatal("could not sign registry snapshot", zap.Error(err))
+		}
+
+		// nolint: gosec
+		f, err := os.OpenFile(registrySnapshotOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			logger.Fatal("could not create snapshot file", zap.Error(err))
+		}
+		err = json.NewEncoder(f).Encode(signed)
+		if err == nil {
+			err = f.Close()
+		}
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(registrySnapshotOutput)
+			logger.Fatal("could not write snapshot file", zap.Error(err))
+		}
+
+		logger.Info("exported registry snapshot",
+			zap.String("file", registrySnapshotOutput),
+			zap.Uint64("block", snapshot.BlockNumber),
+			zap.String("block_hash", snapshot.BlockHash.Hex()),
+			zap.String("signer", signed.Signer),
+			zap.Int("operators", len(snapshot.Operators)),
+			zap.Int("shares", len(snapshot.Shares)),
+			zap.Int("recipients", len(snapshot.Recipients)))
+	},
+}
+
+// importRegistrySnapshot imports the configured registry snapshot, after verifying its signer, network
+// and that its block is canonical. The keys of the operator's shares are then recovered from their events.
+// It returns the snapshot's block.
+func importRegistrySnapshot(
+	ctx context.Context,
+	logger *zap.Logger,
+	executionClient *executionclient.ExecutionClient,
+	eventHandler *eventhandler.EventHandler,
+	networkConfig networkconfig.NetworkConfig,
+	nodeStorage operatorstorage.Storage,
+	operatorDataStore operatordatastore.OperatorDataStore,
+) (uint64, error) {
+	data, err := os.ReadFile(cfg.RegistrySnapshotPath)
+	if err != nil {
+		return 0, fmt.Errorf("could not read snapshot: %w", err)
+	}
+	var signed operatorstorage.SignedRegistrySnapshot
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return 0, fmt.Errorf("could not decode snapshot: %w", err)
+	}
+	snapshot, err := signed.Verify(cfg.RegistrySnapshotSigners)
+	if err != nil {
+		return 0, err
+	}
+	if snapshot.Network != networkConfig.Name {
+		return 0, fmt.Errorf("snapshot is of network %s", snapshot.Network)
+	}
+	header, err := executionClient.HeaderByNumber(ctx, new(big.Int).SetUint64(snapshot.BlockNumber))
+	if err != nil {
+		return 0, fmt.Errorf("could not get header of snapshot block: %w", err)
+	}
+	if header.Hash() != snapshot.BlockHash {
+		return 0, fmt.Errorf("snapshot block %d isn't canonical", snapshot.BlockNumber)
+	}
+
+	if err := nodeStorage.ImportRegistrySnapshot(snapshot, operatorDataStore.GetOperatorData().PublicKey); err != nil {
+		return 0, fmt.Errorf("could not import snapshot: %w", err)
+	}
+	logger = logger.With(zap.Uint64("block", snapshot.BlockNumber))
+	logger.Info("imported registry snapshot",
+		zap.String("signer", signed.Signer),
+		zap.Int("operators", len(snapshot.Operators)),
+		zap.Int("shares", len(snapshot.Shares)))
+
+	// The operator's ID is only known if it was registered by the snapshot's block.
+	operatorData, found, err := nodeStorage.GetOperatorDataByPubKey(nil, operatorDataStore.GetOperatorData().PublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("could not get operator data: %w", err)
+	}
+	if !found {
+		return snapshot.BlockNumber, nil
+	}
+	operatorDataStore.SetOperatorData(operatorData)
+
+	ownShares := nodeStorage.Shares().List(nil, registrystorage.ByOperatorID(operatorData.ID))
+	if len(ownShares) == 0 {
+		return snapshot.BlockNumber, nil
+	}
+	owners := make(map[ethcommon.Address]bool)
+	var ownerList []ethcommon.Address
+	for _, share := range ownShares {
+		if !owners[share.OwnerAddress] {
+			owners[share.OwnerAddress] = true
+			ownerList = append(ownerList, share.OwnerAddress)
+		}
+	}
+	topics, err := eventhandler.ValidatorAddedTopics(ownerList)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.Info("recovering share keys from their events", zap.Int("shares", len(ownShares)))
+	logs, fetchErrors := executionClient.FetchFilteredLogs(ctx, networkConfig.RegistrySyncOffset.Uint64(), snapshot.BlockNumber, topics)
+	recovered, err := eventHandler.RecoverShareKeys(logs)
+	if err != nil {
+		return 0, err
+	}
+	if err := <-fetchErrors; err != nil {
+		return 0, fmt.Errorf("could not fetch validator events: %w", err)
+	}
+	if recovered != len(ownShares) {
+		return 0, fmt.Errorf("recovered the keys of %d out of %d shares", recovered, len(ownShares))
+	}
+	logger.Info("recovered share keys", zap.Int("shares", recovered))
+	return snapshot.BlockNumber, nil
+}
+
+// setupRegistryExecutionClient connects to the configured execution client to fetch registry events.
+func setupRegistryExecutionClient(ctx context.Context, logger *zap.Logger, networkConfig networkconfig.NetworkConfig) *executionclient.ExecutionClient {
+	executionClient, err := executionclient.New(
+		ctx,
+		cfg.ExecutionClient.Addr,
+		ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+		executionclient.WithLogger(logger),
+		executionclient.WithQuorum(cfg.ExecutionClient.Quorum),
+		executionclient.WithLogBatchSize(cfg.ExecutionClient.LogBatchSize),
+		executionclient.WithLogFetchWorkers(cfg.ExecutionClient.LogFetchWorkers),
+		executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
+	)
+	if err != nil {
+		logger.Fatal("could not connect to execution client", zap.Error(err))
+	}
+	return executionClient
+}
+
+// registryBlockRange returns the given block range, which defaults to the blocks from the network's registry
+// sync offset up to the latest block minus the follow distance.
+func registryBlockRange(
+	ctx context.Context,
+	logger *zap.Logger,
+	executionClient *executionclient.ExecutionClient,
+	networkConfig networkconfig.NetworkConfig,
+	fromBlock, toBlock uint64,
+) (uint64, uint64) {
+	if fromBlock == 0 {
+		fromBlock = networkConfig.RegistrySyncOffset.Uint64()
+	}
+	if toBlock == 0 {
+		header, err := executionClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			logger.Fatal("could not get latest block", zap.Error(err))
+		}
+		if header.Number.Uint64() < cfg.ExecutionClient.FollowDistance {
+			logger.Fatal("no blocks to process")
+		}
+		toBlock = header.Number.Uint64() - cfg.ExecutionClient.FollowDistance
+	}
+	if fromBlock > toBlock {
+		logger.Fatal("invalid block range", zap.Uint64("from_block", fromBlock), zap.Uint64("to_block", toBlock))
+	}
+	return fromBlock, toBlock
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, RegistryCmd)
+
+	exportSnapshotCmd.Flags().StringVarP(&registrySnapshotOutput, "output", "o", "", "Path to write the snapshot to, which must not exist")
+	_ = exportSnapshotCmd.MarkFlagRequired("output")
+
+	replayCmd.Flags().Uint64Var(&replayFromBlock, "from-block", 0, "First block to replay (default: the network's registry sync offset)")
+	replayCmd.Flags().Uint64Var(&replayToBlock, "to-block", 0, "Last block to replay (default: the latest block minus the follow distance)")
+	replayCmd.Flags().StringVar(&replayEventsPath, "events", "", "Path to a local events file (YAML, or JSON by its .json extension) to replay instead of the contract's events")
+	replayCmd.Flags().StringVarP(&replayOutput, "output", "o", "", "Path to write the replayed registry to, which must not exist")
+	_ = replayCmd.MarkFlagRequired("output")
+	replayCmd.MarkFlagsMutuallyExclusive("events", "from-block")
+	replayCmd.MarkFlagsMutuallyExclusive("events", "to-block")
+
+	exportEventsCmd.Flags().Uint64Var(&exportEventsFromBlock, "from-block", 0, "First block to export the events of (default: the network's registry sync offset)")
+	exportEventsCmd.Flags().Uint64Var(&exportEventsToBlock, "to-block", 0, "Last block to export the events of (default: the latest block minus the follow distance)")
+	exportEventsCmd.Flags().StringVarP(&exportEventsOutput, "output", "o", "", "Path to write the local events to, which must not exist. The format is JSON if it ends with .json, otherwise YAML")
+	_ = exportEventsCmd.MarkFlagRequired("output")
+
+	RegistryCmd.AddCommand(exportSnapshotCmd, replayCmd, exportEventsCmd)
+}
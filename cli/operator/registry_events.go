@@ -0,0 +1,123 @@
+package operator
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventparser"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	"github.com/bloxapp/ssv/eth/localevents"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+var (
+	exportEventsFromBlock uint64
+	exportEventsToBlock   uint64
+	exportEventsOutput    string
+)
+
+var exportEventsCmd = &cobra.Command{
+	Use:   "export-events",
+	Short: "Exports the registry contract's events of a block range as a local events file",
+	Long: "Exports the registry contract's events of a block range as a local events file, which a node can run with " +
+		"instead of syncing the contract. If the range starts at the network's registry sync offset, " +
+		"the owners' nonces are recorded with their ValidatorAdded events.",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		executionClient := setupRegistryExecutionClient(cmd.Context(), logger, networkConfig)
+		defer executionClient.Close()
+		fromBlock, toBlock := registryBlockRange(cmd.Context(), logger, executionClient, networkConfig, exportEventsFromBlock, exportEventsToBlock)
+
+		eventFilterer, err := executionClient.Filterer()
+		if err != nil {
+			logger.Fatal("could not create event filterer", zap.Error(err))
+		}
+		trackNonces := fromBlock == networkConfig.RegistrySyncOffset.Uint64()
+
+		logger.Info("exporting registry events", zap.Uint64("from_block", fromBlock), zap.Uint64("to_block", toBlock))
+		logs, fetchErrors := executionClient.FetchFilteredLogs(cmd.Context(), fromBlock, toBlock, nil)
+		events, skipped, err := localEventsFromLogs(eventparser.New(eventFilterer), logs, trackNonces)
+		if err != nil {
+			logger.Fatal("could not convert registry events", zap.Error(err))
+		}
+		if err := <-fetchErrors; err != nil {
+			logger.Fatal("could not fetch registry events", zap.Error(err))
+		}
+
+		data, err := localevents.Marshal(events, localevents.FormatOf(exportEventsOutput))
+		if err != nil {
+			logger.Fatal("could not encode local events", zap.Error(err))
+		}
+		// nolint: gosec
+		f, err := os.OpenFile(exportEventsOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			logger.Fatal("could not create output file", zap.Error(err))
+		}
+		_, err = f.Write(data)
+		if err == nil {
+			err = f.Close()
+		}
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(exportEventsOutput)
+			logger.Fatal("could not write local events", zap.Error(err))
+		}
+
+		logger.Info("exported registry events",
+			zap.String("file", exportEventsOutput),
+			zap.Uint64("from_block", fromBlock),
+			zap.Uint64("to_block", toBlock),
+			zap.Int("events", len(events)),
+			zap.Int("skipped", skipped))
+	},
+}
+
+// localEventsFromLogs converts the given registry contract logs into local events, skipping the logs of events
+// which the node doesn't handle. If trackNonces is set, the logs must start from the contract's first event,
+// so that the owners' nonces are counted and recorded with their ValidatorAdded events.
+func localEventsFromLogs(
+	parser eventparser.Parser,
+	logs <-chan executionclient.BlockLogs,
+	trackNonces bool,
+) (events []localevents.Event, skipped int, err error) {
+	nonces := make(map[ethcommon.Address]registrystorage.Nonce)
+	for block := range logs {
+		for _, blockLog := range block.Logs {
+			if err != nil {
+				// Drain the logs, so that fetching them is done.
+				continue
+			}
+			event, convertErr := localevents.FromLog(parser, blockLog)
+			if convertErr != nil {
+				err = fmt.Errorf("block %d: %w", block.BlockNumber, convertErr)
+				continue
+			}
+			if event == nil {
+				skipped++
+				continue
+			}
+			if validatorAdded, ok := event.Data.(contract.ContractValidatorAdded); ok && trackNonces {
+				nonce := nonces[validatorAdded.Owner]
+				event.Nonce = &nonce
+				nonces[validatorAdded.Owner] = nonce + 1
+			}
+			events = append(events, *event)
+		}
+	}
+	return events, skipped, err
+}
@@ -0,0 +1,267 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventhandler"
+	"github.com/bloxapp/ssv/eth/eventparser"
+	"github.com/bloxapp/ssv/eth/localevents"
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/networkconfig"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/operator/keys"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+var (
+	replayFromBlock  uint64
+	replayToBlock    uint64
+	replayEventsPath string
+	replayOutput     string
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replays registry events into a throwaway in-memory registry and writes it out, without starting validators",
+	Long: "Replays the registry events of a block range, or of a local events file, into a throwaway in-memory registry " +
+		"and writes the resulting operators, shares and fee recipients as JSON. The node's database isn't touched. " +
+		"If an operator key is configured, the shares of the operator are marked and their keys are decrypted as the node would.",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		// Without an operator key, a throwaway one is used, so none of the shares are the operator's.
+		var operatorPrivKey keys.OperatorPrivateKey
+		if cfg.KeyStore.PrivateKeyFile != "" || cfg.OperatorPrivateKey != "" {
+			operatorPrivKey, _ = setupOperatorKey(logger)
+		} else if operatorPrivKey, err = keys.GeneratePrivateKey(); err != nil {
+			logger.Fatal("could not generate operator key", zap.Error(err))
+		}
+
+		var replay *registryReplay
+		if replayEventsPath != "" {
+			events, err := localevents.Load(replayEventsPath)
+			if err != nil {
+				logger.Fatal("could not load local events", zap.Error(err))
+			}
+			eventFilterer, err := contract.NewContractFilterer(ethcommon.Address{}, nil)
+			if err != nil {
+				logger.Fatal("could not create event filterer", zap.Error(err))
+			}
+			replay, err = replayRegistryEvents(cmd.Context(), logger, networkConfig, operatorPrivKey, eventFilterer,
+				func(eventHandler *eventhandler.EventHandler) error {
+					return eventHandler.HandleLocalEvents(events)
+				})
+			if err != nil {
+				logger.Fatal("could not replay local events", zap.Error(err))
+			}
+			replay.EventsFile = replayEventsPath
+		} else {
+			replay = replayContractEvents(cmd.Context(), logger, networkConfig, operatorPrivKey)
+		}
+
+		// nolint: gosec
+		f, err := os.OpenFile(replayOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			logger.Fatal("could not create output file", zap.Error(err))
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(replay)
+		if err == nil {
+			err = f.Close()
+		}
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(replayOutput)
+			logger.Fatal("could not write replayed registry", zap.Error(err))
+		}
+
+		logger.Info("replayed registry events",
+			zap.String("file", replayOutput),
+			zap.Uint64("from_block", replay.FromBlock),
+			zap.Uint64("to_block", replay.ToBlock),
+			zap.Uint64("operator_id", replay.OperatorID),
+			zap.Int("operators", len(replay.Operators)),
+			zap.Int("shares", len(replay.Shares)),
+			zap.Int("recipients", len(replay.Recipients)))
+	},
+}
+
+// replayContractEvents replays the registry contract's events of the configured block range.
+func replayContractEvents(
+	ctx context.Context,
+	logger *zap.Logger,
+	networkConfig networkconfig.NetworkConfig,
+	operatorPrivKey keys.OperatorPrivateKey,
+) *registryReplay {
+	executionClient := setupRegistryExecutionClient(ctx, logger, networkConfig)
+	defer executionClient.Close()
+	fromBlock, toBlock := registryBlockRange(ctx, logger, executionClient, networkConfig, replayFromBlock, replayToBlock)
+
+	eventFilterer, err := executionClient.Filterer()
+	if err != nil {
+		logger.Fatal("could not create event filterer", zap.Error(err))
+	}
+	logger.Info("replaying registry events", zap.Uint64("from_block", fromBlock), zap.Uint64("to_block", toBlock))
+	replay, err := replayRegistryEvents(ctx, logger, networkConfig, operatorPrivKey, eventFilterer,
+		func(eventHandler *eventhandler.EventHandler) error {
+			logs, fetchErrors := executionClient.FetchFilteredLogs(ctx, fromBlock, toBlock, nil)
+			if _, err := eventHandler.HandleBlockEventsStream(logs, false); err != nil {
+				return err
+			}
+			if err := <-fetchErrors; err != nil {
+				return fmt.Errorf("could not fetch registry events: %w", err)
+			}
+			return nil
+		})
+	if err != nil {
+		logger.Fatal("could not replay registry events", zap.Error(err))
+	}
+	replay.FromBlock, replay.ToBlock = fromBlock, toBlock
+	return replay
+}
+
+// registryReplay is the registry resulting from replaying registry events, ordered to be easily diffed.
+type registryReplay struct {
+	Network    string `json:"network"`
+	FromBlock  uint64 `json:"from_block,omitempty"`
+	ToBlock    uint64 `json:"to_block,omitempty"`
+	EventsFile string `json:"events_file,omitempty"`
+	// OperatorID is the ID of the operator, if it's registered.
+	OperatorID uint64 `json:"operator_id,omitempty"`
+
+	Operators  []replayedOperator               `json:"operators"`
+	Shares     []replayedShare                  `json:"shares"`
+	Recipients []*registrystorage.RecipientData `json:"recipients"`
+}
+
+type replayedOperator struct {
+	ID        uint64            `json:"id"`
+	Owner     ethcommon.Address `json:"owner"`
+	PublicKey string            `json:"public_key"`
+}
+
+type replayedShare struct {
+	PublicKey   string            `json:"public_key"`
+	Owner       ethcommon.Address `json:"owner"`
+	OperatorIDs []uint64          `json:"operator_ids"`
+	Liquidated  bool              `json:"liquidated"`
+	// Own shares belong to the operator, and their validators would be started.
+	Own bool `json:"own"`
+}
+
+// replayRegistryEvents processes registry events with the given function into a throwaway in-memory registry,
+// without executing their tasks, and returns the resulting registry.
+func replayRegistryEvents(
+	ctx context.Context,
+	logger *zap.Logger,
+	networkConfig networkconfig.NetworkConfig,
+	operatorPrivKey keys.OperatorPrivateKey,
+	eventFilterer *contract.ContractFilterer,
+	handle func(eventHandler *eventhandler.EventHandler) error,
+) (*registryReplay, error) {
+	db, err := kv.NewInMemory(logger, basedb.Options{Ctx: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("could not create in-memory db: %w", err)
+	}
+	defer db.Close()
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		return nil, fmt.Errorf("could not create node storage: %w", err)
+	}
+	encodedPubKey, err := operatorPrivKey.Public().Base64()
+	if err != nil {
+		return nil, fmt.Errorf("could not encode operator public key: %w", err)
+	}
+	operatorDataStore := operatordatastore.New(&registrystorage.OperatorData{PublicKey: encodedPubKey})
+
+	keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not create key manager: %w", err)
+	}
+
+	// Tasks aren't executed, so neither validators nor the beacon node are needed.
+	eventHandler, err := eventhandler.New(
+		nodeStorage,
+		eventparser.New(eventFilterer),
+		nil,
+		networkConfig,
+		operatorDataStore,
+		operatorPrivKey,
+		keyManager,
+		nil,
+		ibftstorage.NewStores(),
+		eventhandler.WithLogger(logger),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create event handler: %w", err)
+	}
+	if err := handle(eventHandler); err != nil {
+		return nil, err
+	}
+
+	replay := &registryReplay{
+		Network:    networkConfig.Name,
+		OperatorID: operatorDataStore.GetOperatorID(),
+		Operators:  []replayedOperator{},
+		Shares:     []replayedShare{},
+	}
+	operators, err := nodeStorage.ListOperators(nil, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not list operators: %w", err)
+	}
+	for _, operator := range operators {
+		replay.Operators = append(replay.Operators, replayedOperator{
+			ID:        operator.ID,
+			Owner:     operator.OwnerAddress,
+			PublicKey: string(operator.PublicKey),
+		})
+	}
+	for _, share := range nodeStorage.Shares().List(nil) {
+		replayed := replayedShare{
+			PublicKey:  "0x" + hex.EncodeToString(share.ValidatorPubKey),
+			Owner:      share.OwnerAddress,
+			Liquidated: share.Liquidated,
+			Own:        replay.OperatorID != 0 && share.BelongsToOperator(replay.OperatorID),
+		}
+		for _, member := range share.Committee {
+			replayed.OperatorIDs = append(replayed.OperatorIDs, member.OperatorID)
+		}
+		replay.Shares = append(replay.Shares, replayed)
+	}
+	sort.Slice(replay.Shares, func(i, j int) bool {
+		return replay.Shares[i].PublicKey < replay.Shares[j].PublicKey
+	})
+	replay.Recipients, err = nodeStorage.ListRecipients(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not list recipients: %w", err)
+	}
+	if replay.Recipients == nil {
+		replay.Recipients = []*registrystorage.RecipientData{}
+	}
+	return replay, nil
+}
@@ -0,0 +1,80 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventhandler"
+	"github.com/bloxapp/ssv/eth/localevents"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/keys"
+)
+
+func TestReplayRegistryEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := zaptest.NewLogger(t)
+
+	operatorKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	operatorPubKey, err := operatorKey.Public().Base64()
+	require.NoError(t, err)
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherPubKey, err := otherKey.Public().Base64()
+	require.NoError(t, err)
+
+	owner := ethcommon.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	recipient := ethcommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	eventsPath := filepath.Join(t.TempDir(), "events.yaml")
+	require.NoError(t, os.WriteFile(eventsPath, []byte(fmt.Sprintf(`
+- Log:
+  Name: OperatorAdded
+  Data:
+    ID: 1
+    Owner: %[1]s
+    PublicKey: %[2]s
+- Log:
+  Name: OperatorAdded
+  Data:
+    ID: 2
+    Owner: %[1]s
+    PublicKey: %[3]s
+- Log:
+  Name: FeeRecipientAddressUpdated
+  Data:
+    Owner: %[1]s
+    RecipientAddress: %[4]s
+`, owner.Hex(), otherPubKey, operatorPubKey, recipient.Hex())), 0600))
+
+	events, err := localevents.Load(eventsPath)
+	require.NoError(t, err)
+	eventFilterer, err := contract.NewContractFilterer(ethcommon.Address{}, nil)
+	require.NoError(t, err)
+
+	replay, err := replayRegistryEvents(ctx, logger, networkconfig.TestNetwork, operatorKey, eventFilterer,
+		func(eventHandler *eventhandler.EventHandler) error {
+			return eventHandler.HandleLocalEvents(events)
+		})
+	require.NoError(t, err)
+
+	require.Equal(t, networkconfig.TestNetwork.Name, replay.Network)
+	require.Equal(t, uint64(2), replay.OperatorID)
+	require.Equal(t, []replayedOperator{
+		{ID: 1, Owner: owner, PublicKey: string(otherPubKey)},
+		{ID: 2, Owner: owner, PublicKey: string(operatorPubKey)},
+	}, replay.Operators)
+	require.Empty(t, replay.Shares)
+	require.Len(t, replay.Recipients, 1)
+	require.Equal(t, owner, replay.Recipients[0].Owner)
+	require.Equal(t, bellatrix.ExecutionAddress(recipient), replay.Recipients[0].FeeRecipient)
+}
@@ -0,0 +1,118 @@
+package operator
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/ekm"
+)
+
+var (
+	slashingProtectionOutput string
+	slashingProtectionInput  string
+)
+
+// SlashingProtectionCmd is the command to export and import slashing protection data
+// in the EIP-3076 interchange format. The node must not be running.
+var SlashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Exports or imports slashing protection data (EIP-3076)",
+}
+
+var exportSlashingProtectionCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the slashing protection data of all shares to an EIP-3076 interchange file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, signerStorage, done := setupSlashingProtection(cmd)
+		defer done()
+
+		interchange, err := ekm.ExportSlashingProtection(signerStorage)
+		if err != nil {
+			logger.Fatal("could not export slashing protection", zap.Error(err))
+		}
+		data, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			logger.Fatal("could not encode slashing protection", zap.Error(err))
+		}
+		if err := os.WriteFile(slashingProtectionOutput, data, 0600); err != nil {
+			logger.Fatal("could not write slashing protection file", zap.Error(err))
+		}
+
+		logger.Info("exported slashing protection",
+			zap.String("file", slashingProtectionOutput),
+			zap.Int("validators", len(interchange.Data)))
+	},
+}
+
+var importSlashingProtectionCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Merges an EIP-3076 interchange file into the slashing protection data, without ever lowering it",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, signerStorage, done := setupSlashingProtection(cmd)
+		defer done()
+
+		// nolint: gosec
+		data, err := os.ReadFile(slashingProtectionInput)
+		if err != nil {
+			logger.Fatal("could not read slashing protection file", zap.Error(err))
+		}
+		var interchange ekm.Interchange
+		if err := json.Unmarshal(data, &interchange); err != nil {
+			logger.Fatal("could not decode slashing protection file", zap.Error(err))
+		}
+
+		result, err := ekm.ImportSlashingProtection(signerStorage, &interchange)
+		if err != nil {
+			logger.Fatal("could not import slashing protection", zap.Error(err))
+		}
+
+		logger.Info("imported slashing protection",
+			zap.String("file", slashingProtectionInput),
+			zap.Int("validators", len(interchange.Data)),
+			zap.Int("raised_attestations", result.Attestations),
+			zap.Int("raised_proposals", result.Proposals))
+	},
+}
+
+func setupSlashingProtection(cmd *cobra.Command) (*zap.Logger, ekm.Storage, func()) {
+	logger, err := setupGlobal()
+	if err != nil {
+		log.Fatal("could not create logger", err)
+	}
+
+	networkConfig, err := setupSSVNetwork(logger)
+	if err != nil {
+		logger.Fatal("could not setup network", zap.Error(err))
+	}
+
+	cfg.DBOptions.Ctx = cmd.Context()
+	db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
+	if err != nil {
+		logger.Fatal("could not setup db", zap.Error(err))
+	}
+
+	done := func() {
+		if err := db.Close(); err != nil {
+			logger.Error("could not close db", zap.Error(err))
+		}
+	}
+	return logger, ekm.NewSignerStorage(db, networkConfig.Beacon, logger), done
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, SlashingProtectionCmd)
+
+	exportSlashingProtectionCmd.Flags().StringVarP(&slashingProtectionOutput, "output", "o", "", "Path to write the interchange file to")
+	_ = exportSlashingProtectionCmd.MarkFlagRequired("output")
+
+	importSlashingProtectionCmd.Flags().StringVarP(&slashingProtectionInput, "input", "i", "", "Path to the interchange file to import")
+	_ = importSlashingProtectionCmd.MarkFlagRequired("input")
+
+	SlashingProtectionCmd.AddCommand(exportSlashingProtectionCmd)
+	SlashingProtectionCmd.AddCommand(importSlashingProtectionCmd)
+}
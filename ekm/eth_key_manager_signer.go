@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
@@ -38,15 +39,17 @@ const (
 	// It defines the smallest allowable gap between the current slot and the slot of a new block proposal,
 	// helping to prevent slashable offenses.
 	minSPProposalSlotGap = phase0.Slot(0)
+	// historyPruneInterval is the number of epochs between prunings of the slashing protection history.
+	historyPruneInterval = phase0.Epoch(32)
 )
 
 type ethKeyManagerSigner struct {
-	wallet            core.Wallet
-	walletLock        *sync.RWMutex
-	signer            signer.ValidatorSigner
-	storage           Storage
-	domain            spectypes.DomainType
-	slashingProtector core.SlashingProtector
+	wallet     core.Wallet
+	walletLock *sync.RWMutex
+	signer     signer.ValidatorSigner
+	storage    Storage
+	domain     spectypes.DomainType
+	*slashingProtection
 }
 
 // StorageProvider provides the underlying KeyManager storage.
@@ -55,10 +58,15 @@ type StorageProvider interface {
 	RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error)
 	RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bool, error)
 	BumpSlashingProtection(pubKey []byte) error
+	ExportSlashingProtection() (*Interchange, error)
+	ImportSlashingProtection(interchange *Interchange) (*InterchangeImportResult, error)
+	ListSignedAttestations(pubKey []byte, fromTarget, toTarget phase0.Epoch) ([]*SignedAttestation, error)
+	ListSignedProposals(pubKey []byte, fromSlot, toSlot phase0.Slot) ([]*SignedProposal, error)
 }
 
 // NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
-func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, encryptionKey string) (spectypes.KeyManager, error) {
+func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, encryptionKey string, opts ...Option) (spectypes.KeyManager, error) {
+	options := newOptions(opts)
 	signerStore := NewSignerStorage(db, network.Beacon, logger)
 	if encryptionKey != "" {
 		err := signerStore.SetEncryptionKey(encryptionKey)
@@ -66,16 +74,16 @@ func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network netw
 			return nil, err
 		}
 	}
-	options := &eth2keymanager.KeyVaultOptions{}
-	options.SetStorage(signerStore)
-	options.SetWalletType(core.NDWallet)
+	vaultOptions := &eth2keymanager.KeyVaultOptions{}
+	vaultOptions.SetStorage(signerStore)
+	vaultOptions.SetWalletType(core.NDWallet)
 
 	wallet, err := signerStore.OpenWallet()
 	if err != nil && err.Error() != "could not find wallet" {
 		return nil, err
 	}
 	if wallet == nil {
-		vault, err := eth2keymanager.NewKeyVault(options)
+		vault, err := eth2keymanager.NewKeyVault(vaultOptions)
 		if err != nil {
 			return nil, err
 		}
@@ -89,12 +97,12 @@ func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network netw
 	beaconSigner := signer.NewSimpleSigner(wallet, slashingProtector, core.Network(network.Beacon.GetBeaconNetwork()))
 
 	return &ethKeyManagerSigner{
-		wallet:            wallet,
-		walletLock:        &sync.RWMutex{},
-		signer:            beaconSigner,
-		storage:           signerStore,
-		domain:            network.Domain,
-		slashingProtector: slashingProtector,
+		wallet:             wallet,
+		walletLock:         &sync.RWMutex{},
+		signer:             beaconSigner,
+		storage:            signerStore,
+		domain:             network.Domain,
+		slashingProtection: newSlashingProtection(logger.Named(logging.NameSignerStorage), signerStore, slashingProtector, options.historyRetention),
 	}, nil
 }
 
@@ -111,19 +119,42 @@ func (km *ethKeyManagerSigner) RetrieveHighestProposal(pubKey []byte) (phase0.Sl
 }
 
 func (km *ethKeyManagerSigner) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	// The history is checked under the wallet lock too, so that it can't race with an import.
+	km.walletLock.RLock()
+	defer km.walletLock.RUnlock()
+
+	var record func() error
+	if domainType == spectypes.DomainAttester || domainType == spectypes.DomainProposer {
+		lock := km.historyLock(pk)
+		lock.Lock()
+		defer lock.Unlock()
+
+		var err error
+		record, err = km.checkSlashingHistory(obj, domain, pk, domainType)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+	}
+
 	sig, rootSlice, err := km.signBeaconObject(obj, domain, pk, domainType)
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
+
+	// The signature is only released once it's recorded in the history.
+	if record != nil {
+		if err := record(); err != nil {
+			return nil, [32]byte{}, fmt.Errorf("could not record slashing protection history: %w", err)
+		}
+	}
+
 	var root [32]byte
 	copy(root[:], rootSlice)
 	return sig, root, nil
 }
 
+// signBeaconObject signs the object with the signer. The caller must hold the wallet lock.
 func (km *ethKeyManagerSigner) signBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, []byte, error) {
-	km.walletLock.RLock()
-	defer km.walletLock.RUnlock()
-
 	switch domainType {
 	case spectypes.DomainAttester:
 		data, ok := obj.(*phase0.AttestationData)
@@ -221,28 +252,6 @@ func (km *ethKeyManagerSigner) signBeaconObject(obj ssz.HashRoot, domain phase0.
 	}
 }
 
-func (km *ethKeyManagerSigner) IsAttestationSlashable(pk []byte, data *phase0.AttestationData) error {
-	if val, err := km.slashingProtector.IsSlashableAttestation(pk, data); err != nil || val != nil {
-		if err != nil {
-			return err
-		}
-		return errors.Errorf("slashable attestation (%s), not signing", val.Status)
-	}
-	return nil
-}
-
-func (km *ethKeyManagerSigner) IsBeaconBlockSlashable(pk []byte, slot phase0.Slot) error {
-	status, err := km.slashingProtector.IsSlashableProposal(pk, slot)
-	if err != nil {
-		return err
-	}
-	if status.Status != core.ValidProposal {
-		return errors.Errorf("slashable proposal (%s), not signing", status.Status)
-	}
-
-	return nil
-}
-
 func (km *ethKeyManagerSigner) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
 	km.walletLock.RLock()
 	defer km.walletLock.RUnlock()
@@ -298,11 +307,8 @@ func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 		if err != nil {
 			return errors.Wrap(err, "could not hex decode share public key")
 		}
-		if err := km.storage.RemoveHighestAttestation(pkDecoded); err != nil {
-			return errors.Wrap(err, "could not remove highest attestation")
-		}
-		if err := km.storage.RemoveHighestProposal(pkDecoded); err != nil {
-			return errors.Wrap(err, "could not remove highest proposal")
+		if err := km.remove(pkDecoded); err != nil {
+			return err
 		}
 		if err := km.wallet.DeleteAccountByPublicKey(pubKey); err != nil {
 			return errors.Wrap(err, "could not delete share")
@@ -311,100 +317,31 @@ func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 	return nil
 }
 
-// BumpSlashingProtection updates the slashing protection data for a given public key.
-func (km *ethKeyManagerSigner) BumpSlashingProtection(pubKey []byte) error {
-	currentSlot := km.storage.BeaconNetwork().EstimatedCurrentSlot()
-
-	// Update highest attestation data for slashing protection.
-	if err := km.updateHighestAttestation(pubKey, currentSlot); err != nil {
-		return err
-	}
-
-	// Update highest proposal data for slashing protection.
-	if err := km.updateHighestProposal(pubKey, currentSlot); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// updateHighestAttestation updates the highest attestation data for slashing protection.
-func (km *ethKeyManagerSigner) updateHighestAttestation(pubKey []byte, slot phase0.Slot) error {
-	// Retrieve the highest attestation data stored for the given public key.
-	retrievedHighAtt, found, err := km.RetrieveHighestAttestation(pubKey)
-	if err != nil {
-		return fmt.Errorf("could not retrieve highest attestation: %w", err)
-	}
-
-	currentEpoch := km.storage.BeaconNetwork().EstimatedEpochAtSlot(slot)
-	minimalSP := km.computeMinimalAttestationSP(currentEpoch)
-
-	// Check if the retrieved highest attestation data is valid and not outdated.
-	if found && retrievedHighAtt != nil {
-		if retrievedHighAtt.Source.Epoch >= minimalSP.Source.Epoch || retrievedHighAtt.Target.Epoch >= minimalSP.Target.Epoch {
-			return nil
-		}
-	}
-
-	// At this point, either the retrieved attestation data was not found, or it was outdated.
-	// In either case, we update it to the minimal slashing protection data.
-	if err := km.storage.SaveHighestAttestation(pubKey, minimalSP); err != nil {
-		return fmt.Errorf("could not save highest attestation: %w", err)
-	}
+// ExportSlashingProtection exports the slashing protection data of all shares in the EIP-3076 interchange format.
+func (km *ethKeyManagerSigner) ExportSlashingProtection() (*Interchange, error) {
+	km.walletLock.RLock()
+	defer km.walletLock.RUnlock()
 
-	return nil
+	return ExportSlashingProtection(km.storage)
 }
 
-// updateHighestProposal updates the highest proposal slot for slashing protection.
-func (km *ethKeyManagerSigner) updateHighestProposal(pubKey []byte, slot phase0.Slot) error {
-	// Retrieve the highest proposal slot stored for the given public key.
-	retrievedHighProp, found, err := km.RetrieveHighestProposal(pubKey)
-	if err != nil {
-		return fmt.Errorf("could not retrieve highest proposal: %w", err)
-	}
-
-	minimalSPSlot := km.computeMinimalProposerSP(slot)
-
-	// Check if the retrieved highest proposal slot is valid and not outdated.
-	if found && retrievedHighProp != 0 {
-		if retrievedHighProp >= minimalSPSlot {
-			return nil
-		}
-	}
-
-	// At this point, either the retrieved proposal slot was not found, or it was outdated.
-	// In either case, we update it to the minimal slashing protection slot.
-	if err := km.storage.SaveHighestProposal(pubKey, minimalSPSlot); err != nil {
-		return fmt.Errorf("could not save highest proposal: %w", err)
-	}
+// ImportSlashingProtection merges EIP-3076 interchange data into the slashing protection data.
+// Signing is blocked during the import, so that concurrently signed data can't be lowered by it.
+func (km *ethKeyManagerSigner) ImportSlashingProtection(interchange *Interchange) (*InterchangeImportResult, error) {
+	km.walletLock.Lock()
+	defer km.walletLock.Unlock()
 
-	return nil
+	return ImportSlashingProtection(km.storage, interchange)
 }
 
-// computeMinimalAttestationSP calculates the minimal safe attestation data for slashing protection.
-// It takes the current epoch as an argument and returns an AttestationData object with the minimal safe source and target epochs.
-func (km *ethKeyManagerSigner) computeMinimalAttestationSP(epoch phase0.Epoch) *phase0.AttestationData {
-	// Calculate the highest safe target epoch based on the current epoch and a predefined minimum distance.
-	highestTarget := epoch + minSPAttestationEpochGap
-	// The highest safe source epoch is one less than the highest target epoch.
-	highestSource := highestTarget - 1
-
-	// Return a new AttestationData object with the calculated source and target epochs.
-	return &phase0.AttestationData{
-		Source: &phase0.Checkpoint{
-			Epoch: highestSource,
-		},
-		Target: &phase0.Checkpoint{
-			Epoch: highestTarget,
-		},
-	}
+// ListSignedAttestations returns the attestations signed by the share with a target epoch within the given range.
+func (km *ethKeyManagerSigner) ListSignedAttestations(pubKey []byte, fromTarget, toTarget phase0.Epoch) ([]*SignedAttestation, error) {
+	return km.storage.ListSignedAttestations(pubKey, fromTarget, toTarget)
 }
 
-// computeMinimalProposerSP calculates the minimal safe slot for a block proposal to avoid slashing.
-// It takes the current slot as an argument and returns the minimal safe slot.
-func (km *ethKeyManagerSigner) computeMinimalProposerSP(slot phase0.Slot) phase0.Slot {
-	// Calculate the highest safe proposal slot based on the current slot and a predefined minimum distance.
-	return slot + minSPProposalSlotGap
+// ListSignedProposals returns the block proposals signed by the share within the given slot range.
+func (km *ethKeyManagerSigner) ListSignedProposals(pubKey []byte, fromSlot, toSlot phase0.Slot) ([]*SignedProposal, error) {
+	return km.storage.ListSignedProposals(pubKey, fromSlot, toSlot)
 }
 
 func (km *ethKeyManagerSigner) saveShare(shareKey *bls.SecretKey) error {
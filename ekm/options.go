@@ -0,0 +1,48 @@
+package ekm
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// DefaultHistoryRetention is the default number of epochs of the slashing protection history which are kept (about 18 days).
+	DefaultHistoryRetention phase0.Epoch = 4096
+	// DefaultRemoteSignerTimeout is the default timeout of requests to the remote signer.
+	DefaultRemoteSignerTimeout = 5 * time.Second
+)
+
+type options struct {
+	historyRetention    phase0.Epoch
+	remoteSignerTimeout time.Duration
+}
+
+// Option defines a key manager configuration option.
+type Option func(*options)
+
+// WithHistoryRetention sets how many epochs of signed attestations and proposals are kept
+// in the slashing protection history. Zero keeps the entire history.
+func WithHistoryRetention(epochs phase0.Epoch) Option {
+	return func(o *options) {
+		o.historyRetention = epochs
+	}
+}
+
+// WithRemoteSignerTimeout sets the timeout of requests to the remote signer.
+func WithRemoteSignerTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.remoteSignerTimeout = timeout
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		historyRetention:    DefaultHistoryRetention,
+		remoteSignerTimeout: DefaultRemoteSignerTimeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,582 @@
+package ekm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	slashingprotection "github.com/bloxapp/eth2-key-manager/slashing_protection"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// ForkInfoProvider provides the fork info which a remote signer needs to compute signature domains.
+type ForkInfoProvider interface {
+	ForkInfo(epoch phase0.Epoch) (*phase0.Fork, phase0.Root, error)
+}
+
+// remoteKeyManager is a KeyManager which delegates signing to a Web3Signer-compatible remote signer,
+// so that share keys are never stored on the node. Slashing protection is still enforced locally,
+// with the same data as ethKeyManagerSigner, before any request is sent to the remote signer.
+type remoteKeyManager struct {
+	url      string
+	client   *http.Client
+	forkInfo ForkInfoProvider
+	network  networkconfig.NetworkConfig
+	storage  Storage
+	logger   *zap.Logger
+
+	// lock blocks signing while slashing protection data is imported, or while shares are added or removed.
+	lock sync.RWMutex
+	*slashingProtection
+
+	// ssvMessagesChecked is set once the remote signer signed an SSV_MESSAGE request.
+	ssvMessagesChecked atomic.Bool
+}
+
+// remoteProbe is the SSV message which is signed to check that the remote signer supports SSV_MESSAGE requests.
+// Its root isn't the root of any valid SSV message.
+type remoteProbe struct{}
+
+func (remoteProbe) GetRoot() ([32]byte, error) {
+	return sha256.Sum256([]byte("ssv remote signer probe")), nil
+}
+
+// remoteStatusError is returned when the remote signer responds with an unexpected status.
+type remoteStatusError struct {
+	code int
+	body string
+}
+
+func (e *remoteStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.code, e.body)
+}
+
+// NewRemoteKeyManager returns a KeyManager which signs with the Web3Signer-compatible remote signer at the given URL.
+// Share keys must be provisioned to the remote signer separately.
+func NewRemoteKeyManager(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, url string, forkInfo ForkInfoProvider, opts ...Option) (spectypes.KeyManager, error) {
+	if url == "" {
+		return nil, errors.New("remote signer URL is empty")
+	}
+	options := newOptions(opts)
+	logger = logger.Named(logging.NameRemoteSigner)
+
+	signerStore := NewSignerStorage(db, network.Beacon, logger)
+	protector := slashingprotection.NewNormalProtection(signerStore)
+
+	return &remoteKeyManager{
+		url:                strings.TrimSuffix(url, "/"),
+		client:             &http.Client{Timeout: options.remoteSignerTimeout},
+		forkInfo:           forkInfo,
+		network:            network,
+		storage:            signerStore,
+		logger:             logger,
+		slashingProtection: newSlashingProtection(logger, signerStore, protector, options.historyRetention),
+	}, nil
+}
+
+// remoteForkInfo is the fork_info of a remote sign request.
+type remoteForkInfo struct {
+	Fork                  *phase0.Fork `json:"fork"`
+	GenesisValidatorsRoot phase0.Root  `json:"genesis_validators_root"`
+}
+
+// remoteBeaconBlock is the beacon_block of a BLOCK_V2 remote sign request.
+// The block header has the same root as the block, so it's sent instead of the full block.
+type remoteBeaconBlock struct {
+	Version     string                    `json:"version"`
+	BlockHeader *phase0.BeaconBlockHeader `json:"block_header"`
+}
+
+type remoteAggregationSlot struct {
+	Slot phase0.Slot `json:"slot"`
+}
+
+type remoteRandaoReveal struct {
+	Epoch phase0.Epoch `json:"epoch"`
+}
+
+type remoteSyncCommitteeMessage struct {
+	BeaconBlockRoot phase0.Root `json:"beacon_block_root"`
+	Slot            phase0.Slot `json:"slot"`
+}
+
+// remoteSSVMessage is the ssv_message of an SSV_MESSAGE remote sign request,
+// from which the remote signer can recompute the signing root.
+type remoteSSVMessage struct {
+	Domain        string      `json:"domain"`
+	SignatureType string      `json:"signature_type"`
+	Root          phase0.Root `json:"root"`
+}
+
+// remoteSignRequest is the body of a Web3Signer sign request (POST /api/v1/eth2/sign/{pubkey}).
+// SSV messages are signed with the SSV_MESSAGE type, which Web3Signer doesn't support,
+// so the remote signer must be a Web3Signer-compatible signer which supports it as well.
+type remoteSignRequest struct {
+	Type                        string                              `json:"type"`
+	ForkInfo                    *remoteForkInfo                     `json:"fork_info,omitempty"`
+	SigningRoot                 phase0.Root                         `json:"signingRoot"`
+	Attestation                 *phase0.AttestationData             `json:"attestation,omitempty"`
+	BeaconBlock                 *remoteBeaconBlock                  `json:"beacon_block,omitempty"`
+	VoluntaryExit               *phase0.VoluntaryExit               `json:"voluntary_exit,omitempty"`
+	AggregateAndProof           *phase0.AggregateAndProof           `json:"aggregate_and_proof,omitempty"`
+	AggregationSlot             *remoteAggregationSlot              `json:"aggregation_slot,omitempty"`
+	RandaoReveal                *remoteRandaoReveal                 `json:"randao_reveal,omitempty"`
+	SyncCommitteeMessage        *remoteSyncCommitteeMessage         `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *altair.SyncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *altair.ContributionAndProof        `json:"contribution_and_proof,omitempty"`
+	ValidatorRegistration       *eth2apiv1.ValidatorRegistration    `json:"validator_registration,omitempty"`
+	SSVMessage                  *remoteSSVMessage                   `json:"ssv_message,omitempty"`
+}
+
+func (km *remoteKeyManager) ListAccounts() ([]core.ValidatorAccount, error) {
+	return km.storage.ListAccounts()
+}
+
+func (km *remoteKeyManager) RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error) {
+	return km.storage.RetrieveHighestAttestation(pubKey)
+}
+
+func (km *remoteKeyManager) RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bool, error) {
+	return km.storage.RetrieveHighestProposal(pubKey)
+}
+
+// SignBeaconObject signs the object at the current slot. Sync committee messages don't carry their slot,
+// which the remote signer requires, so they must be signed with SignBeaconObjectAtSlot.
+func (km *remoteKeyManager) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	if domainType == spectypes.DomainSyncCommittee {
+		return nil, [32]byte{}, errors.New("sync committee messages must be signed with their slot")
+	}
+	return km.SignBeaconObjectAtSlot(obj, km.network.Beacon.EstimatedCurrentSlot(), domain, pk, domainType)
+}
+
+// SignBeaconObjectAtSlot signs the object of a duty at the given slot.
+func (km *remoteKeyManager) SignBeaconObjectAtSlot(obj ssz.HashRoot, slot phase0.Slot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+
+	request, err := km.signRequest(obj, slot, domainType)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	root, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not compute signing root")
+	}
+	request.SigningRoot = root
+
+	var record func() error
+	if domainType == spectypes.DomainAttester || domainType == spectypes.DomainProposer {
+		lock := km.historyLock(pk)
+		lock.Lock()
+		defer lock.Unlock()
+
+		if err := km.protect(obj, pk, domainType); err != nil {
+			return nil, [32]byte{}, err
+		}
+		record, err = km.checkSlashingHistory(obj, domain, pk, domainType)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		if err := km.updateHighest(obj, pk, domainType); err != nil {
+			return nil, [32]byte{}, err
+		}
+	}
+
+	sig, err := km.sign(pk, root, request)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	// The signature is only released once it's recorded in the history.
+	if record != nil {
+		if err := record(); err != nil {
+			return nil, [32]byte{}, fmt.Errorf("could not record slashing protection history: %w", err)
+		}
+	}
+
+	return sig, root, nil
+}
+
+// protect checks the attestation or proposal against the highest attestation or proposal of the share.
+func (km *remoteKeyManager) protect(obj ssz.HashRoot, pk []byte, domainType phase0.DomainType) error {
+	if domainType == spectypes.DomainAttester {
+		return km.IsAttestationSlashable(pk, obj.(*phase0.AttestationData))
+	}
+	slot, err := blockSlot(obj)
+	if err != nil {
+		return err
+	}
+	return km.IsBeaconBlockSlashable(pk, slot)
+}
+
+// updateHighest raises the highest attestation or proposal of the share before signing,
+// like the local signer does.
+func (km *remoteKeyManager) updateHighest(obj ssz.HashRoot, pk []byte, domainType phase0.DomainType) error {
+	if domainType == spectypes.DomainAttester {
+		return km.protector.UpdateHighestAttestation(pk, obj.(*phase0.AttestationData))
+	}
+	slot, err := blockSlot(obj)
+	if err != nil {
+		return err
+	}
+	return km.protector.UpdateHighestProposal(pk, slot)
+}
+
+// signRequest returns the remote sign request for the given object of a duty at the given slot, without its signing root.
+func (km *remoteKeyManager) signRequest(obj ssz.HashRoot, slot phase0.Slot, domainType phase0.DomainType) (*remoteSignRequest, error) {
+	request := &remoteSignRequest{}
+	switch domainType {
+	case spectypes.DomainAttester:
+		data, ok := obj.(*phase0.AttestationData)
+		if !ok {
+			return nil, errors.New("could not cast obj to AttestationData")
+		}
+		request.Type = "ATTESTATION"
+		request.Attestation = data
+	case spectypes.DomainProposer:
+		block, err := blockHeader(obj)
+		if err != nil {
+			return nil, err
+		}
+		request.Type = "BLOCK_V2"
+		request.BeaconBlock = block
+	case spectypes.DomainVoluntaryExit:
+		data, ok := obj.(*phase0.VoluntaryExit)
+		if !ok {
+			return nil, errors.New("could not cast obj to VoluntaryExit")
+		}
+		request.Type = "VOLUNTARY_EXIT"
+		request.VoluntaryExit = data
+	case spectypes.DomainAggregateAndProof:
+		data, ok := obj.(*phase0.AggregateAndProof)
+		if !ok {
+			return nil, errors.New("could not cast obj to AggregateAndProof")
+		}
+		request.Type = "AGGREGATE_AND_PROOF"
+		request.AggregateAndProof = data
+	case spectypes.DomainSelectionProof:
+		data, ok := obj.(spectypes.SSZUint64)
+		if !ok {
+			return nil, errors.New("could not cast obj to SSZUint64")
+		}
+		request.Type = "AGGREGATION_SLOT"
+		request.AggregationSlot = &remoteAggregationSlot{Slot: phase0.Slot(data)}
+	case spectypes.DomainRandao:
+		data, ok := obj.(spectypes.SSZUint64)
+		if !ok {
+			return nil, errors.New("could not cast obj to SSZUint64")
+		}
+		request.Type = "RANDAO_REVEAL"
+		request.RandaoReveal = &remoteRandaoReveal{Epoch: phase0.Epoch(data)}
+	case spectypes.DomainSyncCommittee:
+		data, ok := obj.(spectypes.SSZBytes)
+		if !ok {
+			return nil, errors.New("could not cast obj to SSZBytes")
+		}
+		message := &remoteSyncCommitteeMessage{Slot: slot}
+		copy(message.BeaconBlockRoot[:], data)
+		request.Type = "SYNC_COMMITTEE_MESSAGE"
+		request.SyncCommitteeMessage = message
+	case spectypes.DomainSyncCommitteeSelectionProof:
+		data, ok := obj.(*altair.SyncAggregatorSelectionData)
+		if !ok {
+			return nil, errors.New("could not cast obj to SyncAggregatorSelectionData")
+		}
+		request.Type = "SYNC_COMMITTEE_SELECTION_PROOF"
+		request.SyncAggregatorSelectionData = data
+	case spectypes.DomainContributionAndProof:
+		data, ok := obj.(*altair.ContributionAndProof)
+		if !ok {
+			return nil, errors.New("could not cast obj to ContributionAndProof")
+		}
+		request.Type = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+		request.ContributionAndProof = data
+	case spectypes.DomainApplicationBuilder:
+		data, ok := obj.(*eth2apiv1.ValidatorRegistration)
+		if !ok {
+			return nil, fmt.Errorf("obj type is unknown: %T", obj)
+		}
+		request.Type = "VALIDATOR_REGISTRATION"
+		request.ValidatorRegistration = data
+		// Validator registrations are signed with a fork-independent domain.
+		return request, nil
+	default:
+		return nil, errors.New("domain unknown")
+	}
+
+	fork, genesisValidatorsRoot, err := km.forkInfo.ForkInfo(km.network.Beacon.EstimatedEpochAtSlot(slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get fork info")
+	}
+	request.ForkInfo = &remoteForkInfo{
+		Fork:                  fork,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+	return request, nil
+}
+
+// blockHeader returns the beacon_block of a BLOCK_V2 request for the given (blinded) beacon block.
+func blockHeader(obj interface{}) (*remoteBeaconBlock, error) {
+	var (
+		version string
+		header  = &phase0.BeaconBlockHeader{}
+		body    ssz.HashRoot
+	)
+	switch v := obj.(type) {
+	case *capella.BeaconBlock:
+		version = "CAPELLA"
+		header.Slot, header.ProposerIndex, header.ParentRoot, header.StateRoot, body = v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot, v.Body
+	case *deneb.BeaconBlock:
+		version = "DENEB"
+		header.Slot, header.ProposerIndex, header.ParentRoot, header.StateRoot, body = v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot, v.Body
+	case *apiv1capella.BlindedBeaconBlock:
+		version = "CAPELLA"
+		header.Slot, header.ProposerIndex, header.ParentRoot, header.StateRoot, body = v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot, v.Body
+	case *apiv1deneb.BlindedBeaconBlock:
+		version = "DENEB"
+		header.Slot, header.ProposerIndex, header.ParentRoot, header.StateRoot, body = v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot, v.Body
+	default:
+		return nil, fmt.Errorf("obj type is unknown: %T", obj)
+	}
+
+	bodyRoot, err := body.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block body root")
+	}
+	header.BodyRoot = bodyRoot
+	return &remoteBeaconBlock{Version: version, BlockHeader: header}, nil
+}
+
+func (km *remoteKeyManager) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
+	dataRoot, err := data.GetRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get root")
+	}
+	root, err := spectypes.ComputeSigningRoot(data, spectypes.ComputeSignatureDomain(km.network.Domain, sigType))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute signing root")
+	}
+
+	return km.sign(pk, root, &remoteSignRequest{
+		Type:        "SSV_MESSAGE",
+		SigningRoot: root,
+		SSVMessage: &remoteSSVMessage{
+			Domain:        "0x" + hex.EncodeToString(km.network.Domain[:]),
+			SignatureType: "0x" + hex.EncodeToString(sigType[:]),
+			Root:          dataRoot,
+		},
+	})
+}
+
+// sign sends the sign request to the remote signer, and verifies the returned signature.
+func (km *remoteKeyManager) sign(pk []byte, root phase0.Root, request *remoteSignRequest) (spectypes.Signature, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode sign request")
+	}
+
+	url := fmt.Sprintf("%s/api/v1/eth2/sign/0x%s", km.url, hex.EncodeToString(pk))
+	respBody, err := km.do(http.MethodPost, url, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer failed to sign")
+	}
+
+	sig, err := parseRemoteSignature(respBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never trust the remote signer to sign what it was asked to.
+	pubKey := &bls.PublicKey{}
+	if err := pubKey.Deserialize(pk); err != nil {
+		return nil, errors.Wrap(err, "could not deserialize public key")
+	}
+	blsSig := &bls.Sign{}
+	if err := blsSig.Deserialize(sig); err != nil {
+		return nil, errors.Wrap(err, "remote signer returned a malformed signature")
+	}
+	if !blsSig.VerifyByte(pubKey, root[:]) {
+		return nil, errors.New("remote signer returned an invalid signature")
+	}
+
+	return sig, nil
+}
+
+// parseRemoteSignature parses a sign response, which is either a JSON object or a plain hex signature.
+func parseRemoteSignature(body *remoteResponse) ([]byte, error) {
+	text := strings.TrimSpace(string(body.data))
+	if body.json {
+		var resp struct {
+			Signature string `json:"signature"`
+		}
+		if err := json.Unmarshal(body.data, &resp); err != nil {
+			return nil, errors.Wrap(err, "could not decode remote signer response")
+		}
+		text = resp.Signature
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode remote signature")
+	}
+	return sig, nil
+}
+
+type remoteResponse struct {
+	data []byte
+	json bool
+}
+
+func (km *remoteKeyManager) do(method, url string, body []byte) (*remoteResponse, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := km.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &remoteStatusError{code: resp.StatusCode, body: strings.TrimSpace(string(data))}
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return &remoteResponse{data: data, json: mediaType == "application/json"}, nil
+}
+
+// publicKeys returns the public keys of the keys held by the remote signer.
+func (km *remoteKeyManager) publicKeys() (map[string]struct{}, error) {
+	resp, err := km.do(http.MethodGet, km.url+"/api/v1/eth2/publicKeys", nil)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	if err := json.Unmarshal(resp.data, &keys); err != nil {
+		return nil, errors.Wrap(err, "could not decode public keys")
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[strings.TrimPrefix(strings.ToLower(key), "0x")] = struct{}{}
+	}
+	return set, nil
+}
+
+// AddShare only bumps the slashing protection of the share, since its key must be provisioned
+// to the remote signer separately. It warns if the remote signer doesn't hold the key yet,
+// and fails if the remote signer doesn't support SSV_MESSAGE requests.
+func (km *remoteKeyManager) AddShare(shareKey *bls.SecretKey) error {
+	pubKey := shareKey.GetPublicKey().Serialize()
+	if err := km.bumpSlashingProtection(pubKey); err != nil {
+		return errors.Wrap(err, "could not bump slashing protection")
+	}
+
+	keys, err := km.publicKeys()
+	if err != nil {
+		km.logger.Warn("could not list remote signer keys", zap.Error(err))
+		return nil
+	}
+	if _, ok := keys[hex.EncodeToString(pubKey)]; !ok {
+		km.logger.Warn("remote signer doesn't hold the share key", fields.PubKey(pubKey))
+		return nil
+	}
+	return km.checkSSVMessages(pubKey)
+}
+
+func (km *remoteKeyManager) bumpSlashingProtection(pubKey []byte) error {
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	return km.BumpSlashingProtection(pubKey)
+}
+
+// checkSSVMessages checks that the remote signer supports SSV_MESSAGE requests by signing a probe with the share's key,
+// until it succeeds once. Plain Web3Signer rejects them, and couldn't sign any SSV message.
+func (km *remoteKeyManager) checkSSVMessages(pubKey []byte) error {
+	if km.ssvMessagesChecked.Load() {
+		return nil
+	}
+	_, err := km.SignRoot(remoteProbe{}, spectypes.QBFTSignatureType, pubKey)
+	if err != nil {
+		var statusErr *remoteStatusError
+		if errors.As(err, &statusErr) && statusErr.code >= 400 && statusErr.code < 500 {
+			return fmt.Errorf("remote signer doesn't support SSV_MESSAGE sign requests: %w", err)
+		}
+		km.logger.Warn("could not check remote signer support of SSV messages", zap.Error(err))
+		return nil
+	}
+	km.ssvMessagesChecked.Store(true)
+	return nil
+}
+
+// RemoveShare removes the slashing protection data of the share. Its key isn't removed from the remote signer.
+func (km *remoteKeyManager) RemoveShare(pubKey string) error {
+	pkDecoded, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not hex decode share public key")
+	}
+
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	return km.remove(pkDecoded)
+}
+
+// ExportSlashingProtection exports the slashing protection data of all shares in the EIP-3076 interchange format.
+func (km *remoteKeyManager) ExportSlashingProtection() (*Interchange, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+
+	return ExportSlashingProtection(km.storage)
+}
+
+// ImportSlashingProtection merges EIP-3076 interchange data into the slashing protection data.
+// Signing is blocked during the import, so that concurrently signed data can't be lowered by it.
+func (km *remoteKeyManager) ImportSlashingProtection(interchange *Interchange) (*InterchangeImportResult, error) {
+	km.lock.Lock()
+	defer km.lock.Unlock()
+
+	return ImportSlashingProtection(km.storage, interchange)
+}
+
+// ListSignedAttestations returns the attestations signed by the share with a target epoch within the given range.
+func (km *remoteKeyManager) ListSignedAttestations(pubKey []byte, fromTarget, toTarget phase0.Epoch) ([]*SignedAttestation, error) {
+	return km.storage.ListSignedAttestations(pubKey, fromTarget, toTarget)
+}
+
+// ListSignedProposals returns the block proposals signed by the share within the given slot range.
+func (km *remoteKeyManager) ListSignedProposals(pubKey []byte, fromSlot, toSlot phase0.Slot) ([]*SignedProposal, error) {
+	return km.storage.ListSignedProposals(pubKey, fromSlot, toSlot)
+}
@@ -0,0 +1,344 @@
+package ekm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/utils"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// stubRemoteSigner is a Web3Signer-compatible signer for tests, which supports SSV_MESSAGE requests
+// unless web3SignerOnly is set.
+type stubRemoteSigner struct {
+	keys           map[string]*bls.SecretKey
+	web3SignerOnly bool
+
+	mu       sync.Mutex
+	requests []map[string]json.RawMessage
+	tamper   bool
+	hold     chan struct{} // If set, signatures are only returned once it's closed.
+}
+
+func (s *stubRemoteSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/v1/eth2/publicKeys" {
+		var keys []string
+		for pk := range s.keys {
+			keys = append(keys, "0x"+pk)
+		}
+		_ = json.NewEncoder(w).Encode(keys)
+		return
+	}
+
+	sk, ok := s.keys[strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/0x")]
+	if !ok {
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	}
+	var request map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var requestType string
+	if err := json.Unmarshal(request["type"], &requestType); err != nil || requestType == "" {
+		http.Error(w, "missing type", http.StatusBadRequest)
+		return
+	}
+	var root phase0.Root
+	if err := json.Unmarshal(request["signingRoot"], &root); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if requestType == "SSV_MESSAGE" {
+		if s.web3SignerOnly {
+			http.Error(w, "unknown type", http.StatusBadRequest)
+			return
+		}
+		if err := checkSSVMessageRoot(request["ssv_message"], root); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	tamper, hold := s.tamper, s.hold
+	s.mu.Unlock()
+	if tamper {
+		root[0]++
+	}
+	if hold != nil {
+		<-hold
+	}
+
+	sig := sk.SignByte(root[:]).Serialize()
+	if r.Header.Get("Accept") == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"signature": "0x" + hex.EncodeToString(sig)})
+		return
+	}
+	_, _ = w.Write([]byte("0x" + hex.EncodeToString(sig)))
+}
+
+// checkSSVMessageRoot checks that the signing root of an SSV_MESSAGE request is computed from its ssv_message.
+func checkSSVMessageRoot(data json.RawMessage, signingRoot phase0.Root) error {
+	var message struct {
+		Domain        string      `json:"domain"`
+		SignatureType string      `json:"signature_type"`
+		Root          phase0.Root `json:"root"`
+	}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	domain, err := hex.DecodeString(strings.TrimPrefix(message.Domain, "0x"))
+	if err != nil {
+		return err
+	}
+	sigType, err := hex.DecodeString(strings.TrimPrefix(message.SignatureType, "0x"))
+	if err != nil {
+		return err
+	}
+	if sha256.Sum256(append(append(message.Root[:], domain...), sigType...)) != signingRoot {
+		return fmt.Errorf("signing root mismatch")
+	}
+	return nil
+}
+
+func (s *stubRemoteSigner) lastRequest() map[string]json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return nil
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+type stubForkInfo struct{}
+
+func (stubForkInfo) ForkInfo(epoch phase0.Epoch) (*phase0.Fork, phase0.Root, error) {
+	return &phase0.Fork{CurrentVersion: phase0.Version{1}, PreviousVersion: phase0.Version{0}}, phase0.Root{2}, nil
+}
+
+func testRemoteKeyManager(t *testing.T) (*remoteKeyManager, *stubRemoteSigner, *bls.SecretKey) {
+	km, signer, sk := newTestRemoteKeyManager(t, &stubRemoteSigner{})
+	require.NoError(t, km.AddShare(sk))
+	return km, signer, sk
+}
+
+func newTestRemoteKeyManager(t *testing.T, signer *stubRemoteSigner) (*remoteKeyManager, *stubRemoteSigner, *bls.SecretKey) {
+	threshold.Init()
+	logger := logging.TestLogger(t)
+
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk1Str))
+	signer.keys = map[string]*bls.SecretKey{pk1Str: sk}
+	server := httptest.NewServer(signer)
+	t.Cleanup(server.Close)
+
+	network := networkconfig.NetworkConfig{
+		Beacon: utils.SetupMockBeaconNetwork(t, nil),
+		Domain: networkconfig.TestNetwork.Domain,
+	}
+	km, err := NewRemoteKeyManager(logger, db, network, server.URL+"/", stubForkInfo{})
+	require.NoError(t, err)
+
+	return km.(*remoteKeyManager), signer, sk
+}
+
+func TestRemoteKeyManager_SignAttestation(t *testing.T) {
+	km, signer, sk := testRemoteKeyManager(t)
+	pk := sk.GetPublicKey().Serialize()
+
+	highest, found, err := km.RetrieveHighestAttestation(pk)
+	require.NoError(t, err)
+	require.True(t, found)
+	base := highest.Target.Epoch
+
+	attestation := func(source, target phase0.Epoch) *phase0.AttestationData {
+		return &phase0.AttestationData{
+			Slot:   phase0.Slot(target * 32),
+			Source: &phase0.Checkpoint{Epoch: source},
+			Target: &phase0.Checkpoint{Epoch: target},
+		}
+	}
+
+	data := attestation(base+1, base+2)
+	sig, root, err := km.SignBeaconObject(data, phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.NoError(t, err)
+	expectedRoot, err := spectypes.ComputeETHSigningRoot(data, phase0.Domain{})
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, phase0.Root(root))
+	require.Equal(t, sk.SignByte(root[:]).Serialize(), []byte(sig))
+
+	request := signer.lastRequest()
+	require.JSONEq(t, `"ATTESTATION"`, string(request["type"]))
+	require.Contains(t, request, "attestation")
+	require.Contains(t, string(request["fork_info"]), `"current_version":"0x01000000"`)
+
+	highest, _, err = km.RetrieveHighestAttestation(pk)
+	require.NoError(t, err)
+	require.Equal(t, base+2, highest.Target.Epoch)
+	history, err := km.ListSignedAttestations(pk, 0, base+10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	// Slashable attestations never reach the remote signer.
+	requests := len(signer.requests)
+	_, _, err = km.SignBeaconObject(attestation(base, base+2), phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.ErrorContains(t, err, "slashable attestation")
+
+	// Lower the highest attestation, so that only the history protects against slashing.
+	require.NoError(t, km.storage.SaveHighestAttestation(pk, attestation(base-1, base)))
+	_, _, err = km.SignBeaconObject(attestation(base, base+3), phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.ErrorContains(t, err, "surrounds")
+	require.Len(t, signer.requests, requests)
+}
+
+func TestRemoteKeyManager_SignRoot(t *testing.T) {
+	km, signer, sk := testRemoteKeyManager(t)
+	pk := sk.GetPublicKey().Serialize()
+
+	msg := &specqbft.Message{
+		MsgType:    specqbft.CommitMsgType,
+		Height:     3,
+		Round:      2,
+		Identifier: []byte("identifier"),
+		Root:       [32]byte{1},
+	}
+	sig, err := km.SignRoot(msg, spectypes.QBFTSignatureType, pk)
+	require.NoError(t, err)
+
+	local := testKeyManager(t, nil)
+	expected, err := local.SignRoot(msg, spectypes.QBFTSignatureType, pk)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	request := signer.lastRequest()
+	require.JSONEq(t, `"SSV_MESSAGE"`, string(request["type"]))
+	require.Contains(t, request, "signingRoot")
+	require.Contains(t, string(request["ssv_message"]), `"signature_type":"0x01000000"`)
+}
+
+func TestRemoteKeyManager_Web3SignerOnly(t *testing.T) {
+	km, signer, sk := newTestRemoteKeyManager(t, &stubRemoteSigner{web3SignerOnly: true})
+	require.ErrorContains(t, km.AddShare(sk), "remote signer doesn't support SSV_MESSAGE sign requests")
+
+	// Beacon objects are still signed.
+	_, _, err := km.SignBeaconObject(spectypes.SSZUint64(1), phase0.Domain{}, sk.GetPublicKey().Serialize(), spectypes.DomainRandao)
+	require.NoError(t, err)
+	require.JSONEq(t, `"RANDAO_REVEAL"`, string(signer.lastRequest()["type"]))
+}
+
+func TestRemoteKeyManager_SignSyncCommittee(t *testing.T) {
+	km, signer, sk := testRemoteKeyManager(t)
+	pk := sk.GetPublicKey().Serialize()
+	blockRoot := spectypes.SSZBytes(bytes.Repeat([]byte{1}, 32))
+
+	_, _, err := km.SignBeaconObject(blockRoot, phase0.Domain{}, pk, spectypes.DomainSyncCommittee)
+	require.ErrorContains(t, err, "must be signed with their slot")
+
+	_, _, err = km.SignBeaconObjectAtSlot(blockRoot, 12345, phase0.Domain{}, pk, spectypes.DomainSyncCommittee)
+	require.NoError(t, err)
+	request := signer.lastRequest()
+	require.JSONEq(t, `"SYNC_COMMITTEE_MESSAGE"`, string(request["type"]))
+	require.Contains(t, string(request["sync_committee_message"]), `"slot":"12345"`)
+}
+
+func TestRemoteKeyManager_InvalidSignature(t *testing.T) {
+	km, signer, sk := testRemoteKeyManager(t)
+	pk := sk.GetPublicKey().Serialize()
+
+	signer.tamper = true
+	_, _, err := km.SignBeaconObject(spectypes.SSZUint64(1), phase0.Domain{}, pk, spectypes.DomainRandao)
+	require.ErrorContains(t, err, "invalid signature")
+
+	other := &bls.SecretKey{}
+	require.NoError(t, other.SetHexString(sk2Str))
+	_, err = km.SignRoot(&specqbft.Message{Identifier: []byte{1}}, spectypes.QBFTSignatureType, other.GetPublicKey().Serialize())
+	require.ErrorContains(t, err, "404")
+}
+
+func TestRemoteKeyManager_RemoveShare(t *testing.T) {
+	km, _, sk := testRemoteKeyManager(t)
+	pk := sk.GetPublicKey().Serialize()
+
+	require.NoError(t, km.RemoveShare(sk.GetPublicKey().SerializeToHexStr()))
+	_, found, err := km.RetrieveHighestAttestation(pk)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestRemoteKeyManager_RemoveShareWhileSigning(t *testing.T) {
+	km, signer, sk := testRemoteKeyManager(t)
+	pk := sk.GetPublicKey().Serialize()
+
+	highest, _, err := km.RetrieveHighestAttestation(pk)
+	require.NoError(t, err)
+	base := highest.Target.Epoch
+
+	hold := make(chan struct{})
+	var releaseOnce sync.Once
+	release := func() { releaseOnce.Do(func() { close(hold) }) }
+	t.Cleanup(release) // Before the server is closed, which waits for the held request.
+	signer.mu.Lock()
+	signer.hold = hold
+	signer.mu.Unlock()
+
+	signed := make(chan error)
+	go func() {
+		_, _, err := km.SignBeaconObject(&phase0.AttestationData{
+			Slot:   phase0.Slot((base + 2) * 32),
+			Source: &phase0.Checkpoint{Epoch: base + 1},
+			Target: &phase0.Checkpoint{Epoch: base + 2},
+		}, phase0.Domain{}, pk, spectypes.DomainAttester)
+		signed <- err
+	}()
+	require.Eventually(t, func() bool {
+		request := signer.lastRequest()
+		return request != nil && string(request["type"]) == `"ATTESTATION"`
+	}, time.Second, time.Millisecond)
+
+	// The share is only removed once the attestation is signed and recorded.
+	removed := make(chan error)
+	go func() {
+		removed <- km.RemoveShare(sk.GetPublicKey().SerializeToHexStr())
+	}()
+	select {
+	case <-removed:
+		t.Fatal("share was removed while signing")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+	require.NoError(t, <-signed)
+	require.NoError(t, <-removed)
+
+	_, found, err := km.RetrieveHighestAttestation(pk)
+	require.NoError(t, err)
+	require.False(t, found)
+	history, err := km.ListSignedAttestations(pk, 0, base+10)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
@@ -2,7 +2,11 @@ package ekm
 
 import (
 	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -390,6 +394,41 @@ func TestSlashing_Attestation(t *testing.T) {
 	signAttestation(secretKeys[2], phase0.Root{7}, createAttestationData(6, 6), true, "HighestAttestationVote")
 }
 
+func TestSlashing_AttestationOnNetworkConfigFile(t *testing.T) {
+	// A private chain with its own genesis and timing, which reuses the name of a spec network.
+	path := filepath.Join(t.TempDir(), "network.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(`
+Name: devnet
+BeaconNetwork: holesky
+GenesisForkVersion: "0x10000038"
+MinGenesisTime: %d
+SecondsPerSlot: 2
+SlotsPerEpoch: 8
+DomainType: "0x000005ff"
+RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"
+`, time.Now().Add(-24*time.Hour).Unix())), 0600))
+
+	network, err := networkconfig.LoadNetworkConfig(path)
+	require.NoError(t, err)
+
+	km := testKeyManager(t, &network)
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	// AddShare bumps the highest attestation to the network's current epoch.
+	epoch := network.Beacon.EstimatedCurrentEpoch() + minSPAttestationEpochGap + 1
+	attestationData := &phase0.AttestationData{
+		Slot:            network.Beacon.FirstSlotAtEpoch(epoch),
+		BeaconBlockRoot: [32]byte{1, 2, 3},
+		Source:          &phase0.Checkpoint{Epoch: epoch - 1},
+		Target:          &phase0.Checkpoint{Epoch: epoch},
+	}
+	sig, root, err := km.(*ethKeyManagerSigner).SignBeaconObject(attestationData, phase0.Domain{}, sk1.GetPublicKey().Serialize(), spectypes.DomainAttester)
+	require.NoError(t, err)
+	require.NotZero(t, sig)
+	require.NotZero(t, root)
+}
+
 func TestSignRoot(t *testing.T) {
 	require.NoError(t, bls.Init(bls.BLS12_381))
 
@@ -34,6 +34,8 @@ const (
 	accountsPath          = "accounts_%s"
 	highestAttPrefix      = prefix + "highest_att-"
 	highestProposalPrefix = prefix + "highest_prop-"
+	attHistoryPrefix      = prefix + "att_history-"
+	proposalHistoryPrefix = prefix + "prop_history-"
 )
 
 // Storage represents the interface for ssv node storage
@@ -44,6 +46,14 @@ type Storage interface {
 
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
+	ListSlashingProtectionPubKeys() ([][]byte, error)
+
+	SaveSignedAttestation(pubKey []byte, attestation *SignedAttestation) error
+	ListSignedAttestations(pubKey []byte, fromTarget, toTarget phase0.Epoch) ([]*SignedAttestation, error)
+	SaveSignedProposal(pubKey []byte, proposal *SignedProposal) error
+	ListSignedProposals(pubKey []byte, fromSlot, toSlot phase0.Slot) ([]*SignedProposal, error)
+	RemoveSignedHistory(pubKey []byte) error
+	PruneSignedHistory(minTarget phase0.Epoch, minSlot phase0.Slot) (int, error)
 	SetEncryptionKey(newKey string) error
 	ListAccountsTxn(r basedb.Reader) ([]core.ValidatorAccount, error)
 	SaveAccountTxn(rw basedb.ReadWriter, account core.ValidatorAccount) error
@@ -349,6 +359,29 @@ func (s *storage) RemoveHighestProposal(pubKey []byte) error {
 	return s.db.Delete(s.objPrefix(highestProposalPrefix), pubKey)
 }
 
+// ListSlashingProtectionPubKeys returns the public keys which have a highest attestation or proposal.
+func (s *storage) ListSlashingProtectionPubKeys() ([][]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	seen := make(map[string]struct{})
+	var pubKeys [][]byte
+	for _, p := range []string{highestAttPrefix, highestProposalPrefix} {
+		err := s.db.GetAll(s.objPrefix(p), func(i int, obj basedb.Obj) error {
+			if _, ok := seen[string(obj.Key)]; ok {
+				return nil
+			}
+			seen[string(obj.Key)] = struct{}{}
+			pubKeys = append(pubKeys, append([]byte(nil), obj.Key...))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pubKeys, nil
+}
+
 func (s *storage) decryptData(objectValue []byte) ([]byte, error) {
 	if len(s.encryptionKey) == 0 {
 		return objectValue, nil
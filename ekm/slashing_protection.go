@@ -0,0 +1,277 @@
+package ekm
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// slashingProtection guards signing with the highest attestation and proposal of each share,
+// and with its history of signed attestations and proposals.
+// It's shared by the local and the remote key managers.
+type slashingProtection struct {
+	storage   Storage
+	protector core.SlashingProtector
+	logger    *zap.Logger
+
+	// historyLocks holds a mutex per share, so that checking and recording
+	// the slashing protection history of a share is atomic with signing.
+	historyLocks     sync.Map
+	historyRetention phase0.Epoch
+	lastPrunedEpoch  atomic.Uint64
+}
+
+func newSlashingProtection(logger *zap.Logger, storage Storage, protector core.SlashingProtector, historyRetention phase0.Epoch) *slashingProtection {
+	return &slashingProtection{
+		storage:          storage,
+		protector:        protector,
+		logger:           logger,
+		historyRetention: historyRetention,
+	}
+}
+
+func (sp *slashingProtection) IsAttestationSlashable(pk []byte, data *phase0.AttestationData) error {
+	if val, err := sp.protector.IsSlashableAttestation(pk, data); err != nil || val != nil {
+		if err != nil {
+			return err
+		}
+		return errors.Errorf("slashable attestation (%s), not signing", val.Status)
+	}
+	return nil
+}
+
+func (sp *slashingProtection) IsBeaconBlockSlashable(pk []byte, slot phase0.Slot) error {
+	status, err := sp.protector.IsSlashableProposal(pk, slot)
+	if err != nil {
+		return err
+	}
+	if status.Status != core.ValidProposal {
+		return errors.Errorf("slashable proposal (%s), not signing", status.Status)
+	}
+
+	return nil
+}
+
+// BumpSlashingProtection updates the slashing protection data for a given public key.
+func (sp *slashingProtection) BumpSlashingProtection(pubKey []byte) error {
+	currentSlot := sp.storage.BeaconNetwork().EstimatedCurrentSlot()
+
+	// Update highest attestation data for slashing protection.
+	if err := sp.updateHighestAttestation(pubKey, currentSlot); err != nil {
+		return err
+	}
+
+	// Update highest proposal data for slashing protection.
+	if err := sp.updateHighestProposal(pubKey, currentSlot); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// remove removes the slashing protection data of the share.
+func (sp *slashingProtection) remove(pubKey []byte) error {
+	if err := sp.storage.RemoveHighestAttestation(pubKey); err != nil {
+		return errors.Wrap(err, "could not remove highest attestation")
+	}
+	if err := sp.storage.RemoveHighestProposal(pubKey); err != nil {
+		return errors.Wrap(err, "could not remove highest proposal")
+	}
+	if err := sp.storage.RemoveSignedHistory(pubKey); err != nil {
+		return errors.Wrap(err, "could not remove slashing protection history")
+	}
+	return nil
+}
+
+func (sp *slashingProtection) historyLock(pk []byte) *sync.Mutex {
+	lock, _ := sp.historyLocks.LoadOrStore(string(pk), &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// checkSlashingHistory checks the attestation or proposal against the share's slashing protection history,
+// and returns a function which records it in the history once it's signed.
+func (sp *slashingProtection) checkSlashingHistory(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (func() error, error) {
+	signingRoot, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute signing root")
+	}
+
+	switch domainType {
+	case spectypes.DomainAttester:
+		data, ok := obj.(*phase0.AttestationData)
+		if !ok {
+			return nil, errors.New("could not cast obj to AttestationData")
+		}
+		// Leave attestations which are slashable against the highest attestation
+		// to the signer, which fails with its own error.
+		if err := sp.IsAttestationSlashable(pk, data); err != nil {
+			return nil, nil
+		}
+
+		// Only attestations with a target between the source and the target can conflict:
+		// ones with an earlier target can neither be surrounded nor surround it, and ones
+		// with a later target would be above the highest attestation, which is checked first.
+		history, err := sp.storage.ListSignedAttestations(pk, data.Source.Epoch, data.Target.Epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list signed attestations")
+		}
+		if err := checkAttestationHistory(history, data, signingRoot); err != nil {
+			return nil, err
+		}
+		return func() error {
+			err := sp.storage.SaveSignedAttestation(pk, &SignedAttestation{
+				Slot:        data.Slot,
+				SourceEpoch: data.Source.Epoch,
+				TargetEpoch: data.Target.Epoch,
+				SigningRoot: signingRoot,
+				SignedAt:    time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			sp.maybePruneHistory(data.Target.Epoch)
+			return nil
+		}, nil
+
+	case spectypes.DomainProposer:
+		slot, err := blockSlot(obj)
+		if err != nil {
+			return nil, err
+		}
+		if err := sp.IsBeaconBlockSlashable(pk, slot); err != nil {
+			return nil, nil
+		}
+
+		history, err := sp.storage.ListSignedProposals(pk, slot, slot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list signed proposals")
+		}
+		if err := checkProposalHistory(history, slot, signingRoot); err != nil {
+			return nil, err
+		}
+		return func() error {
+			return sp.storage.SaveSignedProposal(pk, &SignedProposal{
+				Slot:        slot,
+				SigningRoot: signingRoot,
+				SignedAt:    time.Now(),
+			})
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// maybePruneHistory prunes the slashing protection history in the background,
+// at most once every historyPruneInterval epochs.
+func (sp *slashingProtection) maybePruneHistory(epoch phase0.Epoch) {
+	if sp.historyRetention == 0 || epoch <= sp.historyRetention {
+		return
+	}
+	last := sp.lastPrunedEpoch.Load()
+	if uint64(epoch) < last+uint64(historyPruneInterval) || !sp.lastPrunedEpoch.CompareAndSwap(last, uint64(epoch)) {
+		return
+	}
+
+	go func() {
+		minEpoch := epoch - sp.historyRetention
+		minSlot := sp.storage.BeaconNetwork().FirstSlotAtEpoch(minEpoch)
+		start := time.Now()
+		pruned, err := sp.storage.PruneSignedHistory(minEpoch, minSlot)
+		if err != nil {
+			sp.logger.Error("could not prune slashing protection history", zap.Error(err))
+			return
+		}
+		sp.logger.Debug("pruned slashing protection history",
+			zap.Uint64("min_epoch", uint64(minEpoch)),
+			zap.Int("pruned", pruned),
+			zap.Duration("took", time.Since(start)))
+	}()
+}
+
+// updateHighestAttestation updates the highest attestation data for slashing protection.
+func (sp *slashingProtection) updateHighestAttestation(pubKey []byte, slot phase0.Slot) error {
+	// Retrieve the highest attestation data stored for the given public key.
+	retrievedHighAtt, found, err := sp.storage.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return fmt.Errorf("could not retrieve highest attestation: %w", err)
+	}
+
+	currentEpoch := sp.storage.BeaconNetwork().EstimatedEpochAtSlot(slot)
+	minimalSP := computeMinimalAttestationSP(currentEpoch)
+
+	// Check if the retrieved highest attestation data is valid and not outdated.
+	if found && retrievedHighAtt != nil {
+		if retrievedHighAtt.Source.Epoch >= minimalSP.Source.Epoch || retrievedHighAtt.Target.Epoch >= minimalSP.Target.Epoch {
+			return nil
+		}
+	}
+
+	// At this point, either the retrieved attestation data was not found, or it was outdated.
+	// In either case, we update it to the minimal slashing protection data.
+	if err := sp.storage.SaveHighestAttestation(pubKey, minimalSP); err != nil {
+		return fmt.Errorf("could not save highest attestation: %w", err)
+	}
+
+	return nil
+}
+
+// updateHighestProposal updates the highest proposal slot for slashing protection.
+func (sp *slashingProtection) updateHighestProposal(pubKey []byte, slot phase0.Slot) error {
+	// Retrieve the highest proposal slot stored for the given public key.
+	retrievedHighProp, found, err := sp.storage.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return fmt.Errorf("could not retrieve highest proposal: %w", err)
+	}
+
+	minimalSPSlot := computeMinimalProposerSP(slot)
+
+	// Check if the retrieved highest proposal slot is valid and not outdated.
+	if found && retrievedHighProp != 0 {
+		if retrievedHighProp >= minimalSPSlot {
+			return nil
+		}
+	}
+
+	// At this point, either the retrieved proposal slot was not found, or it was outdated.
+	// In either case, we update it to the minimal slashing protection slot.
+	if err := sp.storage.SaveHighestProposal(pubKey, minimalSPSlot); err != nil {
+		return fmt.Errorf("could not save highest proposal: %w", err)
+	}
+
+	return nil
+}
+
+// computeMinimalAttestationSP calculates the minimal safe attestation data for slashing protection.
+// It takes the current epoch as an argument and returns an AttestationData object with the minimal safe source and target epochs.
+func computeMinimalAttestationSP(epoch phase0.Epoch) *phase0.AttestationData {
+	// Calculate the highest safe target epoch based on the current epoch and a predefined minimum distance.
+	highestTarget := epoch + minSPAttestationEpochGap
+	// The highest safe source epoch is one less than the highest target epoch.
+	highestSource := highestTarget - 1
+
+	// Return a new AttestationData object with the calculated source and target epochs.
+	return &phase0.AttestationData{
+		Source: &phase0.Checkpoint{
+			Epoch: highestSource,
+		},
+		Target: &phase0.Checkpoint{
+			Epoch: highestTarget,
+		},
+	}
+}
+
+// computeMinimalProposerSP calculates the minimal safe slot for a block proposal to avoid slashing.
+// It takes the current slot as an argument and returns the minimal safe slot.
+func computeMinimalProposerSP(slot phase0.Slot) phase0.Slot {
+	// Calculate the highest safe proposal slot based on the current slot and a predefined minimum distance.
+	return slot + minSPProposalSlotGap
+}
@@ -0,0 +1,267 @@
+package ekm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// SignedAttestation is an attestation signed by a share, as recorded in the slashing protection history.
+type SignedAttestation struct {
+	Slot        phase0.Slot  `json:"slot"`
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+	SigningRoot phase0.Root  `json:"signing_root"`
+	// SignedAt is zero for imported attestations.
+	SignedAt time.Time `json:"signed_at"`
+}
+
+// SignedProposal is a block proposal signed by a share, as recorded in the slashing protection history.
+type SignedProposal struct {
+	Slot        phase0.Slot `json:"slot"`
+	SigningRoot phase0.Root `json:"signing_root"`
+	// SignedAt is zero for imported proposals.
+	SignedAt time.Time `json:"signed_at"`
+}
+
+const (
+	signedAttestationSize = 8 + 8 + 8 + 32 + 8
+	signedProposalSize    = 8 + 32 + 8
+)
+
+func (a *SignedAttestation) encode() []byte {
+	b := make([]byte, 0, signedAttestationSize)
+	b = binary.BigEndian.AppendUint64(b, uint64(a.Slot))
+	b = binary.BigEndian.AppendUint64(b, uint64(a.SourceEpoch))
+	b = binary.BigEndian.AppendUint64(b, uint64(a.TargetEpoch))
+	b = append(b, a.SigningRoot[:]...)
+	return binary.BigEndian.AppendUint64(b, encodeTime(a.SignedAt))
+}
+
+func (a *SignedAttestation) decode(b []byte) error {
+	if len(b) != signedAttestationSize {
+		return fmt.Errorf("invalid signed attestation size %d", len(b))
+	}
+	a.Slot = phase0.Slot(binary.BigEndian.Uint64(b[0:8]))
+	a.SourceEpoch = phase0.Epoch(binary.BigEndian.Uint64(b[8:16]))
+	a.TargetEpoch = phase0.Epoch(binary.BigEndian.Uint64(b[16:24]))
+	copy(a.SigningRoot[:], b[24:56])
+	a.SignedAt = decodeTime(binary.BigEndian.Uint64(b[56:64]))
+	return nil
+}
+
+func (p *SignedProposal) encode() []byte {
+	b := make([]byte, 0, signedProposalSize)
+	b = binary.BigEndian.AppendUint64(b, uint64(p.Slot))
+	b = append(b, p.SigningRoot[:]...)
+	return binary.BigEndian.AppendUint64(b, encodeTime(p.SignedAt))
+}
+
+func (p *SignedProposal) decode(b []byte) error {
+	if len(b) != signedProposalSize {
+		return fmt.Errorf("invalid signed proposal size %d", len(b))
+	}
+	p.Slot = phase0.Slot(binary.BigEndian.Uint64(b[0:8]))
+	copy(p.SigningRoot[:], b[8:40])
+	p.SignedAt = decodeTime(binary.BigEndian.Uint64(b[40:48]))
+	return nil
+}
+
+func encodeTime(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixMilli())
+}
+
+func decodeTime(v uint64) time.Time {
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(v)).UTC()
+}
+
+// historyLookupLimit is the widest range of epochs or slots of a share's history which is looked up by key,
+// rather than by scanning the whole history of the share.
+const historyLookupLimit = 1024
+
+// historyKey returns the key of a history record, which sorts the records of a share by epoch or slot.
+func historyKey(pubKey []byte, n uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte(nil), pubKey...), n)
+}
+
+// iterateHistory calls handler with the history records of the share within the given range (inclusive), in order.
+func (s *storage) iterateHistory(prefix string, pubKey []byte, from, to uint64, handler func(value []byte) error) error {
+	if from > to {
+		return nil
+	}
+	if to-from < historyLookupLimit {
+		txn := s.db.BeginRead()
+		defer txn.Discard()
+		for n := from; ; n++ {
+			obj, found, err := txn.Get(s.objPrefix(prefix), historyKey(pubKey, n))
+			if err != nil {
+				return err
+			}
+			if found {
+				if err := handler(obj.Value); err != nil {
+					return err
+				}
+			}
+			if n == to {
+				return nil
+			}
+		}
+	}
+	return s.db.GetAll(append(s.objPrefix(prefix), pubKey...), func(i int, obj basedb.Obj) error {
+		if len(obj.Key) < 8 {
+			return nil
+		}
+		if n := binary.BigEndian.Uint64(obj.Key[len(obj.Key)-8:]); n < from || n > to {
+			return nil
+		}
+		return handler(obj.Value)
+	})
+}
+
+// SaveSignedAttestation records an attestation signed by the share, replacing any with the same target epoch.
+func (s *storage) SaveSignedAttestation(pubKey []byte, attestation *SignedAttestation) error {
+	if pubKey == nil {
+		return errors.New("pubKey must not be nil")
+	}
+	return s.db.Set(s.objPrefix(attHistoryPrefix), historyKey(pubKey, uint64(attestation.TargetEpoch)), attestation.encode())
+}
+
+// ListSignedAttestations returns the attestations signed by the share with a target epoch
+// within the given range (inclusive), ordered by target epoch.
+func (s *storage) ListSignedAttestations(pubKey []byte, fromTarget, toTarget phase0.Epoch) ([]*SignedAttestation, error) {
+	var attestations []*SignedAttestation
+	err := s.iterateHistory(attHistoryPrefix, pubKey, uint64(fromTarget), uint64(toTarget), func(value []byte) error {
+		a := &SignedAttestation{}
+		if err := a.decode(value); err != nil {
+			return err
+		}
+		attestations = append(attestations, a)
+		return nil
+	})
+	return attestations, err
+}
+
+// SaveSignedProposal records a block proposal signed by the share, replacing any with the same slot.
+func (s *storage) SaveSignedProposal(pubKey []byte, proposal *SignedProposal) error {
+	if pubKey == nil {
+		return errors.New("pubKey must not be nil")
+	}
+	return s.db.Set(s.objPrefix(proposalHistoryPrefix), historyKey(pubKey, uint64(proposal.Slot)), proposal.encode())
+}
+
+// ListSignedProposals returns the block proposals signed by the share within the given
+// slot range (inclusive), ordered by slot.
+func (s *storage) ListSignedProposals(pubKey []byte, fromSlot, toSlot phase0.Slot) ([]*SignedProposal, error) {
+	var proposals []*SignedProposal
+	err := s.iterateHistory(proposalHistoryPrefix, pubKey, uint64(fromSlot), uint64(toSlot), func(value []byte) error {
+		p := &SignedProposal{}
+		if err := p.decode(value); err != nil {
+			return err
+		}
+		proposals = append(proposals, p)
+		return nil
+	})
+	return proposals, err
+}
+
+// RemoveSignedHistory removes the signed attestations and proposals of the share.
+func (s *storage) RemoveSignedHistory(pubKey []byte) error {
+	if _, err := s.db.DeletePrefix(append(s.objPrefix(attHistoryPrefix), pubKey...)); err != nil {
+		return err
+	}
+	_, err := s.db.DeletePrefix(append(s.objPrefix(proposalHistoryPrefix), pubKey...))
+	return err
+}
+
+// PruneSignedHistory removes the signed attestations with a target epoch before minTarget
+// and the signed proposals before minSlot, of all shares. It returns the number of removed records.
+func (s *storage) PruneSignedHistory(minTarget phase0.Epoch, minSlot phase0.Slot) (int, error) {
+	var pruned int
+	for _, p := range []struct {
+		prefix []byte
+		min    uint64
+	}{
+		{s.objPrefix(attHistoryPrefix), uint64(minTarget)},
+		{s.objPrefix(proposalHistoryPrefix), uint64(minSlot)},
+	} {
+		var keys [][]byte
+		err := s.db.GetAll(p.prefix, func(i int, obj basedb.Obj) error {
+			if len(obj.Key) < 8 {
+				return nil
+			}
+			if binary.BigEndian.Uint64(obj.Key[len(obj.Key)-8:]) < p.min {
+				keys = append(keys, obj.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return pruned, err
+		}
+		for _, key := range keys {
+			if err := s.db.Delete(p.prefix, key); err != nil {
+				return pruned, err
+			}
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+// checkAttestationHistory returns an error if signing the given attestation would be slashable
+// against the share's history: a double vote, or a vote surrounding or surrounded by a previous one.
+func checkAttestationHistory(history []*SignedAttestation, data *phase0.AttestationData, signingRoot phase0.Root) error {
+	source, target := data.Source.Epoch, data.Target.Epoch
+	for _, a := range history {
+		switch {
+		case a.TargetEpoch == target && a.SigningRoot != signingRoot:
+			return fmt.Errorf("slashable attestation (double vote with target epoch %d), not signing", target)
+		case source < a.SourceEpoch && target > a.TargetEpoch:
+			return fmt.Errorf("slashable attestation (surrounds vote %d->%d), not signing", a.SourceEpoch, a.TargetEpoch)
+		case source > a.SourceEpoch && target < a.TargetEpoch:
+			return fmt.Errorf("slashable attestation (surrounded by vote %d->%d), not signing", a.SourceEpoch, a.TargetEpoch)
+		}
+	}
+	return nil
+}
+
+// checkProposalHistory returns an error if signing a block at the given slot would be
+// a double proposal against the share's history.
+func checkProposalHistory(history []*SignedProposal, slot phase0.Slot, signingRoot phase0.Root) error {
+	for _, p := range history {
+		if p.Slot == slot && p.SigningRoot != signingRoot {
+			return fmt.Errorf("slashable proposal (double proposal at slot %d), not signing", slot)
+		}
+	}
+	return nil
+}
+
+// blockSlot returns the slot of the given (blinded) beacon block.
+func blockSlot(obj interface{}) (phase0.Slot, error) {
+	switch v := obj.(type) {
+	case *capella.BeaconBlock:
+		return v.Slot, nil
+	case *deneb.BeaconBlock:
+		return v.Slot, nil
+	case *apiv1capella.BlindedBeaconBlock:
+		return v.Slot, nil
+	case *apiv1deneb.BlindedBeaconBlock:
+		return v.Slot, nil
+	default:
+		return 0, fmt.Errorf("obj type is unknown: %T", obj)
+	}
+}
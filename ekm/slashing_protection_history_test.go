@@ -0,0 +1,148 @@
+package ekm
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedHistoryStorage(t *testing.T) {
+	s, done := newStorageForTest(t)
+	defer done()
+
+	pk1 := _byteArray(pk1Str)
+	pk2 := _byteArray(pk2Str)
+	now := time.Now().Truncate(time.Millisecond).UTC()
+
+	for _, target := range []phase0.Epoch{5, 1, 3} {
+		require.NoError(t, s.SaveSignedAttestation(pk1, &SignedAttestation{
+			Slot:        phase0.Slot(target * 32),
+			SourceEpoch: target - 1,
+			TargetEpoch: target,
+			SigningRoot: phase0.Root{byte(target)},
+			SignedAt:    now,
+		}))
+	}
+	require.NoError(t, s.SaveSignedAttestation(pk2, &SignedAttestation{SourceEpoch: 1, TargetEpoch: 2}))
+	require.NoError(t, s.SaveSignedProposal(pk1, &SignedProposal{Slot: 100, SigningRoot: phase0.Root{1}, SignedAt: now}))
+
+	attestations, err := s.ListSignedAttestations(pk1, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, attestations, 3)
+	require.Equal(t, phase0.Epoch(1), attestations[0].TargetEpoch)
+	require.Equal(t, phase0.Epoch(5), attestations[2].TargetEpoch)
+	require.Equal(t, phase0.Root{5}, attestations[2].SigningRoot)
+	require.Equal(t, now, attestations[2].SignedAt)
+
+	attestations, err = s.ListSignedAttestations(pk1, 2, 4)
+	require.NoError(t, err)
+	require.Len(t, attestations, 1)
+	require.Equal(t, phase0.Epoch(3), attestations[0].TargetEpoch)
+
+	// Narrow ranges are looked up by key, and are ordered the same as scans.
+	attestations, err = s.ListSignedAttestations(pk1, 1, 5)
+	require.NoError(t, err)
+	require.Len(t, attestations, 3)
+	require.Equal(t, phase0.Epoch(1), attestations[0].TargetEpoch)
+	require.Equal(t, phase0.Epoch(5), attestations[2].TargetEpoch)
+	attestations, err = s.ListSignedAttestations(pk1, 5, 4)
+	require.NoError(t, err)
+	require.Empty(t, attestations)
+
+	proposals, err := s.ListSignedProposals(pk1, 100, 100)
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+
+	proposals, err = s.ListSignedProposals(pk1, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	require.Equal(t, phase0.Slot(100), proposals[0].Slot)
+
+	pruned, err := s.PruneSignedHistory(3, 101)
+	require.NoError(t, err)
+	require.Equal(t, 3, pruned)
+	attestations, err = s.ListSignedAttestations(pk1, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, attestations, 2)
+
+	require.NoError(t, s.RemoveSignedHistory(pk1))
+	attestations, err = s.ListSignedAttestations(pk1, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Empty(t, attestations)
+	attestations, err = s.ListSignedAttestations(pk2, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Empty(t, attestations, "pk2's attestation at target 2 was pruned")
+}
+
+func TestCheckAttestationHistory(t *testing.T) {
+	history := []*SignedAttestation{
+		{SourceEpoch: 10, TargetEpoch: 12, SigningRoot: phase0.Root{1}},
+	}
+	attestation := func(source, target phase0.Epoch) *phase0.AttestationData {
+		return &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: source},
+			Target: &phase0.Checkpoint{Epoch: target},
+		}
+	}
+
+	require.NoError(t, checkAttestationHistory(history, attestation(10, 12), phase0.Root{1}))
+	require.ErrorContains(t, checkAttestationHistory(history, attestation(10, 12), phase0.Root{2}), "double vote")
+	require.ErrorContains(t, checkAttestationHistory(history, attestation(9, 13), phase0.Root{2}), "surrounds")
+	require.ErrorContains(t, checkAttestationHistory(history, attestation(11, 11), phase0.Root{2}), "surrounded")
+	require.NoError(t, checkAttestationHistory(history, attestation(12, 13), phase0.Root{2}))
+	require.NoError(t, checkAttestationHistory(history, attestation(9, 11), phase0.Root{2}))
+
+	proposals := []*SignedProposal{{Slot: 5, SigningRoot: phase0.Root{1}}}
+	require.NoError(t, checkProposalHistory(proposals, 5, phase0.Root{1}))
+	require.ErrorContains(t, checkProposalHistory(proposals, 5, phase0.Root{2}), "double proposal")
+	require.NoError(t, checkProposalHistory(proposals, 6, phase0.Root{2}))
+}
+
+func TestSignWithHistory(t *testing.T) {
+	km := testKeyManager(t, nil).(*ethKeyManagerSigner)
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk1Str))
+	pk := sk.GetPublicKey().Serialize()
+
+	sign := func(source, target phase0.Epoch) error {
+		_, _, err := km.SignBeaconObject(&phase0.AttestationData{
+			Slot:   phase0.Slot(target * 32),
+			Source: &phase0.Checkpoint{Epoch: source},
+			Target: &phase0.Checkpoint{Epoch: target},
+		}, phase0.Domain{}, pk, spectypes.DomainAttester)
+		return err
+	}
+
+	highest, _, err := km.RetrieveHighestAttestation(pk)
+	require.NoError(t, err)
+	base := highest.Target.Epoch
+
+	require.NoError(t, sign(base+3, base+4))
+	attestations, err := km.ListSignedAttestations(pk, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, attestations, 1)
+	require.Equal(t, base+4, attestations[0].TargetEpoch)
+	require.False(t, attestations[0].SignedAt.IsZero())
+
+	// Lower the highest attestation, as if the watermark was lost,
+	// so that only the history protects against slashing.
+	lowered := &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: base},
+		Target: &phase0.Checkpoint{Epoch: base + 1},
+	}
+	require.NoError(t, km.storage.SaveHighestAttestation(pk, lowered))
+	require.ErrorContains(t, sign(base+2, base+5), "surrounds")
+
+	require.NoError(t, km.storage.SaveHighestAttestation(pk, lowered))
+	require.ErrorContains(t, sign(base+2, base+4), "double vote")
+
+	attestations, err = km.ListSignedAttestations(pk, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, attestations, 1)
+}
@@ -0,0 +1,345 @@
+package ekm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+)
+
+// InterchangeFormatVersion is the supported version of the EIP-3076 slashing protection interchange format.
+const InterchangeFormatVersion = "5"
+
+// genesisValidatorsRoots are the genesis validators roots of the known beacon networks,
+// which interchange files are checked against.
+var genesisValidatorsRoots = map[spectypes.BeaconNetwork]phase0.Root{
+	spectypes.MainNetwork:    mustRoot("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"),
+	spectypes.HoleskyNetwork: mustRoot("0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1"),
+	spectypes.PraterNetwork:  mustRoot("0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"),
+}
+
+// ErrInvalidInterchange is returned when importing an interchange which fails validation.
+var ErrInvalidInterchange = errors.New("invalid interchange")
+
+// Interchange is the EIP-3076 slashing protection interchange format.
+// See https://eips.ethereum.org/EIPS/eip-3076.
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeData   `json:"data"`
+}
+
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    phase0.Root `json:"genesis_validators_root"`
+}
+
+type InterchangeData struct {
+	PubKey             phase0.BLSPubKey         `json:"pubkey"`
+	SignedBlocks       []InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []InterchangeAttestation `json:"signed_attestations"`
+}
+
+type InterchangeBlock struct {
+	Slot        phase0.Slot  `json:"slot"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+type InterchangeAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+// InterchangeImportResult counts the shares whose slashing protection was raised by an import.
+type InterchangeImportResult struct {
+	Validators   int `json:"validators"`
+	Attestations int `json:"attestations"`
+	Proposals    int `json:"proposals"`
+}
+
+// ExportSlashingProtection exports the signed attestations and proposals in the history of every share
+// with slashing protection data, along with its highest attestation and proposal.
+func ExportSlashingProtection(s Storage) (*Interchange, error) {
+	pubKeys, err := s.ListSlashingProtectionPubKeys()
+	if err != nil {
+		return nil, fmt.Errorf("could not list slashing protection public keys: %w", err)
+	}
+
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    expectedGenesisValidatorsRoot(s),
+		},
+		Data: make([]InterchangeData, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		data := InterchangeData{
+			SignedBlocks:       []InterchangeBlock{},
+			SignedAttestations: []InterchangeAttestation{},
+		}
+		copy(data.PubKey[:], pubKey)
+
+		attestations, err := s.ListSignedAttestations(pubKey, 0, math.MaxUint64)
+		if err != nil {
+			return nil, fmt.Errorf("could not list signed attestations of %x: %w", pubKey, err)
+		}
+		for _, a := range attestations {
+			data.SignedAttestations = append(data.SignedAttestations, InterchangeAttestation{
+				SourceEpoch: a.SourceEpoch,
+				TargetEpoch: a.TargetEpoch,
+				SigningRoot: nonZeroRoot(a.SigningRoot),
+			})
+		}
+
+		attestation, found, err := s.RetrieveHighestAttestation(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve highest attestation of %x: %w", pubKey, err)
+		}
+		if found && attestation != nil && !containsAttestation(attestations, attestation) {
+			data.SignedAttestations = append(data.SignedAttestations, InterchangeAttestation{
+				SourceEpoch: attestation.Source.Epoch,
+				TargetEpoch: attestation.Target.Epoch,
+			})
+		}
+
+		proposals, err := s.ListSignedProposals(pubKey, 0, math.MaxUint64)
+		if err != nil {
+			return nil, fmt.Errorf("could not list signed proposals of %x: %w", pubKey, err)
+		}
+		for _, p := range proposals {
+			data.SignedBlocks = append(data.SignedBlocks, InterchangeBlock{
+				Slot:        p.Slot,
+				SigningRoot: nonZeroRoot(p.SigningRoot),
+			})
+		}
+
+		slot, found, err := s.RetrieveHighestProposal(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve highest proposal of %x: %w", pubKey, err)
+		}
+		if found && slot != 0 && (len(proposals) == 0 || proposals[len(proposals)-1].Slot != slot) {
+			data.SignedBlocks = append(data.SignedBlocks, InterchangeBlock{Slot: slot})
+		}
+
+		interchange.Data = append(interchange.Data, data)
+	}
+	return interchange, nil
+}
+
+// ImportSlashingProtection merges the given interchange into the storage conservatively:
+// the highest attestation and proposal of each share are only ever raised, never lowered,
+// and the imported records are added to its history where it has none of its own.
+func ImportSlashingProtection(s Storage, interchange *Interchange) (*InterchangeImportResult, error) {
+	if err := validateInterchange(s, interchange); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInterchange, err)
+	}
+
+	result := &InterchangeImportResult{}
+	for _, data := range interchange.Data {
+		pubKey := data.PubKey[:]
+
+		attestationRaised, err := importAttestations(s, pubKey, data.SignedAttestations)
+		if err != nil {
+			return result, fmt.Errorf("could not import attestations of %x: %w", pubKey, err)
+		}
+		proposalRaised, err := importBlocks(s, pubKey, data.SignedBlocks)
+		if err != nil {
+			return result, fmt.Errorf("could not import blocks of %x: %w", pubKey, err)
+		}
+		if err := importHistory(s, pubKey, data); err != nil {
+			return result, fmt.Errorf("could not import history of %x: %w", pubKey, err)
+		}
+
+		if attestationRaised {
+			result.Attestations++
+		}
+		if proposalRaised {
+			result.Proposals++
+		}
+		if attestationRaised || proposalRaised {
+			result.Validators++
+		}
+	}
+	return result, nil
+}
+
+func validateInterchange(s Storage, interchange *Interchange) error {
+	if interchange == nil {
+		return fmt.Errorf("interchange is empty")
+	}
+	if v := interchange.Metadata.InterchangeFormatVersion; v != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %q, expected %q", v, InterchangeFormatVersion)
+	}
+
+	expected := expectedGenesisValidatorsRoot(s)
+	if !expected.IsZero() && !bytes.Equal(interchange.Metadata.GenesisValidatorsRoot[:], expected[:]) {
+		return fmt.Errorf("genesis validators root %s does not match the network's %s",
+			interchange.Metadata.GenesisValidatorsRoot, expected)
+	}
+
+	seen := make(map[phase0.BLSPubKey]struct{}, len(interchange.Data))
+	for _, data := range interchange.Data {
+		if data.PubKey.IsZero() {
+			return fmt.Errorf("missing public key")
+		}
+		if _, ok := seen[data.PubKey]; ok {
+			return fmt.Errorf("duplicate public key %s", data.PubKey)
+		}
+		seen[data.PubKey] = struct{}{}
+
+		for _, attestation := range data.SignedAttestations {
+			if attestation.SourceEpoch > attestation.TargetEpoch {
+				return fmt.Errorf("attestation of %s has source epoch %d after target epoch %d",
+					data.PubKey, attestation.SourceEpoch, attestation.TargetEpoch)
+			}
+		}
+	}
+	return nil
+}
+
+// importAttestations raises the highest attestation of the share to the highest
+// source and target epochs of the given attestations.
+func importAttestations(s Storage, pubKey []byte, attestations []InterchangeAttestation) (bool, error) {
+	if len(attestations) == 0 {
+		return false, nil
+	}
+
+	var source, target phase0.Epoch
+	for _, attestation := range attestations {
+		if attestation.SourceEpoch > source {
+			source = attestation.SourceEpoch
+		}
+		if attestation.TargetEpoch > target {
+			target = attestation.TargetEpoch
+		}
+	}
+
+	highest, found, err := s.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return false, err
+	}
+	if found && highest != nil {
+		if highest.Source.Epoch >= source && highest.Target.Epoch >= target {
+			return false, nil
+		}
+		if highest.Source.Epoch > source {
+			source = highest.Source.Epoch
+		}
+		if highest.Target.Epoch > target {
+			target = highest.Target.Epoch
+		}
+	}
+
+	return true, s.SaveHighestAttestation(pubKey, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: source},
+		Target: &phase0.Checkpoint{Epoch: target},
+	})
+}
+
+// importBlocks raises the highest proposal of the share to the highest slot of the given blocks.
+func importBlocks(s Storage, pubKey []byte, blocks []InterchangeBlock) (bool, error) {
+	var slot phase0.Slot
+	for _, block := range blocks {
+		if block.Slot > slot {
+			slot = block.Slot
+		}
+	}
+	if slot == 0 {
+		return false, nil
+	}
+
+	highest, found, err := s.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return false, err
+	}
+	if found && highest >= slot {
+		return false, nil
+	}
+
+	return true, s.SaveHighestProposal(pubKey, slot)
+}
+
+// importHistory adds the imported attestations and blocks to the share's history,
+// except for target epochs and slots it already has records of.
+func importHistory(s Storage, pubKey []byte, data InterchangeData) error {
+	attestations, err := s.ListSignedAttestations(pubKey, 0, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	targets := make(map[phase0.Epoch]struct{}, len(attestations))
+	for _, a := range attestations {
+		targets[a.TargetEpoch] = struct{}{}
+	}
+	for _, a := range data.SignedAttestations {
+		if _, ok := targets[a.TargetEpoch]; ok {
+			continue
+		}
+		targets[a.TargetEpoch] = struct{}{}
+		record := &SignedAttestation{SourceEpoch: a.SourceEpoch, TargetEpoch: a.TargetEpoch}
+		if a.SigningRoot != nil {
+			record.SigningRoot = *a.SigningRoot
+		}
+		if err := s.SaveSignedAttestation(pubKey, record); err != nil {
+			return err
+		}
+	}
+
+	proposals, err := s.ListSignedProposals(pubKey, 0, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	slots := make(map[phase0.Slot]struct{}, len(proposals))
+	for _, p := range proposals {
+		slots[p.Slot] = struct{}{}
+	}
+	for _, b := range data.SignedBlocks {
+		if _, ok := slots[b.Slot]; ok {
+			continue
+		}
+		slots[b.Slot] = struct{}{}
+		record := &SignedProposal{Slot: b.Slot}
+		if b.SigningRoot != nil {
+			record.SigningRoot = *b.SigningRoot
+		}
+		if err := s.SaveSignedProposal(pubKey, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsAttestation(attestations []*SignedAttestation, data *phase0.AttestationData) bool {
+	for _, a := range attestations {
+		if a.SourceEpoch == data.Source.Epoch && a.TargetEpoch == data.Target.Epoch {
+			return true
+		}
+	}
+	return false
+}
+
+func nonZeroRoot(root phase0.Root) *phase0.Root {
+	if root.IsZero() {
+		return nil
+	}
+	return &root
+}
+
+// expectedGenesisValidatorsRoot returns the genesis validators root of the storage's network,
+// or a zero root if it's not a known network, in which case it isn't checked.
+func expectedGenesisValidatorsRoot(s Storage) phase0.Root {
+	if s.BeaconNetwork().GetNetwork().Custom() {
+		return phase0.Root{}
+	}
+	return genesisValidatorsRoots[s.BeaconNetwork().GetBeaconNetwork()]
+}
+
+func mustRoot(s string) phase0.Root {
+	var root phase0.Root
+	if err := root.UnmarshalJSON([]byte(`"` + s + `"`)); err != nil {
+		panic(err)
+	}
+	return root
+}
@@ -0,0 +1,111 @@
+package ekm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingProtectionInterchange(t *testing.T) {
+	pk1 := _byteArray(pk1Str)
+	pk2 := _byteArray(pk2Str)
+
+	source, done := newStorageForTest(t)
+	defer done()
+
+	require.NoError(t, source.SaveHighestAttestation(pk1, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: 10},
+		Target: &phase0.Checkpoint{Epoch: 11},
+	}))
+	require.NoError(t, source.SaveHighestProposal(pk1, 400))
+	require.NoError(t, source.SaveHighestProposal(pk2, 500))
+
+	interchange, err := ExportSlashingProtection(source)
+	require.NoError(t, err)
+	require.Equal(t, InterchangeFormatVersion, interchange.Metadata.InterchangeFormatVersion)
+	require.Len(t, interchange.Data, 2)
+
+	// Round-trip through JSON, which encodes numbers as strings.
+	encoded, err := json.Marshal(interchange)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"source_epoch":"10"`)
+	var decoded Interchange
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+	target, done := newStorageForTest(t)
+	defer done()
+
+	// pk2 already has a higher proposal and an attestation, which must not be lowered.
+	require.NoError(t, target.SaveHighestProposal(pk2, 600))
+	require.NoError(t, target.SaveHighestAttestation(pk2, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: 20},
+		Target: &phase0.Checkpoint{Epoch: 21},
+	}))
+
+	result, err := ImportSlashingProtection(target, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, &InterchangeImportResult{Validators: 1, Attestations: 1, Proposals: 1}, result)
+
+	attestation, found, err := target.RetrieveHighestAttestation(pk1)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, phase0.Epoch(10), attestation.Source.Epoch)
+	require.Equal(t, phase0.Epoch(11), attestation.Target.Epoch)
+
+	slot, found, err := target.RetrieveHighestProposal(pk1)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, phase0.Slot(400), slot)
+
+	slot, _, err = target.RetrieveHighestProposal(pk2)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(600), slot)
+
+	// Source and target are raised independently.
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], pk2)
+	result, err = ImportSlashingProtection(target, &Interchange{
+		Metadata: decoded.Metadata,
+		Data: []InterchangeData{{
+			PubKey:             pubKey,
+			SignedAttestations: []InterchangeAttestation{{SourceEpoch: 15, TargetEpoch: 30}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Attestations)
+	attestation, _, err = target.RetrieveHighestAttestation(pk2)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(20), attestation.Source.Epoch)
+	require.Equal(t, phase0.Epoch(30), attestation.Target.Epoch)
+}
+
+func TestSlashingProtectionInterchangeValidation(t *testing.T) {
+	s, done := newStorageForTest(t)
+	defer done()
+
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], _byteArray(pk1Str))
+	metadata := InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion}
+
+	tests := []struct {
+		name        string
+		interchange *Interchange
+	}{
+		{"nil", nil},
+		{"unsupported version", &Interchange{Metadata: InterchangeMetadata{InterchangeFormatVersion: "4"}}},
+		{"missing public key", &Interchange{Metadata: metadata, Data: []InterchangeData{{}}}},
+		{"duplicate public key", &Interchange{Metadata: metadata, Data: []InterchangeData{{PubKey: pubKey}, {PubKey: pubKey}}}},
+		{"source after target", &Interchange{Metadata: metadata, Data: []InterchangeData{{
+			PubKey:             pubKey,
+			SignedAttestations: []InterchangeAttestation{{SourceEpoch: 2, TargetEpoch: 1}},
+		}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportSlashingProtection(s, tt.interchange)
+			require.Error(t, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -66,6 +67,12 @@ type EventHandler struct {
 	keyManager        spectypes.KeyManager
 	beacon            beaconprotocol.BeaconNode
 	storageMap        *qbftstorage.QBFTStores
+	chain             ChainReader
+	reorgDepth        uint64
+	taskRetryPolicy   TaskRetryPolicy
+	taskMu            sync.Mutex
+
+	haltOnUnknownEvents bool
 
 	fullNode bool
 	logger   *zap.Logger
@@ -94,6 +101,7 @@ func New(
 		keyManager:        keyManager,
 		beacon:            beacon,
 		storageMap:        storageMap,
+		taskRetryPolicy:   defaultTaskRetryPolicy,
 		logger:            zap.NewNop(),
 		metrics:           nopMetrics{},
 	}
@@ -105,10 +113,33 @@ func New(
 	return eh, nil
 }
 
+// HandleBlockEventsStream processes the events of the given blocks and returns the last processed block.
+// If reorg protection is enabled and a reorg is detected, the replaced blocks are rolled back
+// and ErrReorg is returned along with the last processed block which is still canonical.
 func (eh *EventHandler) HandleBlockEventsStream(logs <-chan executionclient.BlockLogs, executeTasks bool) (lastProcessedBlock uint64, err error) {
+	// Recorded unknown events are reported again, so that they're alerted on after restarts too.
+	eh.reportUnknownEvents()
+
+	if err := eh.initRegistryHistory(); err != nil {
+		return 0, fmt.Errorf("failed to init registry history: %w", err)
+	}
+
+	checkedReorg := false
 	for blockLogs := range logs {
 		logger := eh.logger.With(fields.BlockNumber(blockLogs.BlockNumber))
 
+		// Historical blocks are checked once, as they are fetched from the same chain.
+		if eh.chain != nil && (executeTasks || !checkedReorg) {
+			checkedReorg = true
+			ancestor, reorged, err := eh.checkReorg(executeTasks)
+			if err != nil {
+				return 0, fmt.Errorf("failed to check for reorg: %w", err)
+			}
+			if reorged {
+				return ancestor, fmt.Errorf("%w: last canonical block is %d", ErrReorg, ancestor)
+			}
+		}
+
 		start := time.Now()
 		tasks, err := eh.processBlockEvents(blockLogs)
 		logger.Debug("processed events from block",
@@ -128,14 +159,7 @@ func (eh *EventHandler) HandleBlockEventsStream(logs <-chan executionclient.Bloc
 		logger.Debug("executing tasks", fields.Count(len(tasks)))
 
 		for _, task := range tasks {
-			logger = logger.With(fields.Type(task))
-			logger.Debug("executing task")
-			if err := task.Execute(); err != nil {
-				// TODO: We log failed task until we discuss how we want to handle this case. We likely need to crash the node in this case.
-				logger.Error("failed to execute task", zap.Error(err))
-			} else {
-				logger.Debug("executed task")
-			}
+			eh.executeTask(logger, task, blockLogs.BlockNumber)
 		}
 	}
 
@@ -160,14 +184,21 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 		// Returning an error to signal that we should stop processing and
 		// investigate the issue.
 		//
-		// TODO: this may happen during reorgs, and we should either
-		// implement reorg support or only process finalized blocks.
+		// Reorgs are rolled back before processing, see WithReorgProtection.
 		return nil, ErrInferiorBlock
 	}
 
+	// Journal the writes so that the block can be rolled back in case of a reorg.
+	var eventTxn basedb.Txn = txn
+	var journal *journalingTxn
+	if eh.chain != nil {
+		journal = newJournalingTxn(txn)
+		eventTxn = journal
+	}
+
 	var tasks []Task
 	for _, log := range block.Logs {
-		task, err := eh.processEvent(txn, log)
+		task, err := eh.processEvent(eventTxn, log)
 		if err != nil {
 			return nil, err
 		}
@@ -180,6 +211,17 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 		return nil, fmt.Errorf("set last processed block: %w", err)
 	}
 
+	if journal != nil {
+		processedBlock := &nodestorage.ProcessedBlock{
+			Number: block.BlockNumber,
+			Hash:   block.BlockHash,
+			Undo:   journal.undo,
+		}
+		if err := eh.journalProcessedBlock(txn, processedBlock, tasks); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := txn.Commit(); err != nil {
 		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -190,8 +232,7 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task, error) {
 	abiEvent, err := eh.eventParser.EventByID(event.Topics[0])
 	if err != nil {
-		eh.logger.Error("failed to find event by ID", zap.String("hash", event.Topics[0].String()))
-		return nil, nil
+		return nil, eh.handleUnknownEvent(event)
 	}
 
 	switch abiEvent.Name {
@@ -430,6 +471,10 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 }
 
 func (eh *EventHandler) HandleLocalEvents(localEvents []localevents.Event) error {
+	if err := eh.initRegistryHistory(); err != nil {
+		return fmt.Errorf("init registry history: %w", err)
+	}
+
 	txn := eh.nodeStorage.Begin()
 	defer txn.Discard()
 
@@ -462,6 +507,15 @@ func (eh *EventHandler) processLocalEvent(txn basedb.Txn, event localevents.Even
 		return nil
 	case ValidatorAdded:
 		data := event.Data.(contract.ContractValidatorAdded)
+		if event.Nonce != nil {
+			nonce, err := eh.nodeStorage.GetNextNonce(txn, data.Owner)
+			if err != nil {
+				return fmt.Errorf("failed to get next nonce: %w", err)
+			}
+			if *event.Nonce != nonce {
+				return fmt.Errorf("handle ValidatorAdded: nonce %d doesn't match the owner's next nonce %d", *event.Nonce, nonce)
+			}
+		}
 		if _, err := eh.handleValidatorAdded(txn, &data); err != nil {
 			return fmt.Errorf("handle ValidatorAdded: %w", err)
 		}
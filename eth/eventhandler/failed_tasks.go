@@ -0,0 +1,323 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// Task types of failed tasks.
+const (
+	TaskStartValidator     = "StartValidator"
+	TaskStopValidator      = "StopValidator"
+	TaskLiquidateCluster   = "LiquidateCluster"
+	TaskReactivateCluster  = "ReactivateCluster"
+	TaskUpdateFeeRecipient = "UpdateFeeRecipient"
+	TaskExitValidator      = "ExitValidator"
+)
+
+var ErrFailedTaskNotFound = errors.New("failed task not found")
+
+// TaskRetryPolicy defines how failed tasks are retried. The backoff doubles after each attempt,
+// and tasks which ran out of attempts are kept as dead until they're retried or discarded on demand.
+type TaskRetryPolicy struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	MaxAttempts    int
+}
+
+var defaultTaskRetryPolicy = TaskRetryPolicy{
+	InitialBackoff: 30 * time.Second,
+	MaxBackoff:     time.Hour,
+	MaxAttempts:    10,
+}
+
+// executeTask executes the given task of the given block, and saves it to be retried if it fails.
+func (eh *EventHandler) executeTask(logger *zap.Logger, task Task, blockNumber uint64) {
+	eh.taskMu.Lock()
+	defer eh.taskMu.Unlock()
+
+	logger = logger.With(fields.Type(task))
+	logger.Debug("executing task")
+	err := task.Execute()
+	if err == nil {
+		logger.Debug("executed task")
+		return
+	}
+
+	failed := describeTask(task)
+	if failed == nil {
+		logger.Error("failed to execute task", zap.Error(err))
+		return
+	}
+	eh.metrics.EventTaskFailed(failed.Type)
+	failed.BlockNumber = blockNumber
+	failed.CreatedAt = time.Now()
+	eh.failedAttempt(failed, err)
+	if err := eh.nodeStorage.SaveFailedTask(nil, failed); err != nil {
+		logger.Error("failed to execute task, and could not save it to be retried", zap.Error(err))
+		return
+	}
+	logger.Error("failed to execute task, will retry",
+		zap.Uint64("failed_task_id", failed.ID),
+		zap.Time("next_attempt", failed.NextAttempt),
+		zap.Error(err))
+}
+
+// failedAttempt records a failed attempt of the given task and schedules the next one.
+func (eh *EventHandler) failedAttempt(task *nodestorage.FailedTask, err error) {
+	task.Attempts++
+	task.LastError = err.Error()
+	if task.Attempts >= eh.taskRetryPolicy.MaxAttempts {
+		task.Dead = true
+	}
+	backoff := eh.taskRetryPolicy.MaxBackoff
+	if shift := task.Attempts - 1; shift < 32 && eh.taskRetryPolicy.InitialBackoff<<shift < backoff {
+		backoff = eh.taskRetryPolicy.InitialBackoff << shift
+	}
+	task.NextAttempt = time.Now().Add(backoff)
+}
+
+// RetryFailedTasks retries the failed tasks as they're due, until the context is done.
+func (eh *EventHandler) RetryFailedTasks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		eh.retryDueTasks()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (eh *EventHandler) retryDueTasks() {
+	tasks, err := eh.nodeStorage.GetFailedTasks(nil)
+	if err != nil {
+		eh.logger.Error("could not get failed tasks", zap.Error(err))
+		return
+	}
+	now := time.Now()
+	for _, task := range tasks {
+		if task.Dead || task.NextAttempt.After(now) {
+			continue
+		}
+		_ = eh.retryTask(task)
+	}
+	eh.reportFailedTasks()
+}
+
+// FailedTasks returns the failed tasks which are pending a retry or dead.
+func (eh *EventHandler) FailedTasks() ([]*nodestorage.FailedTask, error) {
+	return eh.nodeStorage.GetFailedTasks(nil)
+}
+
+// RetryFailedTask retries the failed task with the given ID now, even if it's dead.
+func (eh *EventHandler) RetryFailedTask(id uint64) error {
+	task, found, err := eh.nodeStorage.GetFailedTask(nil, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrFailedTaskNotFound
+	}
+	defer eh.reportFailedTasks()
+	return eh.retryTask(task)
+}
+
+// DiscardFailedTask deletes the failed task with the given ID without retrying it.
+func (eh *EventHandler) DiscardFailedTask(id uint64) error {
+	task, found, err := eh.nodeStorage.GetFailedTask(nil, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrFailedTaskNotFound
+	}
+	if err := eh.nodeStorage.DeleteFailedTask(nil, id); err != nil {
+		return err
+	}
+	eh.logger.Info("discarded failed task", zap.Uint64("failed_task_id", id), zap.String("type", task.Type))
+	eh.reportFailedTasks()
+	return nil
+}
+
+// retryTask executes the given failed task again, and deletes it if it succeeds or became obsolete.
+func (eh *EventHandler) retryTask(failed *nodestorage.FailedTask) error {
+	eh.taskMu.Lock()
+	defer eh.taskMu.Unlock()
+
+	logger := eh.logger.With(
+		zap.Uint64("failed_task_id", failed.ID),
+		zap.String("type", failed.Type),
+		fields.BlockNumber(failed.BlockNumber),
+		zap.Int("attempts", failed.Attempts))
+
+	task, err := eh.restoreTask(failed)
+	if err != nil {
+		logger.Error("could not restore failed task", zap.Error(err))
+		return err
+	}
+	if task == nil {
+		// Later events changed what the task would do, and executed their own tasks.
+		logger.Info("failed task is obsolete, deleting it")
+		return eh.nodeStorage.DeleteFailedTask(nil, failed.ID)
+	}
+
+	if execErr := task.Execute(); execErr != nil {
+		eh.failedAttempt(failed, execErr)
+		if err := eh.nodeStorage.SaveFailedTask(nil, failed); err != nil {
+			logger.Error("could not save failed task", zap.Error(err))
+		}
+		logger.Warn("failed to retry task",
+			zap.Bool("dead", failed.Dead),
+			zap.Time("next_attempt", failed.NextAttempt),
+			zap.Error(execErr))
+		return execErr
+	}
+	eh.metrics.EventTaskRetried(failed.Type)
+	logger.Info("retried failed task")
+	return eh.nodeStorage.DeleteFailedTask(nil, failed.ID)
+}
+
+func (eh *EventHandler) reportFailedTasks() {
+	tasks, err := eh.nodeStorage.GetFailedTasks(nil)
+	if err != nil {
+		return
+	}
+	var pending, dead int
+	for _, task := range tasks {
+		if task.Dead {
+			dead++
+		} else {
+			pending++
+		}
+	}
+	eh.metrics.FailedEventTasks(pending, dead)
+}
+
+// describeTask returns the failed task which describes the given task, or nil if it can't be retried.
+func describeTask(task Task) *nodestorage.FailedTask {
+	switch t := task.(type) {
+	case *StartValidatorTask:
+		return &nodestorage.FailedTask{
+			Type:             TaskStartValidator,
+			ValidatorPubKeys: [][]byte{t.share.ValidatorPubKey},
+			Owner:            t.share.OwnerAddress,
+		}
+	case *StopValidatorTask:
+		return &nodestorage.FailedTask{
+			Type:             TaskStopValidator,
+			ValidatorPubKeys: [][]byte{t.pubKey},
+		}
+	case *LiquidateClusterTask:
+		return &nodestorage.FailedTask{
+			Type:             TaskLiquidateCluster,
+			ValidatorPubKeys: sharePubKeys(t.toLiquidate),
+			Owner:            t.owner,
+			OperatorIDs:      t.operatorIDs,
+		}
+	case *ReactivateClusterTask:
+		return &nodestorage.FailedTask{
+			Type:             TaskReactivateCluster,
+			ValidatorPubKeys: sharePubKeys(t.toReactivate),
+			Owner:            t.owner,
+			OperatorIDs:      t.operatorIDs,
+		}
+	case *UpdateFeeRecipientTask:
+		return &nodestorage.FailedTask{
+			Type:      TaskUpdateFeeRecipient,
+			Owner:     t.owner,
+			Recipient: t.recipient,
+		}
+	case *ExitValidatorTask:
+		return &nodestorage.FailedTask{
+			Type:             TaskExitValidator,
+			ValidatorPubKeys: [][]byte{t.pubKey[:]},
+			ValidatorIndex:   uint64(t.validatorIndex),
+		}
+	default:
+		return nil
+	}
+}
+
+// restoreTask returns the task described by the given failed task, according to the current state.
+// It returns nil if the task is obsolete, because later events changed the state it would apply.
+func (eh *EventHandler) restoreTask(failed *nodestorage.FailedTask) (Task, error) {
+	shares := eh.nodeStorage.Shares()
+	if len(failed.ValidatorPubKeys) == 0 && failed.Type != TaskUpdateFeeRecipient {
+		return nil, fmt.Errorf("failed task has no validators")
+	}
+	switch failed.Type {
+	case TaskStartValidator:
+		share := shares.Get(nil, failed.ValidatorPubKeys[0])
+		if share == nil || share.Liquidated {
+			return nil, nil
+		}
+		return NewStartValidatorTask(eh.taskExecutor, share), nil
+
+	case TaskStopValidator:
+		if shares.Get(nil, failed.ValidatorPubKeys[0]) != nil {
+			return nil, nil
+		}
+		return NewStopValidatorTask(eh.taskExecutor, failed.ValidatorPubKeys[0]), nil
+
+	case TaskLiquidateCluster, TaskReactivateCluster:
+		liquidate := failed.Type == TaskLiquidateCluster
+		var toApply []*ssvtypes.SSVShare
+		for _, pubKey := range failed.ValidatorPubKeys {
+			if share := shares.Get(nil, pubKey); share != nil && share.Liquidated == liquidate {
+				toApply = append(toApply, share)
+			}
+		}
+		if len(toApply) == 0 {
+			return nil, nil
+		}
+		if liquidate {
+			return NewLiquidateClusterTask(eh.taskExecutor, failed.Owner, failed.OperatorIDs, toApply), nil
+		}
+		return NewReactivateClusterTask(eh.taskExecutor, failed.Owner, failed.OperatorIDs, toApply), nil
+
+	case TaskUpdateFeeRecipient:
+		recipient, found, err := eh.nodeStorage.GetRecipientData(nil, failed.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("could not get recipient data: %w", err)
+		}
+		if !found || ethcommon.Address(recipient.FeeRecipient) != failed.Recipient {
+			return nil, nil
+		}
+		return NewUpdateFeeRecipientTask(eh.taskExecutor, failed.Owner, failed.Recipient), nil
+
+	case TaskExitValidator:
+		if len(failed.ValidatorPubKeys[0]) != phase0.PublicKeyLength {
+			return nil, fmt.Errorf("invalid validator public key")
+		}
+		return NewExitValidatorTask(
+			eh.taskExecutor,
+			phase0.BLSPubKey(failed.ValidatorPubKeys[0]),
+			failed.BlockNumber,
+			phase0.ValidatorIndex(failed.ValidatorIndex),
+		), nil
+
+	default:
+		return nil, fmt.Errorf("unknown task type %q", failed.Type)
+	}
+}
+
+func sharePubKeys(shares []*ssvtypes.SSVShare) [][]byte {
+	pubKeys := make([][]byte, 0, len(shares))
+	for _, share := range shares {
+		pubKeys = append(pubKeys, share.ValidatorPubKey)
+	}
+	return pubKeys
+}
@@ -0,0 +1,110 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/eth/executionclient"
+)
+
+func TestFailedTasks(t *testing.T) {
+	ops, err := createOperators(1, 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := zaptest.NewLogger(t)
+	eh, validatorCtrl, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+	WithTaskRetryPolicy(TaskRetryPolicy{MaxAttempts: 2})(eh)
+
+	owner := ethcommon.HexToAddress("0x1")
+	recipient := ethcommon.HexToAddress("0x2")
+	otherRecipient := ethcommon.HexToAddress("0x3")
+	transientErr := errors.New("beacon node is unavailable")
+
+	block := uint64(0)
+	updateFeeRecipient := func(recipient ethcommon.Address, executeTasks bool) {
+		block++
+		blocks := blocksChan(executionclient.BlockLogs{
+			BlockNumber: block,
+			Logs:        []ethtypes.Log{feeRecipientUpdatedLog(t, owner, recipient)},
+		})
+		_, err := eh.HandleBlockEventsStream(blocks, executeTasks)
+		require.NoError(t, err)
+	}
+
+	// The failed task is saved.
+	validatorCtrl.EXPECT().UpdateFeeRecipient(owner, recipient).Return(transientErr)
+	updateFeeRecipient(recipient, true)
+
+	tasks, err := eh.FailedTasks()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, TaskUpdateFeeRecipient, tasks[0].Type)
+	require.Equal(t, block, tasks[0].BlockNumber)
+	require.Equal(t, recipient, tasks[0].Recipient)
+	require.Equal(t, 1, tasks[0].Attempts)
+	require.Equal(t, transientErr.Error(), tasks[0].LastError)
+	require.False(t, tasks[0].Dead)
+
+	// It's dead after running out of attempts, and isn't retried anymore.
+	validatorCtrl.EXPECT().UpdateFeeRecipient(owner, recipient).Return(transientErr)
+	eh.retryDueTasks()
+	eh.retryDueTasks()
+	tasks, err = eh.FailedTasks()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, 2, tasks[0].Attempts)
+	require.True(t, tasks[0].Dead)
+
+	// It's deleted once it's retried on demand successfully.
+	validatorCtrl.EXPECT().UpdateFeeRecipient(owner, recipient).Return(nil)
+	require.NoError(t, eh.RetryFailedTask(tasks[0].ID))
+	tasks, err = eh.FailedTasks()
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+	require.ErrorIs(t, eh.RetryFailedTask(1), ErrFailedTaskNotFound)
+
+	t.Run("obsolete", func(t *testing.T) {
+		validatorCtrl.EXPECT().UpdateFeeRecipient(owner, recipient).Return(transientErr)
+		updateFeeRecipient(recipient, true)
+		updateFeeRecipient(otherRecipient, false)
+
+		// The recipient was updated since, so the task is deleted without retrying it.
+		eh.retryDueTasks()
+		tasks, err := eh.FailedTasks()
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		validatorCtrl.EXPECT().UpdateFeeRecipient(owner, recipient).Return(transientErr)
+		updateFeeRecipient(recipient, true)
+
+		tasks, err := eh.FailedTasks()
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.NoError(t, eh.DiscardFailedTask(tasks[0].ID))
+		require.ErrorIs(t, eh.DiscardFailedTask(tasks[0].ID), ErrFailedTaskNotFound)
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		WithTaskRetryPolicy(TaskRetryPolicy{InitialBackoff: time.Minute, MaxBackoff: 3 * time.Minute, MaxAttempts: 10})(eh)
+		task := describeTask(NewUpdateFeeRecipientTask(nil, owner, recipient))
+		var backoffs []time.Duration
+		for i := 0; i < 4; i++ {
+			eh.failedAttempt(task, transientErr)
+			backoffs = append(backoffs, time.Until(task.NextAttempt).Round(time.Minute))
+		}
+		require.Equal(t, []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute}, backoffs)
+	})
+}
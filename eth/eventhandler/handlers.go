@@ -16,6 +16,7 @@ import (
 	"github.com/bloxapp/ssv/eth/contract"
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/operator/duties"
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
 	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
@@ -206,6 +207,10 @@ func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.Con
 		return nil, &MalformedEventError{Err: ErrShareBelongsToDifferentOwner}
 	}
 
+	if err := eh.nodeStorage.SaveShareVersion(txn, newShareVersion(validatorShare, event.Raw.BlockNumber)); err != nil {
+		return nil, fmt.Errorf("could not save share version: %w", err)
+	}
+
 	if validatorShare.BelongsToOperator(eh.operatorDataStore.GetOperatorID()) {
 		eh.metrics.ValidatorInactive(event.PublicKey)
 		ownShare = validatorShare
@@ -354,6 +359,12 @@ func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.C
 		return nil, fmt.Errorf("could not remove validator share: %w", err)
 	}
 
+	removedVersion := newShareVersion(share, event.Raw.BlockNumber)
+	removedVersion.Removed = true
+	if err := eh.nodeStorage.SaveShareVersion(txn, removedVersion); err != nil {
+		return nil, fmt.Errorf("could not save share version: %w", err)
+	}
+
 	isOperatorShare := share.BelongsToOperator(eh.operatorDataStore.GetOperatorID())
 	if isOperatorShare || eh.fullNode {
 		logger = logger.With(zap.String("validator_pubkey", hex.EncodeToString(share.ValidatorPubKey)))
@@ -382,7 +393,7 @@ func (eh *EventHandler) handleClusterLiquidated(txn basedb.Txn, event *contract.
 	)
 	logger.Debug("processing event")
 
-	toLiquidate, liquidatedPubKeys, err := eh.processClusterEvent(txn, event.Owner, event.OperatorIds, true)
+	toLiquidate, liquidatedPubKeys, err := eh.processClusterEvent(txn, event.Owner, event.OperatorIds, event.Raw.BlockNumber, true)
 	if err != nil {
 		return nil, fmt.Errorf("could not process cluster event: %w", err)
 	}
@@ -404,7 +415,7 @@ func (eh *EventHandler) handleClusterReactivated(txn basedb.Txn, event *contract
 	)
 	logger.Debug("processing event")
 
-	toReactivate, enabledPubKeys, err := eh.processClusterEvent(txn, event.Owner, event.OperatorIds, false)
+	toReactivate, enabledPubKeys, err := eh.processClusterEvent(txn, event.Owner, event.OperatorIds, event.Raw.BlockNumber, false)
 	if err != nil {
 		return nil, fmt.Errorf("could not process cluster event: %w", err)
 	}
@@ -451,6 +462,15 @@ func (eh *EventHandler) handleFeeRecipientAddressUpdated(txn basedb.Txn, event *
 		return false, fmt.Errorf("could not save recipient data: %w", err)
 	}
 
+	err = eh.nodeStorage.SaveRecipientVersion(txn, &nodestorage.RecipientVersion{
+		Owner:        event.Owner,
+		BlockNumber:  event.Raw.BlockNumber,
+		FeeRecipient: recipientData.FeeRecipient,
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not save recipient version: %w", err)
+	}
+
 	logger.Debug("processed event")
 	return r != nil, nil
 }
@@ -517,6 +537,7 @@ func (eh *EventHandler) processClusterEvent(
 	txn basedb.Txn,
 	owner ethcommon.Address,
 	operatorIDs []uint64,
+	blockNumber uint64,
 	toLiquidate bool,
 ) ([]*ssvtypes.SSVShare, []string, error) {
 	clusterID := ssvtypes.ComputeClusterIDHash(owner, operatorIDs)
@@ -525,6 +546,13 @@ func (eh *EventHandler) processClusterEvent(
 	updatedPubKeys := make([]string, 0)
 
 	for _, share := range shares {
+		// Only the operator's own shares are updated, but the history records the whole cluster.
+		version := newShareVersion(share, blockNumber)
+		version.Liquidated = toLiquidate
+		if err := eh.nodeStorage.SaveShareVersion(txn, version); err != nil {
+			return nil, nil, fmt.Errorf("could not save share version: %w", err)
+		}
+
 		isOperatorShare := share.BelongsToOperator(eh.operatorDataStore.GetOperatorID())
 		if isOperatorShare || eh.fullNode {
 			updatedPubKeys = append(updatedPubKeys, hex.EncodeToString(share.ValidatorPubKey))
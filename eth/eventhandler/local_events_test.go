@@ -92,4 +92,28 @@ func TestHandleLocalEvent(t *testing.T) {
 
 		require.ErrorIs(t, eh.HandleLocalEvents(parsedData), ErrSignatureVerification)
 	})
+
+	t.Run("ValidatorAdded event with unexpected nonce", func(t *testing.T) {
+		input := []byte(`
+- Name: ValidatorAdded
+  Data:
+    PublicKey: 0x89913833b5533c1089a957ea185daecc0c5719165f7cbb7ba971c2dcf916be32d6c620dab56888bc278515bf27aebc5f
+    Owner: 0xcEEfd323DD28a8d9514EDDfeC45a6c81800A7D49
+    OperatorIds: [1]
+    Shares: 0x1234
+    Nonce: 1
+`)
+
+		var parsedData []localevents.Event
+		require.NoError(t, yaml.Unmarshal(input, &parsedData))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		logger := zaptest.NewLogger(t)
+		eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], false)
+		require.NoError(t, err)
+
+		require.ErrorContains(t, eh.HandleLocalEvents(parsedData), "nonce 1 doesn't match the owner's next nonce 0")
+	})
 }
@@ -11,6 +11,10 @@ type metrics interface {
 	ValidatorRemoved(publicKey []byte)
 	EventProcessed(eventName string)
 	EventProcessingFailed(eventName string)
+	EventTaskFailed(taskType string)
+	EventTaskRetried(taskType string)
+	FailedEventTasks(pending, dead int)
+	UnknownEvents(count int)
 }
 
 // nopMetrics is no-op metrics.
@@ -22,3 +26,7 @@ func (n nopMetrics) ValidatorError([]byte)                          {}
 func (n nopMetrics) ValidatorRemoved([]byte)                        {}
 func (n nopMetrics) EventProcessed(string)                          {}
 func (n nopMetrics) EventProcessingFailed(string)                   {}
+func (n nopMetrics) EventTaskFailed(string)                         {}
+func (n nopMetrics) EventTaskRetried(string)                        {}
+func (n nopMetrics) FailedEventTasks(int, int)                      {}
+func (n nopMetrics) UnknownEvents(int)                              {}
@@ -28,3 +28,27 @@ func WithFullNode() Option {
 		eh.fullNode = true
 	}
 }
+
+// WithReorgProtection journals the recently processed blocks, so that the ones which
+// are replaced by a reorg of up to the given depth are detected and rolled back.
+func WithReorgProtection(chain ChainReader, depth uint64) Option {
+	return func(eh *EventHandler) {
+		eh.chain = chain
+		eh.reorgDepth = depth
+	}
+}
+
+// WithTaskRetryPolicy sets how failed tasks are retried.
+func WithTaskRetryPolicy(policy TaskRetryPolicy) Option {
+	return func(eh *EventHandler) {
+		eh.taskRetryPolicy = policy
+	}
+}
+
+// WithHaltOnUnknownEvents halts processing on events which aren't in the contract's ABI instead of skipping them,
+// until the node is upgraded to process them.
+func WithHaltOnUnknownEvents() Option {
+	return func(eh *EventHandler) {
+		eh.haltOnUnknownEvents = true
+	}
+}
@@ -0,0 +1,76 @@
+package eventhandler
+
+import (
+	"fmt"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// initRegistryHistory starts recording the registry's history, unless it's recorded already.
+// If the registry was synced before, its current state is recorded as of the last processed block,
+// so the history is only available from that block on.
+func (eh *EventHandler) initRegistryHistory() error {
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	_, found, err := eh.nodeStorage.GetRegistryHistoryStart(txn)
+	if err != nil {
+		return fmt.Errorf("get registry history start: %w", err)
+	}
+	if found {
+		return nil
+	}
+
+	var start uint64
+	lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(txn)
+	if err != nil {
+		return fmt.Errorf("get last processed block: %w", err)
+	}
+	if found && lastProcessedBlock != nil {
+		start = lastProcessedBlock.Uint64()
+		for _, share := range eh.nodeStorage.Shares().List(txn) {
+			if err := eh.nodeStorage.SaveShareVersion(txn, newShareVersion(share, start)); err != nil {
+				return fmt.Errorf("save share version: %w", err)
+			}
+		}
+		recipients, err := eh.nodeStorage.ListRecipients(txn)
+		if err != nil {
+			return fmt.Errorf("list recipients: %w", err)
+		}
+		for _, recipient := range recipients {
+			err := eh.nodeStorage.SaveRecipientVersion(txn, &nodestorage.RecipientVersion{
+				Owner:        recipient.Owner,
+				BlockNumber:  start,
+				FeeRecipient: recipient.FeeRecipient,
+			})
+			if err != nil {
+				return fmt.Errorf("save recipient version: %w", err)
+			}
+		}
+	}
+	if err := eh.nodeStorage.SaveRegistryHistoryStart(txn, start); err != nil {
+		return fmt.Errorf("save registry history start: %w", err)
+	}
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	if start > 0 {
+		eh.logger.Info("recording registry history, which is unavailable before the last processed block",
+			fields.FromBlock(start))
+	}
+	return nil
+}
+
+// newShareVersion returns the version of the given share as of the given block.
+func newShareVersion(share *ssvtypes.SSVShare, blockNumber uint64) *nodestorage.ShareVersion {
+	return &nodestorage.ShareVersion{
+		ValidatorPubKey: share.ValidatorPubKey,
+		BlockNumber:     blockNumber,
+		Owner:           share.OwnerAddress,
+		OperatorIDs:     committeeIDs(share),
+		Liquidated:      share.Liquidated,
+	}
+}
@@ -0,0 +1,92 @@
+package eventhandler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+func TestRegistryHistory(t *testing.T) {
+	ops, err := createOperators(1, 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("record events", func(t *testing.T) {
+		eh, _, err := setupEventHandler(t, ctx, zaptest.NewLogger(t), nil, ops[0], false)
+		require.NoError(t, err)
+		chain := &testChain{hashes: map[uint64]ethcommon.Hash{}}
+		WithReorgProtection(chain, 2)(eh)
+
+		owner := ethcommon.HexToAddress("0x1")
+		recipient := ethcommon.HexToAddress("0x2")
+		recipientUpdated := feeRecipientUpdatedLog(t, owner, recipient)
+		recipientUpdated.BlockNumber = 2
+		_, err = eh.HandleBlockEventsStream(blocksChan(chain.block(1), chain.block(2, recipientUpdated)), false)
+		require.NoError(t, err)
+
+		recipients, err := eh.nodeStorage.GetRecipientsAtBlock(nil, 1)
+		require.NoError(t, err)
+		require.Empty(t, recipients)
+		recipients, err = eh.nodeStorage.GetRecipientsAtBlock(nil, 2)
+		require.NoError(t, err)
+		require.Equal(t, []*nodestorage.RecipientVersion{
+			{Owner: owner, BlockNumber: 2, FeeRecipient: bellatrix.ExecutionAddress(recipient)},
+		}, recipients)
+
+		// Rolled back blocks are removed from the history.
+		chain.hashes[2] = ethcommon.HexToHash("0xff")
+		_, err = eh.HandleBlockEventsStream(blocksChan(chain.block(3)), false)
+		require.ErrorIs(t, err, ErrReorg)
+		recipients, err = eh.nodeStorage.GetRecipientsAtBlock(nil, 2)
+		require.NoError(t, err)
+		require.Empty(t, recipients)
+	})
+
+	t.Run("record synced registry", func(t *testing.T) {
+		eh, _, err := setupEventHandler(t, ctx, zaptest.NewLogger(t), nil, ops[0], false)
+		require.NoError(t, err)
+
+		share := &ssvtypes.SSVShare{
+			Share: spectypes.Share{
+				ValidatorPubKey: make([]byte, 48),
+				Committee:       []*spectypes.Operator{{OperatorID: 1}, {OperatorID: 2}, {OperatorID: 3}, {OperatorID: 4}},
+			},
+			Metadata: ssvtypes.Metadata{OwnerAddress: ethcommon.Address{1}},
+		}
+		require.NoError(t, eh.nodeStorage.Shares().Save(nil, share))
+		_, err = eh.nodeStorage.SaveRecipientData(nil, &registrystorage.RecipientData{Owner: ethcommon.Address{1}})
+		require.NoError(t, err)
+		require.NoError(t, eh.nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(50)))
+
+		require.NoError(t, eh.initRegistryHistory())
+		shares, err := eh.nodeStorage.GetSharesAtBlock(nil, 50)
+		require.NoError(t, err)
+		require.Equal(t, []*nodestorage.ShareVersion{
+			{ValidatorPubKey: share.ValidatorPubKey, BlockNumber: 50, Owner: ethcommon.Address{1}, OperatorIDs: []uint64{1, 2, 3, 4}},
+		}, shares)
+		recipients, err := eh.nodeStorage.GetRecipientsAtBlock(nil, 50)
+		require.NoError(t, err)
+		require.Len(t, recipients, 1)
+		_, err = eh.nodeStorage.GetSharesAtBlock(nil, 49)
+		require.ErrorIs(t, err, nodestorage.ErrRegistryHistoryUnavailable)
+
+		// It's only recorded once.
+		require.NoError(t, eh.nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(60)))
+		require.NoError(t, eh.initRegistryHistory())
+		start, _, err := eh.nodeStorage.GetRegistryHistoryStart(nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(50), start)
+	})
+}
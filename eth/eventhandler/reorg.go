@@ -0,0 +1,364 @@
+package eventhandler
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	reorgCheckTimeout = 10 * time.Second
+
+	// A header is fetched again after a failure, so that a transient error doesn't stop the sync.
+	headerFetchAttempts       = 3
+	headerFetchInitialBackoff = 500 * time.Millisecond
+)
+
+var (
+	// ErrReorg is returned when processed blocks were rolled back because of a reorg,
+	// and events must be processed again from the block after the returned last processed block.
+	ErrReorg = errors.New("rolled back processed blocks because of a reorg")
+
+	// ErrReorgTooDeep is returned when a reorg replaced blocks which can't be rolled back anymore.
+	ErrReorgTooDeep = errors.New("reorg is deeper than the processed blocks which can be rolled back")
+)
+
+// ChainReader reads the headers of the canonical chain. The execution client reads them from
+// the same quorum of endpoints as the logs, so that a single endpoint can't cause a rollback.
+type ChainReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+}
+
+// checkReorg verifies that the last processed block is still canonical. Otherwise, it rolls back
+// the processed blocks which were replaced, and returns the last processed block which is still canonical.
+// If executeTasks is set, the validators affected by the rolled back events are started or stopped accordingly.
+// Voluntary exits which were already submitted can't be undone.
+func (eh *EventHandler) checkReorg(executeTasks bool) (lastProcessedBlock uint64, reorged bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), reorgCheckTimeout)
+	defer cancel()
+
+	blocks, err := eh.nodeStorage.GetProcessedBlocks(nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("get processed blocks: %w", err)
+	}
+	if len(blocks) == 0 {
+		return 0, false, nil
+	}
+
+	// If the last processed block is canonical, then so are its ancestors.
+	canonical, err := eh.isCanonical(ctx, blocks[len(blocks)-1])
+	if err != nil || canonical {
+		return 0, false, err
+	}
+
+	ancestor := -1
+	for i := len(blocks) - 2; i >= 0; i-- {
+		canonical, err := eh.isCanonical(ctx, blocks[i])
+		if err != nil {
+			return 0, false, err
+		}
+		if canonical {
+			ancestor = i
+			break
+		}
+	}
+	if ancestor == -1 {
+		return 0, false, fmt.Errorf("%w: block %d was replaced, the database must be synced again",
+			ErrReorgTooDeep, blocks[0].Number)
+	}
+
+	lastProcessedBlock = blocks[ancestor].Number
+	if err := eh.rollback(blocks[ancestor+1:], lastProcessedBlock, executeTasks); err != nil {
+		return 0, false, fmt.Errorf("roll back to block %d: %w", lastProcessedBlock, err)
+	}
+	return lastProcessedBlock, true, nil
+}
+
+func (eh *EventHandler) isCanonical(ctx context.Context, block *nodestorage.ProcessedBlock) (bool, error) {
+	if block.Hash == (ethcommon.Hash{}) {
+		// Blocks without a known hash can't be verified.
+		return true, nil
+	}
+	header, err := eh.headerByNumber(ctx, block.Number)
+	if err != nil {
+		return false, fmt.Errorf("get header of block %d: %w", block.Number, err)
+	}
+	return header.Hash() == block.Hash, nil
+}
+
+// headerByNumber fetches the header of the given block, retrying with a doubling backoff.
+func (eh *EventHandler) headerByNumber(ctx context.Context, blockNumber uint64) (*ethtypes.Header, error) {
+	backoff := headerFetchInitialBackoff
+	for attempt := 1; ; attempt++ {
+		header, err := eh.chain.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+		if err == nil {
+			return header, nil
+		}
+		if attempt == headerFetchAttempts {
+			return nil, err
+		}
+		eh.logger.Warn("could not get block header, retrying",
+			fields.BlockNumber(blockNumber),
+			zap.Int("attempt", attempt),
+			zap.Error(err))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+// rollback restores the database to how it was before the given blocks were processed,
+// and then reconciles the validators affected by their tasks.
+func (eh *EventHandler) rollback(blocks []*nodestorage.ProcessedBlock, lastProcessedBlock uint64, executeTasks bool) error {
+	logger := eh.logger.With(
+		fields.FromBlock(blocks[0].Number),
+		fields.ToBlock(blocks[len(blocks)-1].Number),
+		zap.Uint64("last_processed_block", lastProcessedBlock))
+	logger.Warn("reorg detected, rolling back processed blocks")
+
+	// Remember the affected shares before the rollback, to tell which tasks must be undone.
+	var owners []ethcommon.Address
+	sharesBefore := make(map[string]*ssvtypes.SSVShare)
+	for _, block := range blocks {
+		for _, pubKey := range block.ValidatorPubKeys {
+			if _, ok := sharesBefore[string(pubKey)]; !ok {
+				sharesBefore[string(pubKey)] = eh.nodeStorage.Shares().Get(nil, pubKey)
+			}
+		}
+		owners = append(owners, block.Owners...)
+	}
+
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		undo := blocks[i].Undo
+		for j := len(undo) - 1; j >= 0; j-- {
+			var err error
+			if undo[j].Found {
+				err = txn.Set(nil, undo[j].Key, undo[j].Value)
+			} else {
+				err = txn.Delete(nil, undo[j].Key)
+			}
+			if err != nil {
+				return fmt.Errorf("undo block %d: %w", blocks[i].Number, err)
+			}
+		}
+		if err := eh.nodeStorage.DeleteProcessedBlock(txn, blocks[i].Number); err != nil {
+			return fmt.Errorf("delete processed block: %w", err)
+		}
+	}
+	if err := eh.nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(lastProcessedBlock)); err != nil {
+		return fmt.Errorf("set last processed block: %w", err)
+	}
+	// The tasks of the rolled back blocks are undone rather than retried.
+	failedTasks, err := eh.nodeStorage.GetFailedTasks(txn)
+	if err != nil {
+		return fmt.Errorf("get failed tasks: %w", err)
+	}
+	for _, failed := range failedTasks {
+		if failed.BlockNumber > lastProcessedBlock {
+			if err := eh.nodeStorage.DeleteFailedTask(txn, failed.ID); err != nil {
+				return fmt.Errorf("delete failed task: %w", err)
+			}
+		}
+	}
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	if err := eh.nodeStorage.Shares().Reload(); err != nil {
+		return fmt.Errorf("reload shares: %w", err)
+	}
+
+	tasks := eh.undoTasks(logger, sharesBefore, owners)
+	logger.Info("rolled back processed blocks", fields.Count(len(tasks)))
+	if !executeTasks {
+		return nil
+	}
+	for _, task := range tasks {
+		eh.executeTask(logger, task, lastProcessedBlock)
+	}
+	return nil
+}
+
+// undoTasks returns the tasks which bring the affected validators and owners
+// to their rolled back state, and removes the keys of rolled back shares.
+func (eh *EventHandler) undoTasks(logger *zap.Logger, sharesBefore map[string]*ssvtypes.SSVShare, owners []ethcommon.Address) []Task {
+	operatorID := eh.operatorDataStore.GetOperatorID()
+	active := func(share *ssvtypes.SSVShare) bool {
+		return share != nil && !share.Liquidated && share.BelongsToOperator(operatorID)
+	}
+
+	var tasks []Task
+	for pubKey, before := range sharesBefore {
+		after := eh.nodeStorage.Shares().Get(nil, []byte(pubKey))
+		switch {
+		case active(before) && after == nil:
+			tasks = append(tasks, NewStopValidatorTask(eh.taskExecutor, spectypes.ValidatorPK(pubKey)))
+		case active(before) && !active(after):
+			tasks = append(tasks, NewLiquidateClusterTask(eh.taskExecutor, after.OwnerAddress, committeeIDs(after), []*ssvtypes.SSVShare{after}))
+		case !active(before) && active(after) && before != nil:
+			tasks = append(tasks, NewReactivateClusterTask(eh.taskExecutor, after.OwnerAddress, committeeIDs(after), []*ssvtypes.SSVShare{after}))
+		case !active(before) && active(after):
+			// The share's key was removed along with it, so it must be synced again to be signed with.
+			logger.Warn("rolled back the removal of a validator, its share key is restored only by syncing again",
+				fields.PubKey([]byte(pubKey)))
+			tasks = append(tasks, NewStartValidatorTask(eh.taskExecutor, after))
+		}
+
+		// Shares which don't exist anymore mustn't be signed with.
+		if before != nil && after == nil && before.BelongsToOperator(operatorID) {
+			if err := eh.keyManager.RemoveShare(hex.EncodeToString(before.SharePubKey)); err != nil {
+				logger.Error("could not remove rolled back share key", fields.PubKey(before.ValidatorPubKey), zap.Error(err))
+			}
+		}
+	}
+
+	seen := make(map[ethcommon.Address]bool)
+	for _, owner := range owners {
+		if seen[owner] {
+			continue
+		}
+		seen[owner] = true
+
+		// Owners without a fee recipient receive the fees themselves.
+		recipient := owner
+		data, found, err := eh.nodeStorage.GetRecipientData(nil, owner)
+		if err != nil {
+			logger.Error("could not get rolled back fee recipient", fields.Owner(owner), zap.Error(err))
+			continue
+		}
+		if found {
+			recipient = ethcommon.Address(data.FeeRecipient)
+		}
+		tasks = append(tasks, NewUpdateFeeRecipientTask(eh.taskExecutor, owner, recipient))
+	}
+	return tasks
+}
+
+// journalProcessedBlock saves the given processed block so that it can be rolled back,
+// and deletes the processed blocks which are too old to be rolled back.
+func (eh *EventHandler) journalProcessedBlock(txn basedb.Txn, block *nodestorage.ProcessedBlock, tasks []Task) error {
+	for _, task := range tasks {
+		switch t := task.(type) {
+		case *StartValidatorTask:
+			block.ValidatorPubKeys = append(block.ValidatorPubKeys, t.share.ValidatorPubKey)
+		case *StopValidatorTask:
+			block.ValidatorPubKeys = append(block.ValidatorPubKeys, t.pubKey)
+		case *LiquidateClusterTask:
+			for _, share := range t.toLiquidate {
+				block.ValidatorPubKeys = append(block.ValidatorPubKeys, share.ValidatorPubKey)
+			}
+		case *ReactivateClusterTask:
+			for _, share := range t.toReactivate {
+				block.ValidatorPubKeys = append(block.ValidatorPubKeys, share.ValidatorPubKey)
+			}
+		case *UpdateFeeRecipientTask:
+			block.Owners = append(block.Owners, t.owner)
+		}
+	}
+	if err := eh.nodeStorage.SaveProcessedBlock(txn, block); err != nil {
+		return fmt.Errorf("save processed block: %w", err)
+	}
+
+	if block.Number <= eh.reorgDepth {
+		return nil
+	}
+	blocks, err := eh.nodeStorage.GetProcessedBlocks(txn)
+	if err != nil {
+		return fmt.Errorf("get processed blocks: %w", err)
+	}
+	for _, old := range blocks {
+		if old.Number >= block.Number-eh.reorgDepth {
+			break
+		}
+		if err := eh.nodeStorage.DeleteProcessedBlock(txn, old.Number); err != nil {
+			return fmt.Errorf("delete processed block: %w", err)
+		}
+	}
+	return nil
+}
+
+func committeeIDs(share *ssvtypes.SSVShare) []spectypes.OperatorID {
+	ids := make([]spectypes.OperatorID, 0, len(share.Committee))
+	for _, operator := range share.Committee {
+		ids = append(ids, operator.OperatorID)
+	}
+	return ids
+}
+
+// journalingTxn records the previous value of every key written through it,
+// so that the writes can be undone.
+type journalingTxn struct {
+	basedb.Txn
+	undo    []nodestorage.UndoEntry
+	written map[string]bool
+}
+
+func newJournalingTxn(txn basedb.Txn) *journalingTxn {
+	return &journalingTxn{
+		Txn:     txn,
+		written: make(map[string]bool),
+	}
+}
+
+func (t *journalingTxn) Set(prefix []byte, key []byte, value []byte) error {
+	if err := t.record(prefix, key); err != nil {
+		return err
+	}
+	return t.Txn.Set(prefix, key, value)
+}
+
+func (t *journalingTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	return t.Txn.SetMany(prefix, n, func(i int) (basedb.Obj, error) {
+		obj, err := next(i)
+		if err != nil {
+			return obj, err
+		}
+		return obj, t.record(prefix, obj.Key)
+	})
+}
+
+func (t *journalingTxn) Delete(prefix []byte, key []byte) error {
+	if err := t.record(prefix, key); err != nil {
+		return err
+	}
+	return t.Txn.Delete(prefix, key)
+}
+
+// record saves the value of the key before its first write.
+func (t *journalingTxn) record(prefix []byte, key []byte) error {
+	fullKey := make([]byte, 0, len(prefix)+len(key))
+	fullKey = append(append(fullKey, prefix...), key...)
+	if t.written[string(fullKey)] {
+		return nil
+	}
+	obj, found, err := t.Txn.Get(nil, fullKey)
+	if err != nil {
+		return fmt.Errorf("journal previous value: %w", err)
+	}
+	t.written[string(fullKey)] = true
+	t.undo = append(t.undo, nodestorage.UndoEntry{
+		Key:   fullKey,
+		Value: obj.Value,
+		Found: found,
+	})
+	return nil
+}
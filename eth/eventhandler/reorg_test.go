@@ -0,0 +1,214 @@
+package eventhandler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventparser"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestReorgRollback(t *testing.T) {
+	ops, err := createOperators(1, 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := zaptest.NewLogger(t)
+	eh, validatorCtrl, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+
+	chain := &testChain{hashes: map[uint64]ethcommon.Hash{}}
+	WithReorgProtection(chain, 2)(eh)
+
+	owner := ethcommon.HexToAddress("0x1")
+	recipient := ethcommon.HexToAddress("0x2")
+	blocks := []executionclient.BlockLogs{
+		chain.block(1, operatorAddedLog(t, 10, owner)),
+		chain.block(2, operatorAddedLog(t, 11, owner), feeRecipientUpdatedLog(t, owner, recipient)),
+	}
+	lastProcessedBlock, err := eh.HandleBlockEventsStream(blocksChan(blocks...), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lastProcessedBlock)
+
+	processed, err := eh.nodeStorage.GetProcessedBlocks(nil)
+	require.NoError(t, err)
+	require.Len(t, processed, 2)
+	require.Equal(t, []ethcommon.Address{owner}, processed[1].Owners)
+
+	t.Run("canonical", func(t *testing.T) {
+		lastProcessedBlock, reorged, err := eh.checkReorg(false)
+		require.NoError(t, err)
+		require.False(t, reorged)
+		require.Zero(t, lastProcessedBlock)
+	})
+
+	t.Run("header fetch fails once", func(t *testing.T) {
+		chain.failures, chain.requests = 1, 0
+		lastProcessedBlock, reorged, err := eh.checkReorg(false)
+		require.NoError(t, err)
+		require.False(t, reorged)
+		require.Zero(t, lastProcessedBlock)
+		require.Equal(t, 2, chain.requests)
+	})
+
+	t.Run("header fetch keeps failing", func(t *testing.T) {
+		chain.failures, chain.requests = headerFetchAttempts, 0
+		_, _, err := eh.checkReorg(false)
+		require.ErrorContains(t, err, "unavailable")
+		require.Equal(t, headerFetchAttempts, chain.requests)
+		chain.failures = 0
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		chain.hashes[2] = ethcommon.HexToHash("0xff")
+
+		validatorCtrl.EXPECT().UpdateFeeRecipient(owner, owner).Return(nil)
+		lastProcessedBlock, err := eh.HandleBlockEventsStream(blocksChan(chain.block(3)), true)
+		require.ErrorIs(t, err, ErrReorg)
+		require.Equal(t, uint64(1), lastProcessedBlock)
+
+		_, found, err := eh.nodeStorage.GetOperatorData(nil, 10)
+		require.NoError(t, err)
+		require.True(t, found)
+		_, found, err = eh.nodeStorage.GetOperatorData(nil, 11)
+		require.NoError(t, err)
+		require.False(t, found)
+		_, found, err = eh.nodeStorage.GetRecipientData(nil, owner)
+		require.NoError(t, err)
+		require.False(t, found)
+
+		stored, found, err := eh.nodeStorage.GetLastProcessedBlock(nil)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, uint64(1), stored.Uint64())
+
+		processed, err := eh.nodeStorage.GetProcessedBlocks(nil)
+		require.NoError(t, err)
+		require.Len(t, processed, 1)
+	})
+
+	t.Run("pruning", func(t *testing.T) {
+		lastProcessedBlock, err := eh.HandleBlockEventsStream(blocksChan(chain.block(2), chain.block(3), chain.block(4)), false)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), lastProcessedBlock)
+
+		processed, err := eh.nodeStorage.GetProcessedBlocks(nil)
+		require.NoError(t, err)
+		require.Len(t, processed, 3)
+		require.Equal(t, uint64(2), processed[0].Number)
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		for number := uint64(1); number <= 4; number++ {
+			chain.hashes[number] = ethcommon.BigToHash(new(big.Int).SetUint64(number + 100))
+		}
+		_, err := eh.HandleBlockEventsStream(blocksChan(chain.block(5)), false)
+		require.ErrorIs(t, err, ErrReorgTooDeep)
+	})
+}
+
+func TestJournalingTxn(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	prefix := []byte("p/")
+	require.NoError(t, db.Set(prefix, []byte("a"), []byte("1")))
+
+	txn := newJournalingTxn(db.Begin())
+	defer txn.Discard()
+	require.NoError(t, txn.Set(prefix, []byte("a"), []byte("2")))
+	require.NoError(t, txn.Set(prefix, []byte("a"), []byte("3")))
+	require.NoError(t, txn.SetMany(prefix, 1, func(int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte("b"), Value: []byte("1")}, nil
+	}))
+	require.NoError(t, txn.Delete(prefix, []byte("b")))
+
+	// Only the values before the first write are recorded.
+	require.Len(t, txn.undo, 2)
+	require.Equal(t, []byte("p/a"), txn.undo[0].Key)
+	require.Equal(t, []byte("1"), txn.undo[0].Value)
+	require.True(t, txn.undo[0].Found)
+	require.Equal(t, []byte("p/b"), txn.undo[1].Key)
+	require.False(t, txn.undo[1].Found)
+}
+
+// testChain serves the headers of the blocks it created, unless their hashes are overridden.
+type testChain struct {
+	hashes map[uint64]ethcommon.Hash
+
+	// failures is the number of header requests which fail before they succeed again.
+	failures int
+	requests int
+}
+
+func (c *testChain) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	c.requests++
+	if c.failures > 0 {
+		c.failures--
+		return nil, fmt.Errorf("unavailable")
+	}
+	return &ethtypes.Header{Number: number, Extra: c.hashes[number.Uint64()].Bytes()}, nil
+}
+
+func (c *testChain) block(number uint64, logs ...ethtypes.Log) executionclient.BlockLogs {
+	if _, ok := c.hashes[number]; !ok {
+		c.hashes[number] = ethcommon.Hash{}
+	}
+	header, _ := c.HeaderByNumber(context.Background(), new(big.Int).SetUint64(number))
+	return executionclient.BlockLogs{BlockNumber: number, BlockHash: header.Hash(), Logs: logs}
+}
+
+func blocksChan(blocks ...executionclient.BlockLogs) <-chan executionclient.BlockLogs {
+	ch := make(chan executionclient.BlockLogs, len(blocks))
+	for _, block := range blocks {
+		ch <- block
+	}
+	close(ch)
+	return ch
+}
+
+func operatorAddedLog(t *testing.T, id uint64, owner ethcommon.Address) ethtypes.Log {
+	publicKey, err := eventparser.PackOperatorPublicKey([]byte(fmt.Sprintf("pubkey-%d", id)))
+	require.NoError(t, err)
+	return packLog(t, OperatorAdded, []any{id, owner}, publicKey, big.NewInt(0))
+}
+
+func feeRecipientUpdatedLog(t *testing.T, owner, recipient ethcommon.Address) ethtypes.Log {
+	return packLog(t, FeeRecipientAddressUpdated, []any{owner}, recipient)
+}
+
+func packLog(t *testing.T, name string, indexed []any, data ...any) ethtypes.Log {
+	contractABI, err := contract.ContractMetaData.GetAbi()
+	require.NoError(t, err)
+	event := contractABI.Events[name]
+
+	query := make([][]any, len(indexed))
+	for i, value := range indexed {
+		query[i] = []any{value}
+	}
+	topics, err := abi.MakeTopics(query...)
+	require.NoError(t, err)
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+
+	log := ethtypes.Log{Topics: []ethcommon.Hash{event.ID}, Data: packed}
+	for _, topic := range topics {
+		log.Topics = append(log.Topics, topic[0])
+	}
+	return log
+}
@@ -0,0 +1,74 @@
+package eventhandler
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+// ValidatorAddedTopics returns the topics of the ValidatorAdded events of the given owners.
+func ValidatorAddedTopics(owners []ethcommon.Address) ([][]ethcommon.Hash, error) {
+	contractABI, err := contract.ContractMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	ownerTopics := make([]ethcommon.Hash, 0, len(owners))
+	for _, owner := range owners {
+		ownerTopics = append(ownerTopics, ethcommon.BytesToHash(owner.Bytes()))
+	}
+	return [][]ethcommon.Hash{{contractABI.Events[ValidatorAdded].ID}, ownerTopics}, nil
+}
+
+// RecoverShareKeys adds the keys of the operator's saved shares to the key manager,
+// from the given ValidatorAdded events which created them. It's needed after importing
+// a registry snapshot, which doesn't include the keys. It returns the number of recovered keys.
+func (eh *EventHandler) RecoverShareKeys(logs <-chan executionclient.BlockLogs) (int, error) {
+	operatorID := eh.operatorDataStore.GetOperatorID()
+	recovered := make(map[string]bool)
+	var err error
+	for blockLogs := range logs {
+		// Keep draining the logs after an error, so that fetching isn't blocked.
+		if err != nil {
+			continue
+		}
+		for _, log := range blockLogs.Logs {
+			event, parseErr := eh.eventParser.ParseValidatorAdded(log)
+			if parseErr != nil {
+				continue
+			}
+			share := eh.nodeStorage.Shares().Get(nil, event.PublicKey)
+			if share == nil || share.OwnerAddress != event.Owner || !share.BelongsToOperator(operatorID) ||
+				recovered[string(share.SharePubKey)] {
+				continue
+			}
+
+			operatorCount := len(event.OperatorIds)
+			pubKeysOffset := phase0.PublicKeyLength*operatorCount + phase0.SignatureLength
+			if len(event.Shares) != encryptedKeyLength*operatorCount+pubKeysOffset {
+				continue
+			}
+			sharePublicKeys := splitBytes(event.Shares[phase0.SignatureLength:pubKeysOffset], phase0.PublicKeyLength)
+			encryptedKeys := splitBytes(event.Shares[pubKeysOffset:], encryptedKeyLength)
+
+			// A validator may have been registered again, so only the event which created the saved share is used.
+			created, shareSecret, convertErr := eh.validatorAddedEventToShare(event, sharePublicKeys, encryptedKeys)
+			if convertErr != nil || shareSecret == nil || !bytes.Equal(created.SharePubKey, share.SharePubKey) {
+				continue
+			}
+			if err = eh.keyManager.AddShare(shareSecret); err != nil {
+				err = fmt.Errorf("could not add share secret to key manager: %w", err)
+				break
+			}
+			recovered[string(share.SharePubKey)] = true
+			eh.logger.Debug("recovered share key", fields.PubKey(share.ValidatorPubKey), zap.Uint64("block", blockLogs.BlockNumber))
+		}
+	}
+	return len(recovered), err
+}
@@ -0,0 +1,91 @@
+package eventhandler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	"github.com/bloxapp/ssv/networkconfig"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/utils"
+)
+
+func TestRecoverShareKeysFromSnapshot(t *testing.T) {
+	ops, err := createOperators(4, 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	currentSlot := &utils.SlotValue{}
+	currentSlot.SetSlot(100)
+	network := &networkconfig.NetworkConfig{
+		Beacon: utils.SetupMockBeaconNetwork(t, currentSlot),
+	}
+
+	logger := zaptest.NewLogger(t)
+	exporter, _, err := setupEventHandler(t, ctx, logger, network, ops[0], true)
+	require.NoError(t, err)
+	importer, _, err := setupEventHandler(t, ctx, logger, network, ops[1], true)
+	require.NoError(t, err)
+
+	// The exporter's registry has the operators and a validator added by its event.
+	operatorIDs := make([]uint64, 0, len(ops))
+	for _, op := range ops {
+		encodedPubKey, err := op.privateKey.Public().Base64()
+		require.NoError(t, err)
+		_, err = exporter.nodeStorage.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:           op.id,
+			PublicKey:    encodedPubKey,
+			OwnerAddress: testAddr,
+		})
+		require.NoError(t, err)
+		operatorIDs = append(operatorIDs, op.id)
+	}
+
+	validatorData, err := createNewValidator(ops)
+	require.NoError(t, err)
+	sharesData, err := generateSharesData(validatorData, ops, testAddr, 0)
+	require.NoError(t, err)
+	log := packLog(t, ValidatorAdded, []any{testAddr},
+		operatorIDs, validatorData.masterPubKey.Serialize(), sharesData, contract.ISSVNetworkCoreCluster{Balance: big.NewInt(0)})
+	event, err := exporter.eventParser.ParseValidatorAdded(log)
+	require.NoError(t, err)
+
+	operatorCount := len(event.OperatorIds)
+	pubKeysOffset := phase0.PublicKeyLength*operatorCount + phase0.SignatureLength
+	share, _, err := exporter.validatorAddedEventToShare(event,
+		splitBytes(event.Shares[phase0.SignatureLength:pubKeysOffset], phase0.PublicKeyLength),
+		splitBytes(event.Shares[pubKeysOffset:], encryptedKeyLength))
+	require.NoError(t, err)
+	require.NoError(t, exporter.nodeStorage.Shares().Save(nil, share))
+	require.NoError(t, exporter.nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(1)))
+
+	// Another operator of the validator imports the exporter's snapshot.
+	snapshot, err := exporter.nodeStorage.ExportRegistrySnapshot("testnet")
+	require.NoError(t, err)
+	importerPubKey, err := ops[1].privateKey.Public().Base64()
+	require.NoError(t, err)
+	require.NoError(t, importer.nodeStorage.ImportRegistrySnapshot(snapshot, importerPubKey))
+
+	ownShares := importer.nodeStorage.Shares().List(nil, registrystorage.ByOperatorID(ops[1].id))
+	require.Len(t, ownShares, 1)
+	require.Equal(t, validatorData.operatorsShares[1].pub.Serialize(), ownShares[0].SharePubKey)
+
+	recovered, err := importer.RecoverShareKeys(blocksChan(executionclient.BlockLogs{BlockNumber: 1, Logs: []ethtypes.Log{log}}))
+	require.NoError(t, err)
+	require.Equal(t, 1, recovered)
+
+	accounts, err := importer.keyManager.(ekm.StorageProvider).ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.True(t, shareExist(accounts, validatorData.operatorsShares[1].pub.Serialize()))
+}
@@ -0,0 +1,59 @@
+package eventhandler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
+)
+
+var ErrUnknownEvent = errors.New("unknown registry event")
+
+// handleUnknownEvent records an event which isn't in the contract's ABI, presumably added by a contract upgrade.
+// Skipping it could desynchronize the registry from other operators, so it's returned as an error if halting is enabled.
+func (eh *EventHandler) handleUnknownEvent(log ethtypes.Log) error {
+	logger := eh.logger.With(
+		zap.String("topic", log.Topics[0].String()),
+		fields.BlockNumber(log.BlockNumber),
+		fields.TxHash(log.TxHash),
+	)
+
+	// It's saved outside the block's transaction, so that it's recorded even if syncing is halted.
+	err := eh.nodeStorage.SaveUnknownEvent(nil, &nodestorage.UnknownEvent{
+		Topic:       log.Topics[0],
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+		SeenAt:      time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not save unknown event: %w", err)
+	}
+	eh.reportUnknownEvents()
+
+	if eh.haltOnUnknownEvents {
+		logger.Error("halting on unknown registry event, the node must be upgraded to process it")
+		return fmt.Errorf("%w %s in tx %s", ErrUnknownEvent, log.Topics[0], log.TxHash)
+	}
+	logger.Error("skipped unknown registry event, the node may have to be upgraded to process it")
+	return nil
+}
+
+// UnknownEvents returns the recorded events which aren't in the contract's ABI.
+func (eh *EventHandler) UnknownEvents() ([]*nodestorage.UnknownEvent, error) {
+	return eh.nodeStorage.GetUnknownEvents(nil)
+}
+
+func (eh *EventHandler) reportUnknownEvents() {
+	events, err := eh.nodeStorage.GetUnknownEvents(nil)
+	if err != nil {
+		return
+	}
+	eh.metrics.UnknownEvents(len(events))
+}
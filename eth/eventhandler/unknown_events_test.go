@@ -0,0 +1,71 @@
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/eth/executionclient"
+)
+
+func TestHandleUnknownEvents(t *testing.T) {
+	ops, err := createOperators(1, 0)
+	require.NoError(t, err)
+
+	unknownTopic := ethcommon.HexToHash("0x1234")
+	blockLogs := func(blockNumber uint64) <-chan executionclient.BlockLogs {
+		logs := make(chan executionclient.BlockLogs, 1)
+		logs <- executionclient.BlockLogs{
+			BlockNumber: blockNumber,
+			Logs: []ethtypes.Log{{
+				Topics:      []ethcommon.Hash{unknownTopic},
+				BlockNumber: blockNumber,
+				TxHash:      ethcommon.HexToHash("0x5678"),
+				Index:       3,
+			}},
+		}
+		close(logs)
+		return logs
+	}
+
+	t.Run("skip unknown event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		eh, _, err := setupEventHandler(t, ctx, zaptest.NewLogger(t), nil, ops[0], false)
+		require.NoError(t, err)
+
+		lastProcessedBlock, err := eh.HandleBlockEventsStream(blockLogs(10), false)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), lastProcessedBlock)
+
+		events, err := eh.UnknownEvents()
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, unknownTopic, events[0].Topic)
+		require.Equal(t, uint64(10), events[0].BlockNumber)
+		require.Equal(t, uint(3), events[0].LogIndex)
+	})
+
+	t.Run("halt on unknown event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		eh, _, err := setupEventHandler(t, ctx, zaptest.NewLogger(t), nil, ops[0], false)
+		require.NoError(t, err)
+		eh.haltOnUnknownEvents = true
+
+		_, err = eh.HandleBlockEventsStream(blockLogs(10), false)
+		require.ErrorIs(t, err, ErrUnknownEvent)
+
+		// The block wasn't processed, but the event is recorded.
+		_, found, err := eh.nodeStorage.GetLastProcessedBlock(nil)
+		require.NoError(t, err)
+		require.False(t, found)
+		events, err := eh.UnknownEvents()
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+	})
+}
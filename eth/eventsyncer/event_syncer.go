@@ -10,6 +10,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/eth/eventhandler"
 	"github.com/bloxapp/ssv/eth/executionclient"
 	"github.com/bloxapp/ssv/logging/fields"
 	nodestorage "github.com/bloxapp/ssv/operator/storage"
@@ -86,7 +87,30 @@ func (es *EventSyncer) Healthy(ctx context.Context) error {
 }
 
 // SyncHistory reads and processes historical events since the given fromBlock.
+// If a reorg is rolled back meanwhile, it syncs again from the last canonical block.
 func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastProcessedBlock uint64, err error) {
+	rolledBack := false
+	for {
+		lastProcessedBlock, err = es.syncHistory(ctx, fromBlock)
+		if errors.Is(err, eventhandler.ErrReorg) {
+			es.logger.Warn("rolled back reorged blocks, syncing historical events again",
+				zap.Uint64("last_processed_block", lastProcessedBlock))
+			fromBlock = lastProcessedBlock + 1
+			rolledBack = true
+			continue
+		}
+		if rolledBack && errors.Is(err, executionclient.ErrNothingToSync) {
+			// Ongoing sync must continue from the block after the last canonical block.
+			return fromBlock - 1, nil
+		}
+		return lastProcessedBlock, err
+	}
+}
+
+func (es *EventSyncer) syncHistory(ctx context.Context, fromBlock uint64) (lastProcessedBlock uint64, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	fetchLogs, fetchError, err := es.executionClient.FetchHistoricalLogs(ctx, fromBlock)
 	if errors.Is(err, executionclient.ErrNothingToSync) {
 		// Nothing to sync, should keep ongoing sync from the given fromBlock.
@@ -97,6 +121,10 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 	}
 
 	lastProcessedBlock, err = es.eventHandler.HandleBlockEventsStream(fetchLogs, false)
+	if errors.Is(err, eventhandler.ErrReorg) {
+		stopFetching(cancel, fetchLogs)
+		return lastProcessedBlock, err
+	}
 	if err != nil {
 		return 0, fmt.Errorf("handle historical block events: %w", err)
 	}
@@ -120,10 +148,30 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 }
 
 // SyncOngoing streams and processes ongoing events as they come since the given fromBlock.
+// If a reorg is rolled back meanwhile, it streams again from the last canonical block.
 func (es *EventSyncer) SyncOngoing(ctx context.Context, fromBlock uint64) error {
-	es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
+	for {
+		es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		logs := es.executionClient.StreamLogs(streamCtx, fromBlock)
+		lastProcessedBlock, err := es.eventHandler.HandleBlockEventsStream(logs, true)
+		if !errors.Is(err, eventhandler.ErrReorg) {
+			cancel()
+			return err
+		}
+		stopFetching(cancel, logs)
+
+		es.logger.Warn("rolled back reorged blocks, streaming ongoing events again",
+			zap.Uint64("last_processed_block", lastProcessedBlock))
+		fromBlock = lastProcessedBlock + 1
+	}
+}
 
-	logs := es.executionClient.StreamLogs(ctx, fromBlock)
-	_, err := es.eventHandler.HandleBlockEventsStream(logs, true)
-	return err
+// stopFetching cancels fetching and drains the remaining logs,
+// so that the fetching goroutine isn't blocked.
+func stopFetching(cancel context.CancelFunc, logs <-chan executionclient.BlockLogs) {
+	cancel()
+	for range logs {
+	}
 }
@@ -8,6 +8,13 @@ import (
 
 // ExecutionOptions contains config configurations related to Ethereum execution client.
 type ExecutionOptions struct {
-	Addr              string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"Execution client WebSocket address"`
-	ConnectionTimeout time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"Execution client connection timeout"`
+	Addr                string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"Execution client WebSocket address, or multiple semicolon separated addresses in order of preference"`
+	ConnectionTimeout   time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"Execution client connection timeout"`
+	FollowDistance      uint64        `yaml:"ETH1FollowDistance" env:"ETH_1_FOLLOW_DISTANCE" env-default:"8" env-description:"Number of blocks to wait before processing registry events"`
+	Quorum              int           `yaml:"ETH1Quorum" env:"ETH_1_QUORUM" env-default:"1" env-description:"Number of execution clients which must return the same registry events"`
+	LogBatchSize        uint64        `yaml:"ETH1LogBatchSize" env:"ETH_1_LOG_BATCH_SIZE" env-default:"5000" env-description:"Maximum number of blocks of which registry events are fetched at once, reduced while the execution client's limits are exceeded"`
+	LogFetchWorkers     int           `yaml:"ETH1LogFetchWorkers" env:"ETH_1_LOG_FETCH_WORKERS" env-default:"4" env-description:"Number of batches of registry events fetched in parallel"`
+	ReorgDepth          uint64        `yaml:"ETH1ReorgDepth" env:"ETH_1_REORG_DEPTH" env-default:"64" env-description:"Number of processed blocks which can be rolled back in case of a reorg (0 to disable)"`
+	SyncFinalized       bool          `yaml:"ETH1SyncFinalized" env:"ETH_1_SYNC_FINALIZED" env-default:"false" env-description:"Process registry events up to the consensus client's finalized checkpoint, falling back to the follow distance when it's unavailable"`
+	HaltOnUnknownEvents bool          `yaml:"ETH1HaltOnUnknownEvents" env:"ETH_1_HALT_ON_UNKNOWN_EVENTS" env-default:"false" env-description:"Halt syncing on registry events which aren't in the contract's ABI, instead of skipping them"`
 }
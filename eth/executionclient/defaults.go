@@ -10,5 +10,6 @@ const (
 	DefaultReconnectionMaxInterval     = 64 * time.Second
 	DefaultFollowDistance              = 8
 	DefaultHistoricalLogsBatchSize     = 5000
+	DefaultLogFetchWorkers             = 4
 	defaultLogBuf                      = 8 * 1024
 )
@@ -0,0 +1,259 @@
+package executionclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+// ErrNoQuorum is returned when not enough endpoints agree on a result.
+var ErrNoQuorum = errors.New("endpoints didn't reach quorum")
+
+// addrSeparator separates the endpoints in the node address.
+const addrSeparator = ";"
+
+// endpoint is one of the execution clients, which is dialed lazily.
+type endpoint struct {
+	addr string
+
+	mu     sync.Mutex
+	client *ethclient.Client
+}
+
+func parseEndpoints(nodeAddr string) []*endpoint {
+	var endpoints []*endpoint
+	for _, addr := range strings.Split(nodeAddr, addrSeparator) {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			endpoints = append(endpoints, &endpoint{addr: addr})
+		}
+	}
+	return endpoints
+}
+
+// dial returns the endpoint's client, dialing it if it isn't connected.
+func (e *endpoint) dial(ctx context.Context) (*ethclient.Client, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		return e.client, nil
+	}
+	client, err := ethclient.DialContext(ctx, e.addr)
+	if err != nil {
+		return nil, err
+	}
+	e.client = client
+	return client, nil
+}
+
+// reset closes the endpoint's client, so that it's dialed again on next use.
+func (e *endpoint) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		e.client.Close()
+		e.client = nil
+	}
+}
+
+// checkSynced returns an error if the client is syncing or doesn't respond.
+func checkSynced(ctx context.Context, client *ethclient.Client) error {
+	sp, err := client.SyncProgress(ctx)
+	if err != nil {
+		return err
+	}
+	if sp != nil {
+		return fmt.Errorf("syncing")
+	}
+	return nil
+}
+
+// quorumBlockNumber returns the highest block number which at least quorum endpoints have reached.
+func (ec *ExecutionClient) quorumBlockNumber(ctx context.Context) (uint64, error) {
+	results := ec.queryEndpoints(ctx, func(ctx context.Context, client *ethclient.Client) (any, error) {
+		return client.BlockNumber(ctx)
+	})
+
+	var numbers []uint64
+	for _, result := range results {
+		if result.err == nil {
+			numbers = append(numbers, result.value.(uint64))
+		}
+	}
+	if len(numbers) < ec.quorum {
+		return 0, fmt.Errorf("%w: %d of %d endpoints returned the current block", ErrNoQuorum, len(numbers), ec.quorum)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+	return numbers[ec.quorum-1], nil
+}
+
+// quorumFilterLogs returns the logs which at least quorum endpoints returned for the given query.
+func (ec *ExecutionClient) quorumFilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	results := ec.queryEndpoints(ctx, func(ctx context.Context, client *ethclient.Client) (any, error) {
+		return client.FilterLogs(ctx, query)
+	})
+
+	votes := make(map[[sha256.Size]byte][]int)
+	for i, result := range results {
+		if result.err != nil {
+			ec.logger.Warn("failed to fetch logs from endpoint", fields.Address(ec.endpoints[i].addr), zap.Error(result.err))
+			continue
+		}
+		digest := logsDigest(result.value.([]ethtypes.Log))
+		votes[digest] = append(votes[digest], i)
+	}
+
+	// Several groups can reach a quorum which isn't a majority, and none of them is chosen.
+	var agreed []int
+	for _, voters := range votes {
+		if len(voters) < ec.quorum {
+			continue
+		}
+		if agreed != nil {
+			return nil, fmt.Errorf("%w: endpoints returned different logs from block %d to %d",
+				ErrNoQuorum, query.FromBlock.Uint64(), query.ToBlock.Uint64())
+		}
+		agreed = voters
+	}
+	if agreed == nil {
+		return nil, fmt.Errorf("%w: no %d endpoints returned the same logs from block %d to %d",
+			ErrNoQuorum, ec.quorum, query.FromBlock.Uint64(), query.ToBlock.Uint64())
+	}
+
+	for i := range results {
+		if results[i].err == nil && !containsIndex(agreed, i) {
+			ec.logger.Warn("endpoint disagrees with quorum on logs",
+				fields.Address(ec.endpoints[i].addr),
+				fields.FromBlock(query.FromBlock.Uint64()),
+				fields.ToBlock(query.ToBlock.Uint64()))
+		}
+	}
+	return results[agreed[0]].value.([]ethtypes.Log), nil
+}
+
+// quorumHeaderByNumber returns the header of the given block which at least quorum endpoints returned.
+func (ec *ExecutionClient) quorumHeaderByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Header, error) {
+	results := ec.queryEndpoints(ctx, func(ctx context.Context, client *ethclient.Client) (any, error) {
+		return client.HeaderByNumber(ctx, blockNumber)
+	})
+
+	votes := make(map[ethcommon.Hash][]int)
+	for i, result := range results {
+		if result.err != nil {
+			ec.logger.Warn("failed to fetch header from endpoint", fields.Address(ec.endpoints[i].addr), zap.Error(result.err))
+			continue
+		}
+		hash := result.value.(*ethtypes.Header).Hash()
+		votes[hash] = append(votes[hash], i)
+	}
+
+	var agreed []int
+	for _, voters := range votes {
+		if len(voters) < ec.quorum {
+			continue
+		}
+		if agreed != nil {
+			return nil, fmt.Errorf("%w: endpoints returned different headers of block %s", ErrNoQuorum, blockNumber)
+		}
+		agreed = voters
+	}
+	if agreed == nil {
+		return nil, fmt.Errorf("%w: no %d endpoints returned the same header of block %s", ErrNoQuorum, ec.quorum, blockNumber)
+	}
+	return results[agreed[0]].value.(*ethtypes.Header), nil
+}
+
+type endpointResult struct {
+	value any
+	err   error
+}
+
+// queryEndpoints calls the given function with every endpoint concurrently,
+// and returns the results in the order of the endpoints.
+func (ec *ExecutionClient) queryEndpoints(ctx context.Context, f func(context.Context, *ethclient.Client) (any, error)) []endpointResult {
+	results := make([]endpointResult, len(ec.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range ec.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+
+			dialCtx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
+			client, err := e.dial(dialCtx)
+			cancel()
+			if err != nil {
+				results[i].err = fmt.Errorf("dial: %w", err)
+				return
+			}
+			results[i].value, results[i].err = f(ctx, client)
+			if results[i].err != nil && ctx.Err() == nil && client != ec.activeClient() {
+				// Dial again on next use, in case the connection is broken.
+				e.reset()
+			}
+		}(i, e)
+	}
+	wg.Wait()
+	return results
+}
+
+// logsDigest hashes the given logs, including where they were emitted.
+func logsDigest(logs []ethtypes.Log) [sha256.Size]byte {
+	h := sha256.New()
+	var buf [8]byte
+	writeUint := func(v uint64) {
+		binary.BigEndian.PutUint64(buf[:], v)
+		h.Write(buf[:])
+	}
+	writeBytes := func(b []byte) {
+		writeUint(uint64(len(b)))
+		h.Write(b)
+	}
+
+	writeUint(uint64(len(logs)))
+	for _, log := range logs {
+		writeUint(log.BlockNumber)
+		h.Write(log.BlockHash[:])
+		h.Write(log.TxHash[:])
+		writeUint(uint64(log.TxIndex))
+		writeUint(uint64(log.Index))
+		h.Write(log.Address[:])
+		writeUint(uint64(len(log.Topics)))
+		for _, topic := range log.Topics {
+			h.Write(topic[:])
+		}
+		writeBytes(log.Data)
+		if log.Removed {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	}
+
+	var digest [sha256.Size]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
+
+func containsIndex(indexes []int, i int) bool {
+	for _, index := range indexes {
+		if index == i {
+			return true
+		}
+	}
+	return false
+}
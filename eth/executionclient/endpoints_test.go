@@ -0,0 +1,178 @@
+package executionclient
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/eth/simulator"
+)
+
+func TestFailover(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sim, contractAddr := simCallable(t, 3, 0)
+	addr := serveSim(t, sim)
+
+	// The first endpoint is down.
+	client, err := New(ctx, "ws://127.0.0.1:1;"+addr, contractAddr, WithLogger(logger), WithFollowDistance(0))
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, 1, client.active)
+	require.NoError(t, client.Healthy(ctx))
+
+	logs, fetchErrCh, err := client.FetchHistoricalLogs(ctx, 0)
+	require.NoError(t, err)
+	var fetched int
+	for block := range logs {
+		fetched += len(block.Logs)
+	}
+	require.NoError(t, <-fetchErrCh)
+	require.Equal(t, 3, fetched)
+}
+
+func TestQuorum(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	honest, contractAddr := simCallable(t, 3, 0)
+	honestAddr1, honestAddr2 := serveSim(t, honest), serveSim(t, honest)
+
+	// The lying endpoint hides an event at the same height.
+	liar, liarContractAddr := simCallable(t, 2, 1)
+	require.Equal(t, contractAddr, liarContractAddr)
+	liarAddr := serveSim(t, liar)
+
+	fetch := func(client *ExecutionClient) (int, error) {
+		logs, fetchErrCh, err := client.FetchHistoricalLogs(ctx, 0)
+		if err != nil {
+			return 0, err
+		}
+		var fetched int
+		for block := range logs {
+			fetched += len(block.Logs)
+		}
+		return fetched, <-fetchErrCh
+	}
+
+	t.Run("agreed", func(t *testing.T) {
+		nodeAddr := strings.Join([]string{liarAddr, honestAddr1, honestAddr2}, ";")
+		client, err := New(ctx, nodeAddr, contractAddr, WithLogger(logger), WithFollowDistance(0), WithQuorum(2))
+		require.NoError(t, err)
+		defer client.Close()
+
+		fetched, err := fetch(client)
+		require.NoError(t, err)
+		require.Equal(t, 3, fetched)
+	})
+
+	t.Run("not agreed", func(t *testing.T) {
+		nodeAddr := strings.Join([]string{honestAddr1, liarAddr}, ";")
+		client, err := New(ctx, nodeAddr, contractAddr, WithLogger(logger), WithFollowDistance(0), WithQuorum(2))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = fetch(client)
+		require.ErrorIs(t, err, ErrNoQuorum)
+	})
+
+	t.Run("several quorums", func(t *testing.T) {
+		// Each of the two groups of endpoints reaches the quorum, which isn't a majority.
+		nodeAddr := strings.Join([]string{liarAddr, serveSim(t, liar), honestAddr1, honestAddr2}, ";")
+		client, err := New(ctx, nodeAddr, contractAddr, WithLogger(logger), WithFollowDistance(0), WithQuorum(2))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = fetch(client)
+		require.ErrorIs(t, err, ErrNoQuorum)
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		// The lying endpoint is the active one.
+		nodeAddr := strings.Join([]string{liarAddr, honestAddr1, honestAddr2}, ";")
+		client, err := New(ctx, nodeAddr, contractAddr, WithLogger(logger), WithFollowDistance(0), WithQuorum(2))
+		require.NoError(t, err)
+		defer client.Close()
+		require.Equal(t, 0, client.active)
+
+		blockNumber := big.NewInt(3)
+		honestHeader, err := honest.HeaderByNumber(ctx, blockNumber)
+		require.NoError(t, err)
+		liarHeader, err := liar.HeaderByNumber(ctx, blockNumber)
+		require.NoError(t, err)
+		require.NotEqual(t, honestHeader.Hash(), liarHeader.Hash())
+
+		header, err := client.HeaderByNumber(ctx, blockNumber)
+		require.NoError(t, err)
+		require.Equal(t, honestHeader.Hash(), header.Hash())
+
+		// Blocks without logs are emitted with the header agreed on too.
+		logs, fetchErrCh := client.FetchFilteredLogs(ctx, 3, 3, [][]ethcommon.Hash{{{1}}})
+		var blocks []BlockLogs
+		for block := range logs {
+			blocks = append(blocks, block)
+		}
+		require.NoError(t, <-fetchErrCh)
+		require.Len(t, blocks, 1)
+		require.Empty(t, blocks[0].Logs)
+		require.Equal(t, honestHeader.Hash(), blocks[0].BlockHash)
+
+		nodeAddr = strings.Join([]string{honestAddr1, liarAddr}, ";")
+		client, err = New(ctx, nodeAddr, contractAddr, WithLogger(logger), WithFollowDistance(0), WithQuorum(2))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.HeaderByNumber(ctx, blockNumber)
+		require.ErrorIs(t, err, ErrNoQuorum)
+	})
+
+	t.Run("invalid quorum", func(t *testing.T) {
+		_, err := New(ctx, honestAddr1, contractAddr, WithQuorum(2))
+		require.Error(t, err)
+	})
+}
+
+// simCallable deploys the callable contract, commits the given number of empty blocks,
+// and then calls the contract the given number of times, each in a block.
+func simCallable(t *testing.T, calls, emptyBlocks int) (*simulator.SimulatedBackend, ethcommon.Address) {
+	sim := simTestBackend(testAddr)
+	t.Cleanup(func() { _ = sim.Close() })
+
+	parsed, err := abi.JSON(strings.NewReader(callableAbi))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	require.NoError(t, err)
+	contractAddr, _, contract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim)
+	require.NoError(t, err)
+	sim.Commit()
+
+	for i := 0; i < emptyBlocks; i++ {
+		sim.Commit()
+	}
+	for i := 0; i < calls; i++ {
+		_, err := contract.Transact(auth, "Call")
+		require.NoError(t, err)
+		sim.Commit()
+	}
+	return sim, contractAddr
+}
+
+func serveSim(t *testing.T, sim *simulator.SimulatedBackend) string {
+	rpcServer, err := sim.Node.RPCHandler()
+	require.NoError(t, err)
+	httpsrv := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	t.Cleanup(httpsrv.Close)
+	return httpToWebSocketURL(httpsrv.URL)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -27,29 +28,38 @@ var (
 )
 
 // ExecutionClient represents a client for interacting with Ethereum execution client.
+// It uses one endpoint at a time, and fails over to the next healthy one when it fails.
+// With a quorum, logs are only processed once enough endpoints agree on them.
 type ExecutionClient struct {
 	// mandatory
-	nodeAddr        string
+	endpoints       []*endpoint
 	contractAddress ethcommon.Address
 
 	// optional
 	logger                      *zap.Logger
 	metrics                     metrics
-	followDistance              uint64 // TODO: consider reading the finalized checkpoint from consensus layer
+	followDistance              uint64
+	finalizedBlockProvider      FinalizedBlockProvider
 	connectionTimeout           time.Duration
 	reconnectionInitialInterval time.Duration
 	reconnectionMaxInterval     time.Duration
 	logBatchSize                uint64
+	logFetchWorkers             int
+	quorum                      int
 
 	// variables
-	client *ethclient.Client
-	closed chan struct{}
+	mu            sync.RWMutex
+	client        *ethclient.Client // client of the active endpoint
+	active        int
+	logBatchSizer *logBatchSizer
+	closed        chan struct{}
 }
 
 // New creates a new instance of ExecutionClient.
+// The nodeAddr may list multiple endpoints separated by semicolons, in order of preference.
 func New(ctx context.Context, nodeAddr string, contractAddr ethcommon.Address, opts ...Option) (*ExecutionClient, error) {
 	client := &ExecutionClient{
-		nodeAddr:                    nodeAddr,
+		endpoints:                   parseEndpoints(nodeAddr),
 		contractAddress:             contractAddr,
 		logger:                      zap.NewNop(),
 		metrics:                     nopMetrics{},
@@ -57,12 +67,24 @@ func New(ctx context.Context, nodeAddr string, contractAddr ethcommon.Address, o
 		connectionTimeout:           DefaultConnectionTimeout,
 		reconnectionInitialInterval: DefaultReconnectionInitialInterval,
 		reconnectionMaxInterval:     DefaultReconnectionMaxInterval,
-		logBatchSize:                DefaultHistoricalLogsBatchSize, // TODO Make batch of logs adaptive depending on "websocket: read limit"
+		logBatchSize:                DefaultHistoricalLogsBatchSize,
+		logFetchWorkers:             DefaultLogFetchWorkers,
+		quorum:                      1,
 		closed:                      make(chan struct{}),
 	}
 	for _, opt := range opts {
 		opt(client)
 	}
+	if len(client.endpoints) == 0 {
+		return nil, fmt.Errorf("no execution client address")
+	}
+	if client.quorum < 1 || client.quorum > len(client.endpoints) {
+		return nil, fmt.Errorf("quorum must be between 1 and the number of endpoints (%d)", len(client.endpoints))
+	}
+	if client.logBatchSize == 0 || client.logFetchWorkers < 1 {
+		return nil, fmt.Errorf("log batch size and fetch workers must be positive")
+	}
+	client.logBatchSizer = newLogBatchSizer(client.logBatchSize)
 	err := client.connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to execution client: %w", err)
@@ -73,21 +95,20 @@ func New(ctx context.Context, nodeAddr string, contractAddr ethcommon.Address, o
 // Close shuts down ExecutionClient.
 func (ec *ExecutionClient) Close() error {
 	close(ec.closed)
-	ec.client.Close()
+	for _, e := range ec.endpoints {
+		e.reset()
+	}
 	return nil
 }
 
 // FetchHistoricalLogs retrieves historical logs emitted by the contract starting from fromBlock.
 func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock uint64) (logs <-chan BlockLogs, errors <-chan error, err error) {
-	currentBlock, err := ec.client.BlockNumber(ctx)
+	currentBlock, err := ec.blockNumber(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get current block: %w", err)
 	}
-	if currentBlock < ec.followDistance {
-		return nil, nil, ErrNothingToSync
-	}
-	toBlock := currentBlock - ec.followDistance
-	if toBlock < fromBlock {
+	toBlock, ok := ec.syncHorizon(ctx, currentBlock)
+	if !ok || toBlock < fromBlock {
 		return nil, nil, ErrNothingToSync
 	}
 
@@ -95,83 +116,14 @@ func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock ui
 	return
 }
 
+// FetchFilteredLogs retrieves the logs emitted by the contract between the given blocks, which match the given topics.
+func (ec *ExecutionClient) FetchFilteredLogs(ctx context.Context, fromBlock, toBlock uint64, topics [][]ethcommon.Hash) (logs <-chan BlockLogs, errors <-chan error) {
+	return ec.fetchFilteredLogsInBatches(ctx, fromBlock, toBlock, topics)
+}
+
 // Calls FilterLogs multiple times and batches results to avoid fetching enormous amount of events
 func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, endBlock uint64) (<-chan BlockLogs, <-chan error) {
-	logs := make(chan BlockLogs, defaultLogBuf)
-	errors := make(chan error, 1)
-
-	go func() {
-		defer close(logs)
-		defer close(errors)
-
-		if startBlock > endBlock {
-			errors <- ErrBadInput
-			return
-		}
-
-		for fromBlock := startBlock; fromBlock <= endBlock; fromBlock += ec.logBatchSize {
-			toBlock := fromBlock + ec.logBatchSize - 1
-			if toBlock > endBlock {
-				toBlock = endBlock
-			}
-
-			start := time.Now()
-			results, err := ec.client.FilterLogs(ctx, ethereum.FilterQuery{
-				Addresses: []ethcommon.Address{ec.contractAddress},
-				FromBlock: new(big.Int).SetUint64(fromBlock),
-				ToBlock:   new(big.Int).SetUint64(toBlock),
-			})
-			if err != nil {
-				errors <- err
-				return
-			}
-
-			ec.logger.Info("fetched registry events",
-				fields.FromBlock(fromBlock),
-				fields.ToBlock(toBlock),
-				zap.Uint64("target_block", endBlock),
-				zap.String("progress", fmt.Sprintf("%.2f%%", float64(toBlock-startBlock+1)/float64(endBlock-startBlock+1)*100)),
-				zap.Int("events", len(results)),
-				fields.Took(time.Since(start)),
-			)
-
-			select {
-			case <-ctx.Done():
-				errors <- ctx.Err()
-				return
-
-			case <-ec.closed:
-				errors <- ErrClosed
-				return
-
-			default:
-				validLogs := make([]ethtypes.Log, 0, len(results))
-				for _, log := range results {
-					if log.Removed {
-						// This shouldn't happen unless there was a reorg!
-						ec.logger.Warn("log is removed",
-							zap.String("block_hash", log.BlockHash.Hex()),
-							fields.TxHash(log.TxHash),
-							zap.Uint("log_index", log.Index))
-						continue
-					}
-					validLogs = append(validLogs, log)
-				}
-				if len(validLogs) == 0 {
-					// Emit empty block logs to indicate that we have advanced to this block.
-					logs <- BlockLogs{BlockNumber: toBlock}
-				} else {
-					for _, blockLogs := range PackLogs(validLogs) {
-						logs <- blockLogs
-					}
-				}
-			}
-		}
-
-		ec.metrics.ExecutionClientLastFetchedBlock(endBlock)
-	}()
-
-	return logs, errors
+	return ec.fetchFilteredLogsInBatches(ctx, startBlock, endBlock, nil)
 }
 
 // StreamLogs subscribes to events emitted by the contract.
@@ -228,7 +180,18 @@ func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
 	defer cancel()
 
-	sp, err := ec.client.SyncProgress(ctx)
+	sp, err := ec.activeClient().SyncProgress(ctx)
+	if err == nil && sp == nil {
+		ec.metrics.ExecutionClientReady()
+		return nil
+	}
+
+	// Fail over to another endpoint if there's a healthy one.
+	if len(ec.endpoints) > 1 && ec.failover(ctx) == nil {
+		ec.metrics.ExecutionClientReady()
+		return nil
+	}
+
 	if err != nil {
 		ec.metrics.ExecutionClientFailure()
 		return err
@@ -245,7 +208,38 @@ func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 }
 
 func (ec *ExecutionClient) BlockByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Block, error) {
-	return ec.client.BlockByNumber(ctx, blockNumber)
+	return ec.activeClient().BlockByNumber(ctx, blockNumber)
+}
+
+// HeaderByNumber returns the header of the canonical block with the given number,
+// which enough endpoints agree on if there's a quorum.
+func (ec *ExecutionClient) HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Header, error) {
+	if ec.quorum > 1 {
+		return ec.quorumHeaderByNumber(ctx, blockNumber)
+	}
+	return ec.activeClient().HeaderByNumber(ctx, blockNumber)
+}
+
+func (ec *ExecutionClient) activeClient() *ethclient.Client {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+	return ec.client
+}
+
+// blockNumber returns the current block, which enough endpoints reached if there's a quorum.
+func (ec *ExecutionClient) blockNumber(ctx context.Context) (uint64, error) {
+	if ec.quorum > 1 {
+		return ec.quorumBlockNumber(ctx)
+	}
+	return ec.activeClient().BlockNumber(ctx)
+}
+
+// filterLogs returns the logs matching the given query, which enough endpoints agree on if there's a quorum.
+func (ec *ExecutionClient) filterLogs(ctx context.Context, query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	if ec.quorum > 1 {
+		return ec.quorumFilterLogs(ctx, query)
+	}
+	return ec.activeClient().FilterLogs(ctx, query)
 }
 
 func (ec *ExecutionClient) isClosed() bool {
@@ -259,11 +253,10 @@ func (ec *ExecutionClient) isClosed() bool {
 
 // streamLogsToChan streams ongoing logs from the given block to the given channel.
 // streamLogsToChan *always* returns the last block it fetched, even if it errored.
-// TODO: consider handling "websocket: read limit exceeded" error and reducing batch size (syncSmartContractsEvents has code for this)
 func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- BlockLogs, fromBlock uint64) (lastBlock uint64, err error) {
 	heads := make(chan *ethtypes.Header)
 
-	sub, err := ec.client.SubscribeNewHead(ctx, heads)
+	sub, err := ec.activeClient().SubscribeNewHead(ctx, heads)
 	if err != nil {
 		return fromBlock, fmt.Errorf("subscribe heads: %w", err)
 	}
@@ -284,11 +277,20 @@ func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- Blo
 			return fromBlock, fmt.Errorf("subscription: %w", err)
 
 		case header := <-heads:
-			if header.Number.Uint64() < ec.followDistance {
-				continue
+			currentBlock := header.Number.Uint64()
+			if ec.quorum > 1 {
+				// Don't go past the block which enough endpoints reached, as they would disagree.
+				quorumBlock, err := ec.quorumBlockNumber(ctx)
+				if err != nil {
+					ec.logger.Warn("could not get current block from quorum", zap.Error(err))
+					continue
+				}
+				if quorumBlock < currentBlock {
+					currentBlock = quorumBlock
+				}
 			}
-			toBlock := header.Number.Uint64() - ec.followDistance
-			if toBlock < fromBlock {
+			toBlock, ok := ec.syncHorizon(ctx, currentBlock)
+			if !ok || toBlock < fromBlock {
 				continue
 			}
 			logStream, fetchErrors := ec.fetchLogsInBatches(ctx, fromBlock, toBlock)
@@ -306,52 +308,117 @@ func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- Blo
 	}
 }
 
-// connect connects to Ethereum execution client.
+// connect connects to the first endpoint, in order of preference, which is healthy.
+// If none is healthy, it connects to the first one which can be dialed.
 // It must not be called twice in parallel.
 func (ec *ExecutionClient) connect(ctx context.Context) error {
-	logger := ec.logger.With(fields.Address(ec.nodeAddr))
-
 	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
 	defer cancel()
 
 	start := time.Now()
-	var err error
-	ec.client, err = ethclient.DialContext(ctx, ec.nodeAddr)
-	if err != nil {
-		return err
+	fallback := -1
+	var errs []error
+	for i, e := range ec.endpoints {
+		logger := ec.logger.With(fields.Address(e.addr))
+
+		client, err := e.dial(ctx)
+		if err != nil {
+			logger.Warn("could not connect to execution client", zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", e.addr, err))
+			continue
+		}
+		if len(ec.endpoints) > 1 {
+			if err := checkSynced(ctx, client); err != nil {
+				logger.Warn("execution client is not healthy", zap.Error(err))
+				if fallback == -1 {
+					fallback = i
+				}
+				continue
+			}
+		}
+
+		ec.setActive(i, client)
+		logger.Info("connected to execution client", zap.Duration("took", time.Since(start)))
+		return nil
 	}
 
-	logger.Info("connected to execution client", zap.Duration("took", time.Since(start)))
-	return nil
+	if fallback != -1 {
+		client, err := ec.endpoints[fallback].dial(ctx)
+		if err == nil {
+			ec.setActive(fallback, client)
+			ec.logger.Warn("connected to unhealthy execution client, as none is healthy",
+				fields.Address(ec.endpoints[fallback].addr))
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// failover switches to the first healthy endpoint other than the active one.
+func (ec *ExecutionClient) failover(ctx context.Context) error {
+	ec.mu.RLock()
+	active := ec.active
+	ec.mu.RUnlock()
+
+	for i, e := range ec.endpoints {
+		if i == active {
+			continue
+		}
+		client, err := e.dial(ctx)
+		if err != nil {
+			continue
+		}
+		if err := checkSynced(ctx, client); err != nil {
+			continue
+		}
+
+		ec.setActive(i, client)
+		ec.logger.Warn("failed over to another execution client",
+			zap.String("from", ec.endpoints[active].addr),
+			zap.String("to", e.addr))
+		return nil
+	}
+	return fmt.Errorf("no healthy execution client to fail over to")
+}
+
+func (ec *ExecutionClient) setActive(i int, client *ethclient.Client) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	ec.active = i
+	ec.client = client
 }
 
-// reconnect tries to reconnect multiple times with an exponent interval.
+// reconnect tries to reconnect multiple times with an exponent interval, failing over to other endpoints.
 // It panics when reconnecting limit is reached.
 // It must not be called twice in parallel.
 func (ec *ExecutionClient) reconnect(ctx context.Context) {
-	logger := ec.logger.With(fields.Address(ec.nodeAddr))
+	// Drop the failed connection, so that it's dialed again.
+	ec.mu.RLock()
+	ec.endpoints[ec.active].reset()
+	ec.mu.RUnlock()
 
 	start := time.Now()
 	tasks.ExecWithInterval(func(lastTick time.Duration) (stop bool, cont bool) {
-		logger.Info("reconnecting")
+		ec.logger.Info("reconnecting")
 		if err := ec.connect(ctx); err != nil {
 			if ec.isClosed() {
 				return true, false
 			}
 			// continue until reaching to limit, and then panic as Ethereum execution client connection is required
 			if lastTick >= ec.reconnectionMaxInterval {
-				logger.Panic("failed to reconnect", zap.Error(err))
+				ec.logger.Panic("failed to reconnect", zap.Error(err))
 			} else {
-				logger.Warn("could not reconnect, still trying", zap.Error(err))
+				ec.logger.Warn("could not reconnect, still trying", zap.Error(err))
 			}
 			return false, false
 		}
 		return true, false
 	}, ec.reconnectionInitialInterval, ec.reconnectionMaxInterval+(ec.reconnectionInitialInterval))
 
-	logger.Info("reconnected to execution client", zap.Duration("took", time.Since(start)))
+	ec.logger.Info("reconnected to execution client", zap.Duration("took", time.Since(start)))
 }
 
 func (ec *ExecutionClient) Filterer() (*contract.ContractFilterer, error) {
-	return contract.NewContractFilterer(ec.contractAddress, ec.client)
+	return contract.NewContractFilterer(ec.contractAddress, ec.activeClient())
 }
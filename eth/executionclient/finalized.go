@@ -0,0 +1,37 @@
+package executionclient
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// FinalizedBlockProvider provides the number of the latest finalized execution block,
+// such as the one of the consensus client's finalized checkpoint.
+type FinalizedBlockProvider interface {
+	FinalizedExecutionBlockNumber(ctx context.Context) (uint64, error)
+}
+
+// syncHorizon returns the last block to process given the current block, or false if there's none yet.
+// With a FinalizedBlockProvider, it's the latest finalized block, so that registry changes are processed
+// as soon as they're final. When that's unavailable, it falls back to the follow distance.
+func (ec *ExecutionClient) syncHorizon(ctx context.Context, currentBlock uint64) (uint64, bool) {
+	if ec.finalizedBlockProvider != nil {
+		finalizedBlock, err := ec.finalizedBlockProvider.FinalizedExecutionBlockNumber(ctx)
+		switch {
+		case err != nil:
+			ec.logger.Warn("could not get finalized block, falling back to follow distance", zap.Error(err))
+		case finalizedBlock == 0:
+			ec.logger.Warn("no finalized block yet, falling back to follow distance")
+		case finalizedBlock > currentBlock:
+			// The execution client is behind the consensus client.
+			return currentBlock, true
+		default:
+			return finalizedBlock, true
+		}
+	}
+	if currentBlock < ec.followDistance {
+		return 0, false
+	}
+	return currentBlock - ec.followDistance, true
+}
@@ -0,0 +1,55 @@
+package executionclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type finalizedBlockProviderFunc func(ctx context.Context) (uint64, error)
+
+func (f finalizedBlockProviderFunc) FinalizedExecutionBlockNumber(ctx context.Context) (uint64, error) {
+	return f(ctx)
+}
+
+func TestSyncHorizon(t *testing.T) {
+	ctx := context.Background()
+	newClient := func(provider FinalizedBlockProvider) *ExecutionClient {
+		return &ExecutionClient{
+			logger:                 zaptest.NewLogger(t),
+			followDistance:         8,
+			finalizedBlockProvider: provider,
+		}
+	}
+	finalizedBlock := func(block uint64, err error) FinalizedBlockProvider {
+		return finalizedBlockProviderFunc(func(ctx context.Context) (uint64, error) {
+			return block, err
+		})
+	}
+
+	tests := []struct {
+		name         string
+		provider     FinalizedBlockProvider
+		currentBlock uint64
+		toBlock      uint64
+		ok           bool
+	}{
+		{name: "follow distance", currentBlock: 100, toBlock: 92, ok: true},
+		{name: "follow distance before enough blocks", currentBlock: 5, ok: false},
+		{name: "finalized block", provider: finalizedBlock(70, nil), currentBlock: 100, toBlock: 70, ok: true},
+		{name: "finalized block within follow distance", provider: finalizedBlock(96, nil), currentBlock: 100, toBlock: 96, ok: true},
+		{name: "finalized block ahead of execution client", provider: finalizedBlock(120, nil), currentBlock: 100, toBlock: 100, ok: true},
+		{name: "fallback without finalized block", provider: finalizedBlock(0, nil), currentBlock: 100, toBlock: 92, ok: true},
+		{name: "fallback on error", provider: finalizedBlock(70, fmt.Errorf("unavailable")), currentBlock: 100, toBlock: 92, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toBlock, ok := newClient(tt.provider).syncHorizon(ctx, tt.currentBlock)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.toBlock, toBlock)
+		})
+	}
+}
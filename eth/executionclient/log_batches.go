@@ -0,0 +1,271 @@
+package executionclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+// limitExceededCode is the JSON-RPC error code of requests exceeding the provider's limits (EIP-1474).
+const limitExceededCode = -32005
+
+// logLimitErrors are the messages of the errors returned by providers
+// when fetching the logs of too many blocks or with too many results.
+var logLimitErrors = []string{
+	"read limit",
+	"limit exceeded",
+	"more than",
+	"too many results",
+	"too large",
+	"response size",
+	"block range",
+	"range is too",
+	"limited to",
+}
+
+// isLogLimitError returns whether the given error is returned when fetching too many logs at once.
+func isLogLimitError(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == limitExceededCode {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, limitErr := range logLimitErrors {
+		if strings.Contains(msg, limitErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// logBatchSizer adapts the number of blocks of which logs are fetched at once to the provider's limits.
+// The size is halved when a batch exceeds the limits, and grows back gradually up to the maximum.
+type logBatchSizer struct {
+	mu   sync.Mutex
+	size uint64
+	max  uint64
+}
+
+func newLogBatchSizer(max uint64) *logBatchSizer {
+	return &logBatchSizer{size: max, max: max}
+}
+
+func (s *logBatchSizer) get() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// shrink reduces the size below the size of the failed batch. Batches failing in parallel
+// with the same size therefore only shrink it once.
+func (s *logBatchSizer) shrink(failed uint64) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if half := failed / 2; half < s.size {
+		s.size = half
+	}
+	if s.size == 0 {
+		s.size = 1
+	}
+	return s.size
+}
+
+// grow increases the size if a batch of the current size succeeded.
+func (s *logBatchSizer) grow(succeeded uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if succeeded < s.size {
+		return
+	}
+	s.size += s.size/4 + 1
+	if s.size > s.max {
+		s.size = s.max
+	}
+}
+
+// logBatch is a range of blocks fetched by a worker.
+type logBatch struct {
+	fromBlock uint64
+	toBlock   uint64
+	result    chan logBatchResult
+}
+
+type logBatchResult struct {
+	logs   []BlockLogs
+	events int
+	took   time.Duration
+	err    error
+}
+
+// fetchFilteredLogsInBatches fetches the logs of the given blocks in batches by multiple workers,
+// and delivers them in order of blocks.
+func (ec *ExecutionClient) fetchFilteredLogsInBatches(ctx context.Context, startBlock, endBlock uint64, topics [][]ethcommon.Hash) (<-chan BlockLogs, <-chan error) {
+	logs := make(chan BlockLogs, defaultLogBuf)
+	errors := make(chan error, 1)
+
+	go func() {
+		defer close(logs)
+		defer close(errors)
+
+		if startBlock > endBlock {
+			errors <- ErrBadInput
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Batches are queued in order for delivery, and handed to the workers as they're free.
+		// The queue limits how far ahead of the delivered blocks the workers fetch.
+		queue := make(chan *logBatch, ec.logFetchWorkers)
+		jobs := make(chan *logBatch)
+		go func() {
+			defer close(queue)
+			defer close(jobs)
+			for fromBlock := startBlock; fromBlock <= endBlock; {
+				toBlock := fromBlock + ec.logBatchSizer.get() - 1
+				if toBlock > endBlock || toBlock < fromBlock {
+					toBlock = endBlock
+				}
+				batch := &logBatch{fromBlock: fromBlock, toBlock: toBlock, result: make(chan logBatchResult, 1)}
+				select {
+				case queue <- batch:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case jobs <- batch:
+				case <-ctx.Done():
+					return
+				}
+				if toBlock == endBlock {
+					return
+				}
+				fromBlock = toBlock + 1
+			}
+		}()
+		for i := 0; i < ec.logFetchWorkers; i++ {
+			go func() {
+				for batch := range jobs {
+					batch.result <- ec.fetchLogBatch(ctx, batch.fromBlock, batch.toBlock, topics)
+				}
+			}()
+		}
+
+		for batch := range queue {
+			var result logBatchResult
+			select {
+			case result = <-batch.result:
+			case <-ctx.Done():
+				errors <- ctx.Err()
+				return
+			case <-ec.closed:
+				errors <- ErrClosed
+				return
+			}
+			if result.err != nil {
+				errors <- result.err
+				return
+			}
+
+			ec.logger.Info("fetched registry events",
+				fields.FromBlock(batch.fromBlock),
+				fields.ToBlock(batch.toBlock),
+				zap.Uint64("target_block", endBlock),
+				zap.String("progress", fmt.Sprintf("%.2f%%", float64(batch.toBlock-startBlock+1)/float64(endBlock-startBlock+1)*100)),
+				zap.Int("events", result.events),
+				fields.Took(result.took),
+			)
+
+			for _, blockLogs := range result.logs {
+				select {
+				case logs <- blockLogs:
+				case <-ctx.Done():
+					errors <- ctx.Err()
+					return
+				}
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			errors <- err
+			return
+		}
+
+		ec.metrics.ExecutionClientLastFetchedBlock(endBlock)
+	}()
+
+	return logs, errors
+}
+
+// fetchLogBatch fetches the logs of the given blocks, in smaller batches if they exceed the provider's limits.
+func (ec *ExecutionClient) fetchLogBatch(ctx context.Context, fromBlock, toBlock uint64, topics [][]ethcommon.Hash) logBatchResult {
+	start := time.Now()
+	var results []ethtypes.Log
+	for from := fromBlock; from <= toBlock; {
+		to := from + ec.logBatchSizer.get() - 1
+		if to > toBlock || to < from {
+			to = toBlock
+		}
+		batchResults, err := ec.filterLogs(ctx, ethereum.FilterQuery{
+			Addresses: []ethcommon.Address{ec.contractAddress},
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Topics:    topics,
+		})
+		if err != nil {
+			if to > from && ctx.Err() == nil && isLogLimitError(err) {
+				size := ec.logBatchSizer.shrink(to - from + 1)
+				ec.logger.Debug("registry events exceeded the execution client's limits, reducing batch size",
+					fields.FromBlock(from),
+					fields.ToBlock(to),
+					zap.Uint64("batch_size", size),
+					zap.Error(err))
+				continue
+			}
+			return logBatchResult{err: err}
+		}
+		ec.logBatchSizer.grow(to - from + 1)
+		results = append(results, batchResults...)
+		if to == toBlock {
+			break
+		}
+		from = to + 1
+	}
+
+	validLogs := make([]ethtypes.Log, 0, len(results))
+	for _, log := range results {
+		if log.Removed {
+			// This shouldn't happen unless there was a reorg!
+			ec.logger.Warn("log is removed",
+				zap.String("block_hash", log.BlockHash.Hex()),
+				fields.TxHash(log.TxHash),
+				zap.Uint("log_index", log.Index))
+			continue
+		}
+		validLogs = append(validLogs, log)
+	}
+	if len(validLogs) == 0 {
+		// Emit empty block logs to indicate that we have advanced to this block.
+		header, err := ec.HeaderByNumber(ctx, new(big.Int).SetUint64(toBlock))
+		if err != nil {
+			return logBatchResult{err: fmt.Errorf("failed to get header of block %d: %w", toBlock, err)}
+		}
+		return logBatchResult{
+			logs: []BlockLogs{{BlockNumber: toBlock, BlockHash: header.Hash()}},
+			took: time.Since(start),
+		}
+	}
+	return logBatchResult{logs: PackLogs(validLogs), events: len(results), took: time.Since(start)}
+}
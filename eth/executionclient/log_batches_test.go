@@ -0,0 +1,131 @@
+package executionclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/eth/simulator"
+)
+
+func TestIsLogLimitError(t *testing.T) {
+	require.True(t, isLogLimitError(errors.New("websocket: read limit exceeded")))
+	require.True(t, isLogLimitError(errors.New("query returned more than 10000 results")))
+	require.True(t, isLogLimitError(errors.New("Log response size exceeded.")))
+	require.True(t, isLogLimitError(errors.New("block range is too wide")))
+	require.True(t, isLogLimitError(limitExceededError{}))
+	require.False(t, isLogLimitError(errors.New("connection refused")))
+	require.False(t, isLogLimitError(ErrNoQuorum))
+}
+
+func TestLogBatchSizer(t *testing.T) {
+	sizer := newLogBatchSizer(100)
+	require.Equal(t, uint64(100), sizer.get())
+
+	// Parallel failures of the same size shrink it once.
+	require.Equal(t, uint64(50), sizer.shrink(100))
+	require.Equal(t, uint64(50), sizer.shrink(100))
+	require.Equal(t, uint64(1), sizer.shrink(1))
+
+	// Only batches of the current size grow it.
+	sizer.grow(1)
+	require.Equal(t, uint64(2), sizer.get())
+	for i := 0; i < 100; i++ {
+		sizer.grow(sizer.get())
+	}
+	require.Equal(t, uint64(100), sizer.get())
+}
+
+func TestAdaptiveLogBatches(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const calls = 30
+	sim, contractAddr := simCallable(t, calls, 0)
+
+	t.Run("within limits", func(t *testing.T) {
+		const maxRange = 4
+		addr := serveLimitedSim(t, sim, maxRange)
+		client, err := New(ctx, addr, contractAddr, WithLogger(logger), WithLogBatchSize(16), WithLogFetchWorkers(3))
+		require.NoError(t, err)
+		defer client.Close()
+
+		logs, errs := client.fetchLogsInBatches(ctx, 0, calls+1)
+		var fetched int
+		var lastBlock uint64
+		for block := range logs {
+			require.Greater(t, block.BlockNumber, lastBlock, "blocks must be delivered in order")
+			lastBlock = block.BlockNumber
+			fetched += len(block.Logs)
+		}
+		require.NoError(t, <-errs)
+		require.Equal(t, calls, fetched)
+		require.Equal(t, uint64(calls+1), lastBlock)
+		require.LessOrEqual(t, client.logBatchSizer.get(), uint64(maxRange))
+	})
+
+	t.Run("single block exceeds limits", func(t *testing.T) {
+		addr := serveLimitedSim(t, sim, 0)
+		client, err := New(ctx, addr, contractAddr, WithLogger(logger), WithLogFetchWorkers(2))
+		require.NoError(t, err)
+		defer client.Close()
+
+		logs, errs := client.fetchLogsInBatches(ctx, 0, calls+1)
+		for range logs {
+			require.Fail(t, "no logs should be delivered")
+		}
+		err = <-errs
+		require.Error(t, err)
+		require.True(t, isLogLimitError(err))
+	})
+}
+
+type limitExceededError struct{}
+
+func (limitExceededError) Error() string  { return "limit exceeded" }
+func (limitExceededError) ErrorCode() int { return limitExceededCode }
+
+// serveLimitedSim serves the simulator over HTTP, and fails requests for the logs of more than maxRange blocks.
+func serveLimitedSim(t *testing.T, sim *simulator.SimulatedBackend, maxRange uint64) string {
+	rpcServer, err := sim.Node.RPCHandler()
+	require.NoError(t, err)
+
+	httpsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []struct {
+				FromBlock hexutil.Uint64 `json:"fromBlock"`
+				ToBlock   hexutil.Uint64 `json:"toBlock"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(body, &req) == nil && req.Method == "eth_getLogs" && len(req.Params) == 1 &&
+			uint64(req.Params[0].ToBlock-req.Params[0].FromBlock)+1 > maxRange {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":%d,"message":"query returned more than %d results"}}`,
+				req.ID, limitExceededCode, maxRange)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		rpcServer.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpsrv.Close)
+	return httpsrv.URL
+}
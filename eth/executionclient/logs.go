@@ -3,12 +3,15 @@ package executionclient
 import (
 	"sort"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
-// BlockLogs holds a block's number and it's logs.
+// BlockLogs holds a block's number, hash and it's logs.
+// The hash may be empty if it's unknown, such as for local events.
 type BlockLogs struct {
 	BlockNumber uint64
+	BlockHash   ethcommon.Hash
 	Logs        []ethtypes.Log
 }
 
@@ -28,6 +31,7 @@ func PackLogs(logs []ethtypes.Log) []BlockLogs {
 		if len(all) == 0 || all[len(all)-1].BlockNumber != log.BlockNumber {
 			all = append(all, BlockLogs{
 				BlockNumber: log.BlockNumber,
+				BlockHash:   log.BlockHash,
 			})
 		}
 
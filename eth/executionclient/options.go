@@ -31,6 +31,14 @@ func WithFollowDistance(offset uint64) Option {
 	}
 }
 
+// WithFinalizedBlockProvider processes blocks up to the latest finalized block of the given provider,
+// falling back to the follow distance when it's unavailable.
+func WithFinalizedBlockProvider(provider FinalizedBlockProvider) Option {
+	return func(s *ExecutionClient) {
+		s.finalizedBlockProvider = provider
+	}
+}
+
 // WithConnectionTimeout sets timeout for network connection to eth1 node.
 func WithConnectionTimeout(timeout time.Duration) Option {
 	return func(s *ExecutionClient) {
@@ -52,9 +60,25 @@ func WithReconnectionMaxInterval(interval time.Duration) Option {
 	}
 }
 
-// WithLogBatchSize sets log batch size.
+// WithLogBatchSize sets the maximum log batch size,
+// which is reduced while batches exceed the execution client's limits.
 func WithLogBatchSize(size uint64) Option {
 	return func(s *ExecutionClient) {
 		s.logBatchSize = size
 	}
 }
+
+// WithLogFetchWorkers sets how many log batches are fetched in parallel.
+func WithLogFetchWorkers(workers int) Option {
+	return func(s *ExecutionClient) {
+		s.logFetchWorkers = workers
+	}
+}
+
+// WithQuorum sets how many endpoints must agree on logs before they're processed.
+// If several groups of endpoints agree on different logs, none of them are processed.
+func WithQuorum(quorum int) Option {
+	return func(s *ExecutionClient) {
+		s.quorum = quorum
+	}
+}
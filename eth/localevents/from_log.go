@@ -0,0 +1,71 @@
+package localevents
+
+import (
+	"fmt"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/bloxapp/ssv/eth/eventparser"
+)
+
+// FromLog returns the local event of the given registry contract log,
+// or nil if it isn't one of the events which the node handles.
+func FromLog(parser eventparser.Parser, log ethtypes.Log) (*Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil
+	}
+	abiEvent, err := parser.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, nil
+	}
+
+	var data interface{}
+	switch abiEvent.Name {
+	case "OperatorAdded":
+		event, parseErr := parser.ParseOperatorAdded(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	case "OperatorRemoved":
+		event, parseErr := parser.ParseOperatorRemoved(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	case "ValidatorAdded":
+		event, parseErr := parser.ParseValidatorAdded(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	case "ValidatorRemoved":
+		event, parseErr := parser.ParseValidatorRemoved(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	case "ClusterLiquidated":
+		event, parseErr := parser.ParseClusterLiquidated(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	case "ClusterReactivated":
+		event, parseErr := parser.ParseClusterReactivated(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	case "FeeRecipientAddressUpdated":
+		event, parseErr := parser.ParseFeeRecipientAddressUpdated(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	case "ValidatorExited":
+		event, parseErr := parser.ParseValidatorExited(log)
+		if err = parseErr; err == nil {
+			data = *event
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s event of tx %s: %w", abiEvent.Name, log.TxHash.Hex(), err)
+	}
+	return &Event{Name: abiEvent.Name, Data: data}, nil
+}
@@ -1,16 +1,24 @@
 package localevents
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"gopkg.in/yaml.v3"
 
 	"github.com/bloxapp/ssv/eth/contract"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 )
 
 // Event represents an eth1 event log in the system
@@ -20,22 +28,93 @@ type Event struct {
 	Name string
 	// Data is the parsed event
 	Data interface{}
+	// Nonce is the owner's nonce which the signature of a ValidatorAdded event was made with, if it's specified.
+	Nonce *registrystorage.Nonce
 }
 
+// Format is the format of a local events file.
+type Format int
+
+const (
+	FormatYAML Format = iota
+	FormatJSON
+)
+
+// FormatOf returns the format of the given local events file by its extension. Files which aren't JSON are YAML.
+func FormatOf(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// Load loads the events of the given local events file.
 func Load(path string) ([]Event, error) {
-	yamlFile, err := os.ReadFile(filepath.Clean(path))
+	data, err := os.ReadFile(filepath.Clean(path))
 	if err != nil {
 		return nil, err
 	}
+	return Parse(data, FormatOf(path))
+}
+
+// Parse parses local events in the given format. Unknown and missing fields are rejected,
+// and errors point to the line of the offending event and field.
+func Parse(data []byte, format Format) ([]Event, error) {
+	if format == FormatJSON {
+		// YAML accepts JSON, but also what isn't JSON.
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return nil, fmt.Errorf("line %d: %w", bytes.Count(data[:syntaxErr.Offset], []byte("\n"))+1, err)
+			}
+			return nil, err
+		}
+	}
 
-	var events []Event
-	if err := yaml.Unmarshal(yamlFile, &events); err != nil {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
 		return nil, err
 	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	list := root.Content[0]
+	if list.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: expected a list of events", list.Line)
+	}
 
+	events := make([]Event, 0, len(list.Content))
+	for i, item := range list.Content {
+		var event Event
+		if err := item.Decode(&event); err != nil {
+			return nil, fmt.Errorf("event %d at line %d: %w", i+1, item.Line, err)
+		}
+		events = append(events, event)
+	}
 	return events, nil
 }
 
+// Marshal encodes the given events in the given format, which Parse can read.
+func Marshal(events []Event, format Format) ([]byte, error) {
+	if events == nil {
+		events = []Event{}
+	}
+	if format == FormatJSON {
+		return json.MarshalIndent(events, "", "  ")
+	}
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(events); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
 type eventData interface {
 	toEventData() (interface{}, error)
 }
@@ -43,163 +122,430 @@ type eventData interface {
 type eventDataUnmarshaler struct {
 	name string
 	data eventData
+	node *yaml.Node
 }
 
 type OperatorAddedEventYAML struct {
-	ID        uint64 `yaml:"ID"`
-	Owner     string `yaml:"Owner"`
-	PublicKey string `yaml:"PublicKey"`
+	ID        uint64 `yaml:"ID" json:"ID"`
+	Owner     string `yaml:"Owner" json:"Owner"`
+	PublicKey string `yaml:"PublicKey" json:"PublicKey"`
 }
 
 type OperatorRemovedEventYAML struct {
-	ID uint64 `yaml:"ID"`
+	ID uint64 `yaml:"ID" json:"ID"`
 }
 
 type ValidatorAddedEventYAML struct {
-	PublicKey   string   `yaml:"PublicKey"`
-	Owner       string   `yaml:"Owner"`
-	OperatorIds []uint64 `yaml:"OperatorIds"`
-	Shares      string   `yaml:"Shares"`
+	PublicKey   string   `yaml:"PublicKey" json:"PublicKey"`
+	Owner       string   `yaml:"Owner" json:"Owner"`
+	OperatorIds []uint64 `yaml:"OperatorIds" json:"OperatorIds"`
+	// Shares are the signature, the share public keys and the encrypted keys, concatenated.
+	// Alternatively, they can be specified separately.
+	Shares          string   `yaml:"Shares,omitempty" json:"Shares,omitempty"`
+	Signature       string   `yaml:"Signature,omitempty" json:"Signature,omitempty"`
+	SharePublicKeys []string `yaml:"SharePublicKeys,omitempty" json:"SharePublicKeys,omitempty"`
+	EncryptedKeys   []string `yaml:"EncryptedKeys,omitempty" json:"EncryptedKeys,omitempty"`
+	// Nonce is the owner's nonce which the signature was made with. If it's specified, it's verified when the event is handled.
+	Nonce   *registrystorage.Nonce `yaml:"Nonce,omitempty" json:"Nonce,omitempty"`
+	Cluster *ClusterYAML           `yaml:"Cluster,omitempty" json:"Cluster,omitempty"`
 }
 
 type ValidatorRemovedEventYAML struct {
-	Owner       string   `yaml:"Owner"`
-	OperatorIds []uint64 `yaml:"OperatorIds"`
-	PublicKey   string   `yaml:"PublicKey"`
+	Owner       string       `yaml:"Owner" json:"Owner"`
+	OperatorIds []uint64     `yaml:"OperatorIds" json:"OperatorIds"`
+	PublicKey   string       `yaml:"PublicKey" json:"PublicKey"`
+	Cluster     *ClusterYAML `yaml:"Cluster,omitempty" json:"Cluster,omitempty"`
 }
 
 type ClusterLiquidatedEventYAML struct {
-	Owner       string   `yaml:"Owner"`
-	OperatorIds []uint64 `yaml:"OperatorIds"`
+	Owner       string       `yaml:"Owner" json:"Owner"`
+	OperatorIds []uint64     `yaml:"OperatorIds" json:"OperatorIds"`
+	Cluster     *ClusterYAML `yaml:"Cluster,omitempty" json:"Cluster,omitempty"`
 }
 
 type ClusterReactivatedEventYAML struct {
-	Owner       string   `yaml:"Owner"`
-	OperatorIds []uint64 `yaml:"OperatorIds"`
+	Owner       string       `yaml:"Owner" json:"Owner"`
+	OperatorIds []uint64     `yaml:"OperatorIds" json:"OperatorIds"`
+	Cluster     *ClusterYAML `yaml:"Cluster,omitempty" json:"Cluster,omitempty"`
 }
 
 type FeeRecipientAddressUpdatedEventYAML struct {
-	Owner            string `yaml:"Owner"`
-	RecipientAddress string `yaml:"RecipientAddress"`
+	Owner            string `yaml:"Owner" json:"Owner"`
+	RecipientAddress string `yaml:"RecipientAddress" json:"RecipientAddress"`
 }
 
 type ValidatorExitedEventYAML struct {
-	PublicKey   string   `yaml:"PublicKey"`
-	OperatorIds []uint64 `yaml:"OperatorIds"`
+	Owner       string   `yaml:"Owner" json:"Owner"`
+	PublicKey   string   `yaml:"PublicKey" json:"PublicKey"`
+	OperatorIds []uint64 `yaml:"OperatorIds" json:"OperatorIds"`
+}
+
+// ClusterYAML is the state of a cluster after an event.
+type ClusterYAML struct {
+	ValidatorCount  uint32 `yaml:"ValidatorCount" json:"ValidatorCount"`
+	NetworkFeeIndex uint64 `yaml:"NetworkFeeIndex" json:"NetworkFeeIndex"`
+	Index           uint64 `yaml:"Index" json:"Index"`
+	Active          bool   `yaml:"Active" json:"Active"`
+	// Balance is a decimal number of SSV wei.
+	Balance string `yaml:"Balance,omitempty" json:"Balance,omitempty"`
 }
 
 func (e *OperatorAddedEventYAML) toEventData() (interface{}, error) {
+	if e.ID == 0 {
+		return nil, missingField("ID")
+	}
+	owner, err := parseAddress("Owner", e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if e.PublicKey == "" {
+		return nil, missingField("PublicKey")
+	}
 	return contract.ContractOperatorAdded{
 		OperatorId: e.ID,
-		Owner:      ethcommon.HexToAddress(e.Owner),
+		Owner:      owner,
 		PublicKey:  []byte(e.PublicKey),
 	}, nil
 }
 
 func (e *OperatorRemovedEventYAML) toEventData() (interface{}, error) {
+	if e.ID == 0 {
+		return nil, missingField("ID")
+	}
 	return contract.ContractOperatorRemoved{
 		OperatorId: e.ID,
 	}, nil
 }
 
 func (e *ValidatorAddedEventYAML) toEventData() (interface{}, error) {
-	pubKey, err := hex.DecodeString(strings.TrimPrefix(e.PublicKey, "0x"))
+	pubKey, err := parseHex("PublicKey", e.PublicKey, phase0.PublicKeyLength)
 	if err != nil {
 		return nil, err
 	}
-
-	shares, err := hex.DecodeString(strings.TrimPrefix(e.Shares, "0x"))
+	owner, err := parseAddress("Owner", e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.OperatorIds) == 0 {
+		return nil, missingField("OperatorIds")
+	}
+	shares, err := e.shares()
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := e.Cluster.toCluster()
 	if err != nil {
 		return nil, err
 	}
 
 	return contract.ContractValidatorAdded{
 		PublicKey:   pubKey,
-		Owner:       ethcommon.HexToAddress(e.Owner),
+		Owner:       owner,
 		OperatorIds: e.OperatorIds,
 		Shares:      shares,
+		Cluster:     cluster,
 	}, nil
 }
 
+// shares returns the given shares, or concatenates them from their parts if they're specified separately.
+// Shares are used as is, so that malformed events can be specified as well.
+func (e *ValidatorAddedEventYAML) shares() ([]byte, error) {
+	separate := e.Signature != "" || len(e.SharePublicKeys) != 0 || len(e.EncryptedKeys) != 0
+	if e.Shares != "" {
+		if separate {
+			return nil, invalidField("Shares", errors.New("can't be specified along with Signature, SharePublicKeys and EncryptedKeys"))
+		}
+		return parseHex("Shares", e.Shares, 0)
+	}
+	if !separate {
+		return nil, missingField("Shares")
+	}
+
+	shares, err := parseHex("Signature", e.Signature, phase0.SignatureLength)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.SharePublicKeys) != len(e.OperatorIds) {
+		return nil, invalidField("SharePublicKeys", fmt.Errorf("%d keys for %d operators", len(e.SharePublicKeys), len(e.OperatorIds)))
+	}
+	for _, sharePubKey := range e.SharePublicKeys {
+		b, err := parseHex("SharePublicKeys", sharePubKey, phase0.PublicKeyLength)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, b...)
+	}
+	if len(e.EncryptedKeys) != len(e.OperatorIds) {
+		return nil, invalidField("EncryptedKeys", fmt.Errorf("%d keys for %d operators", len(e.EncryptedKeys), len(e.OperatorIds)))
+	}
+	for _, encryptedKey := range e.EncryptedKeys {
+		b, err := parseHex("EncryptedKeys", encryptedKey, 0)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, b...)
+	}
+	return shares, nil
+}
+
 func (e *ValidatorRemovedEventYAML) toEventData() (interface{}, error) {
+	owner, err := parseAddress("Owner", e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.OperatorIds) == 0 {
+		return nil, missingField("OperatorIds")
+	}
+	pubKey, err := parseHex("PublicKey", e.PublicKey, phase0.PublicKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := e.Cluster.toCluster()
+	if err != nil {
+		return nil, err
+	}
+
 	return contract.ContractValidatorRemoved{
-		Owner:       ethcommon.HexToAddress(e.Owner),
+		Owner:       owner,
 		OperatorIds: e.OperatorIds,
-		PublicKey:   []byte(strings.TrimPrefix(e.PublicKey, "0x")),
+		PublicKey:   pubKey,
+		Cluster:     cluster,
 	}, nil
 }
 
 func (e *ClusterLiquidatedEventYAML) toEventData() (interface{}, error) {
+	owner, err := parseAddress("Owner", e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.OperatorIds) == 0 {
+		return nil, missingField("OperatorIds")
+	}
+	cluster, err := e.Cluster.toCluster()
+	if err != nil {
+		return nil, err
+	}
+
 	return contract.ContractClusterLiquidated{
-		Owner:       ethcommon.HexToAddress(e.Owner),
+		Owner:       owner,
 		OperatorIds: e.OperatorIds,
+		Cluster:     cluster,
 	}, nil
 }
 
 func (e *ClusterReactivatedEventYAML) toEventData() (interface{}, error) {
+	owner, err := parseAddress("Owner", e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.OperatorIds) == 0 {
+		return nil, missingField("OperatorIds")
+	}
+	cluster, err := e.Cluster.toCluster()
+	if err != nil {
+		return nil, err
+	}
+
 	return contract.ContractClusterReactivated{
-		Owner:       ethcommon.HexToAddress(e.Owner),
+		Owner:       owner,
 		OperatorIds: e.OperatorIds,
+		Cluster:     cluster,
 	}, nil
 }
 
 func (e *FeeRecipientAddressUpdatedEventYAML) toEventData() (interface{}, error) {
+	owner, err := parseAddress("Owner", e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := parseAddress("RecipientAddress", e.RecipientAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	return contract.ContractFeeRecipientAddressUpdated{
-		Owner:            ethcommon.HexToAddress(e.Owner),
-		RecipientAddress: ethcommon.HexToAddress(e.RecipientAddress),
+		Owner:            owner,
+		RecipientAddress: recipient,
 	}, nil
 }
 
 func (e *ValidatorExitedEventYAML) toEventData() (interface{}, error) {
+	owner, err := parseAddress("Owner", e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := parseHex("PublicKey", e.PublicKey, phase0.PublicKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.OperatorIds) == 0 {
+		return nil, missingField("OperatorIds")
+	}
+
 	return contract.ContractValidatorExited{
-		PublicKey:   []byte(strings.TrimPrefix(e.PublicKey, "0x")),
+		Owner:       owner,
+		PublicKey:   pubKey,
 		OperatorIds: e.OperatorIds,
 	}, nil
 }
 
+func (c *ClusterYAML) toCluster() (contract.ISSVNetworkCoreCluster, error) {
+	if c == nil {
+		return contract.ISSVNetworkCoreCluster{}, nil
+	}
+	cluster := contract.ISSVNetworkCoreCluster{
+		ValidatorCount:  c.ValidatorCount,
+		NetworkFeeIndex: c.NetworkFeeIndex,
+		Index:           c.Index,
+		Active:          c.Active,
+	}
+	if c.Balance != "" {
+		balance, ok := new(big.Int).SetString(c.Balance, 10)
+		if !ok {
+			return contract.ISSVNetworkCoreCluster{}, invalidField("Cluster", fmt.Errorf("invalid balance %q", c.Balance))
+		}
+		cluster.Balance = balance
+	}
+	return cluster, nil
+}
+
+func (c *ClusterYAML) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkFields(value, c); err != nil {
+		return err
+	}
+	type plain ClusterYAML
+	return value.Decode((*plain)(c))
+}
+
+func newClusterYAML(cluster contract.ISSVNetworkCoreCluster) *ClusterYAML {
+	if cluster == (contract.ISSVNetworkCoreCluster{}) {
+		return nil
+	}
+	c := &ClusterYAML{
+		ValidatorCount:  cluster.ValidatorCount,
+		NetworkFeeIndex: cluster.NetworkFeeIndex,
+		Index:           cluster.Index,
+		Active:          cluster.Active,
+	}
+	if cluster.Balance != nil {
+		c.Balance = cluster.Balance.String()
+	}
+	return c
+}
+
+// newEventData returns the local event data of the given parsed event.
+func newEventData(data interface{}, nonce *registrystorage.Nonce) (eventData, error) {
+	switch d := data.(type) {
+	case contract.ContractOperatorAdded:
+		return &OperatorAddedEventYAML{
+			ID:        d.OperatorId,
+			Owner:     d.Owner.Hex(),
+			PublicKey: string(d.PublicKey),
+		}, nil
+	case contract.ContractOperatorRemoved:
+		return &OperatorRemovedEventYAML{
+			ID: d.OperatorId,
+		}, nil
+	case contract.ContractValidatorAdded:
+		e := &ValidatorAddedEventYAML{
+			PublicKey:   hexutil.Encode(d.PublicKey),
+			Owner:       d.Owner.Hex(),
+			OperatorIds: d.OperatorIds,
+			Nonce:       nonce,
+			Cluster:     newClusterYAML(d.Cluster),
+		}
+		// Well-formed shares are split into their parts, to be readable.
+		operatorCount := len(d.OperatorIds)
+		pubKeysOffset := phase0.SignatureLength + phase0.PublicKeyLength*operatorCount
+		if encryptedKeysLength := len(d.Shares) - pubKeysOffset; operatorCount == 0 || encryptedKeysLength <= 0 || encryptedKeysLength%operatorCount != 0 {
+			e.Shares = hexutil.Encode(d.Shares)
+			return e, nil
+		}
+		e.Signature = hexutil.Encode(d.Shares[:phase0.SignatureLength])
+		for i := 0; i < operatorCount; i++ {
+			offset := phase0.SignatureLength + phase0.PublicKeyLength*i
+			e.SharePublicKeys = append(e.SharePublicKeys, hexutil.Encode(d.Shares[offset:offset+phase0.PublicKeyLength]))
+		}
+		encryptedKeyLength := (len(d.Shares) - pubKeysOffset) / operatorCount
+		for i := 0; i < operatorCount; i++ {
+			offset := pubKeysOffset + encryptedKeyLength*i
+			e.EncryptedKeys = append(e.EncryptedKeys, hexutil.Encode(d.Shares[offset:offset+encryptedKeyLength]))
+		}
+		return e, nil
+	case contract.ContractValidatorRemoved:
+		return &ValidatorRemovedEventYAML{
+			Owner:       d.Owner.Hex(),
+			OperatorIds: d.OperatorIds,
+			PublicKey:   hexutil.Encode(d.PublicKey),
+			Cluster:     newClusterYAML(d.Cluster),
+		}, nil
+	case contract.ContractClusterLiquidated:
+		return &ClusterLiquidatedEventYAML{
+			Owner:       d.Owner.Hex(),
+			OperatorIds: d.OperatorIds,
+			Cluster:     newClusterYAML(d.Cluster),
+		}, nil
+	case contract.ContractClusterReactivated:
+		return &ClusterReactivatedEventYAML{
+			Owner:       d.Owner.Hex(),
+			OperatorIds: d.OperatorIds,
+			Cluster:     newClusterYAML(d.Cluster),
+		}, nil
+	case contract.ContractFeeRecipientAddressUpdated:
+		return &FeeRecipientAddressUpdatedEventYAML{
+			Owner:            d.Owner.Hex(),
+			RecipientAddress: d.RecipientAddress.Hex(),
+		}, nil
+	case contract.ContractValidatorExited:
+		return &ValidatorExitedEventYAML{
+			Owner:       d.Owner.Hex(),
+			PublicKey:   hexutil.Encode(d.PublicKey),
+			OperatorIds: d.OperatorIds,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown event data %T", data)
+	}
+}
+
 func (u *eventDataUnmarshaler) UnmarshalYAML(value *yaml.Node) error {
 	var err error
 	switch u.name {
 	case "OperatorAdded":
-		var v OperatorAddedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &OperatorAddedEventYAML{}
 	case "OperatorRemoved":
-		var v OperatorRemovedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &OperatorRemovedEventYAML{}
 	case "ValidatorAdded":
-		var v ValidatorAddedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &ValidatorAddedEventYAML{}
 	case "ValidatorRemoved":
-		var v ValidatorRemovedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &ValidatorRemovedEventYAML{}
 	case "ClusterLiquidated":
-		var v ClusterLiquidatedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &ClusterLiquidatedEventYAML{}
 	case "ClusterReactivated":
-		var v ClusterReactivatedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &ClusterReactivatedEventYAML{}
 	case "FeeRecipientAddressUpdated":
-		var v FeeRecipientAddressUpdatedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &FeeRecipientAddressUpdatedEventYAML{}
 	case "ValidatorExited":
-		var v ValidatorExitedEventYAML
-		err = value.Decode(&v)
-		u.data = &v
+		u.data = &ValidatorExitedEventYAML{}
 	default:
 		return errors.New("event unknown")
 	}
+	u.node = value
 
-	return err
+	if err = checkFields(value, u.data); err != nil {
+		return err
+	}
+	return value.Decode(u.data)
 }
 
 func (e *Event) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkFields(value, &struct {
+		Log  interface{} `yaml:"Log"`
+		Name string      `yaml:"Name"`
+		Data interface{} `yaml:"Data"`
+	}{}); err != nil {
+		return err
+	}
+
 	var evName struct {
 		Name string `yaml:"Name"`
 	}
@@ -224,9 +570,126 @@ func (e *Event) UnmarshalYAML(value *yaml.Node) error {
 	e.Name = ev.Data.name
 	data, err := ev.Data.data.toEventData()
 	if err != nil {
+		var fieldErr *fieldError
+		if errors.As(err, &fieldErr) {
+			return fmt.Errorf("line %d: %w", fieldLine(ev.Data.node, fieldErr.field), err)
+		}
 		return err
 	}
 	e.Data = data
+	if validatorAdded, ok := ev.Data.data.(*ValidatorAddedEventYAML); ok {
+		e.Nonce = validatorAdded.Nonce
+	}
 
 	return nil
 }
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	if len(node.Content) == 0 {
+		return errors.New("event is empty")
+	}
+	return e.UnmarshalYAML(node.Content[0])
+}
+
+type eventJSON struct {
+	Name string    `yaml:"Name" json:"Name"`
+	Data eventData `yaml:"Data" json:"Data"`
+}
+
+func (e Event) MarshalYAML() (interface{}, error) {
+	data, err := newEventData(e.Data, e.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	return eventJSON{Name: e.Name, Data: data}, nil
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	data, err := newEventData(e.Data, e.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(eventJSON{Name: e.Name, Data: data})
+}
+
+// fieldError is an error of an event's field, which is reported with the field's line.
+type fieldError struct {
+	field string
+	err   error
+}
+
+func missingField(field string) error {
+	return &fieldError{field: field}
+}
+
+func invalidField(field string, err error) error {
+	return &fieldError{field: field, err: err}
+}
+
+func (e *fieldError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("missing %s", e.field)
+	}
+	return fmt.Sprintf("invalid %s: %v", e.field, e.err)
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// fieldLine returns the line of the given field of a mapping node, or of the node if the field is missing.
+func fieldLine(node *yaml.Node, field string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == field {
+			return node.Content[i].Line
+		}
+	}
+	return node.Line
+}
+
+// checkFields fails if the given node isn't a mapping, or has fields which the given struct doesn't have.
+func checkFields(node *yaml.Node, v interface{}) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	known := make(map[string]bool)
+	t := reflect.TypeOf(v).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		known[strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]] = true
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i]; !known[key.Value] {
+			return fmt.Errorf("line %d: unknown field %q", key.Line, key.Value)
+		}
+	}
+	return nil
+}
+
+func parseAddress(field, s string) (ethcommon.Address, error) {
+	if s == "" {
+		return ethcommon.Address{}, missingField(field)
+	}
+	if !ethcommon.IsHexAddress(s) {
+		return ethcommon.Address{}, invalidField(field, fmt.Errorf("%q isn't an address", s))
+	}
+	return ethcommon.HexToAddress(s), nil
+}
+
+// parseHex decodes the given hex string, with or without the 0x prefix, of the given length if it's positive.
+func parseHex(field, s string, length int) ([]byte, error) {
+	if s == "" {
+		return nil, missingField(field)
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, invalidField(field, err)
+	}
+	if length > 0 && len(b) != length {
+		return nil, invalidField(field, fmt.Errorf("%d bytes instead of %d", len(b), length))
+	}
+	return b, nil
+}
@@ -1,12 +1,22 @@
 package localevents_test
 
 import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
-	"github.com/bloxapp/ssv/eth/contract"
-	"github.com/bloxapp/ssv/eth/localevents"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventparser"
+	"github.com/bloxapp/ssv/eth/localevents"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 )
 
 func TestLocalEventsUnmarshalYAML(t *testing.T) {
@@ -83,3 +93,157 @@ func TestUnmarshalYAMLOperatorAddedEvent(t *testing.T) {
 		require.EqualError(t, err, "yaml: unmarshal errors:\n  line 5: cannot unmarshal !!str `id` into uint64")
 	})
 }
+
+func TestLocalEventsFormats(t *testing.T) {
+	nonce := registrystorage.Nonce(3)
+	owner := ethcommon.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	pubKey := bytes.Repeat([]byte{1}, phase0.PublicKeyLength)
+	cluster := contract.ISSVNetworkCoreCluster{ValidatorCount: 2, NetworkFeeIndex: 3, Index: 4, Active: true, Balance: big.NewInt(5)}
+	shares := bytes.Repeat([]byte{2}, phase0.SignatureLength)
+	for i := 0; i < 4; i++ {
+		shares = append(shares, bytes.Repeat([]byte{3}, phase0.PublicKeyLength)...)
+	}
+	for i := 0; i < 4; i++ {
+		shares = append(shares, bytes.Repeat([]byte{4}, 256)...)
+	}
+	operatorIDs := []uint64{1, 2, 3, 4}
+
+	events := []localevents.Event{
+		{Name: "OperatorAdded", Data: contract.ContractOperatorAdded{OperatorId: 1, Owner: owner, PublicKey: []byte("LS0tLS1CRUdJTiBSU0E=")}},
+		{Name: "OperatorRemoved", Data: contract.ContractOperatorRemoved{OperatorId: 1}},
+		{Name: "ValidatorAdded", Data: contract.ContractValidatorAdded{Owner: owner, OperatorIds: operatorIDs, PublicKey: pubKey, Shares: shares, Cluster: cluster}, Nonce: &nonce},
+		{Name: "ValidatorAdded", Data: contract.ContractValidatorAdded{Owner: owner, OperatorIds: operatorIDs, PublicKey: pubKey, Shares: []byte{1, 2, 3}}},
+		{Name: "ValidatorRemoved", Data: contract.ContractValidatorRemoved{Owner: owner, OperatorIds: operatorIDs, PublicKey: pubKey, Cluster: cluster}},
+		{Name: "ClusterLiquidated", Data: contract.ContractClusterLiquidated{Owner: owner, OperatorIds: operatorIDs, Cluster: cluster}},
+		{Name: "ClusterReactivated", Data: contract.ContractClusterReactivated{Owner: owner, OperatorIds: operatorIDs}},
+		{Name: "FeeRecipientAddressUpdated", Data: contract.ContractFeeRecipientAddressUpdated{Owner: owner, RecipientAddress: ethcommon.HexToAddress("0x1")}},
+		{Name: "ValidatorExited", Data: contract.ContractValidatorExited{Owner: owner, OperatorIds: operatorIDs, PublicKey: pubKey}},
+	}
+
+	for _, format := range []localevents.Format{localevents.FormatYAML, localevents.FormatJSON} {
+		data, err := localevents.Marshal(events, format)
+		require.NoError(t, err)
+		parsed, err := localevents.Parse(data, format)
+		require.NoError(t, err)
+		require.Equal(t, events, parsed)
+	}
+
+	t.Run("shares are split", func(t *testing.T) {
+		data, err := localevents.Marshal(events[2:3], localevents.FormatYAML)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "Signature: 0x0202")
+		require.Contains(t, string(data), "SharePublicKeys:")
+		require.Contains(t, string(data), "EncryptedKeys:")
+		require.NotContains(t, string(data), "Shares:")
+	})
+
+	t.Run("load by extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.json")
+		data, err := localevents.Marshal(events, localevents.FormatJSON)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		loaded, err := localevents.Load(path)
+		require.NoError(t, err)
+		require.Equal(t, events, loaded)
+	})
+}
+
+func TestLocalEventsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		format localevents.Format
+		input  string
+		err    string
+	}{
+		{
+			name: "unknown field",
+			input: `
+- Name: OperatorRemoved
+  Data:
+    ID: 1
+    Owner: 0x97a6C1f3aaB5427B901fb135ED492749191C0f1F
+`,
+			err: "event 1 at line 2: line 5: unknown field \"Owner\"",
+		},
+		{
+			name: "missing field",
+			input: `
+- Name: OperatorRemoved
+  Data:
+    ID: 1
+- Name: FeeRecipientAddressUpdated
+  Data:
+    Owner: 0x97a6C1f3aaB5427B901fb135ED492749191C0f1F
+`,
+			err: "event 2 at line 5: line 7: missing RecipientAddress",
+		},
+		{
+			name: "invalid field",
+			input: `
+- Name: ValidatorExited
+  Data:
+    Owner: 0x97a6C1f3aaB5427B901fb135ED492749191C0f1F
+    OperatorIds: [1, 2, 3, 4]
+    PublicKey: 0x1234
+`,
+			err: "event 1 at line 2: line 6: invalid PublicKey: 2 bytes instead of 48",
+		},
+		{
+			name: "shares along with their parts",
+			input: `
+- Name: ValidatorAdded
+  Data:
+    Owner: 0x97a6C1f3aaB5427B901fb135ED492749191C0f1F
+    OperatorIds: [1]
+    PublicKey: 0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101
+    Shares: 0x1234
+    Signature: 0x1234
+`,
+			err: "event 1 at line 2: line 7: invalid Shares: can't be specified along with Signature, SharePublicKeys and EncryptedKeys",
+		},
+		{
+			name:   "invalid JSON",
+			format: localevents.FormatJSON,
+			input: `[
+  {"Name": "OperatorRemoved", "Data": {"ID": 1}},
+]`,
+			err: "line 3: invalid character ']' looking for beginning of value",
+		},
+		{
+			name:   "JSON unknown field",
+			format: localevents.FormatJSON,
+			input: `[
+  {"Name": "OperatorRemoved", "Data": {"ID": 1}},
+  {"Name": "OperatorRemoved", "Data": {"ID": 2, "Foo": 3}}
+]`,
+			err: "event 2 at line 3: line 3: unknown field \"Foo\"",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := localevents.Parse([]byte(test.input), test.format)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestFromLog(t *testing.T) {
+	contractFilterer, err := contract.NewContractFilterer(ethcommon.Address{}, nil)
+	require.NoError(t, err)
+	parser := eventparser.New(contractFilterer)
+
+	event, err := localevents.FromLog(parser, ethtypes.Log{
+		Topics: []ethcommon.Hash{
+			ethcommon.HexToHash("0x0e0ba6c2b04de36d6d509ec5bd155c43a9fe862f8052096dd54f3902a74cca3e"),
+			ethcommon.HexToHash("0x1234"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "OperatorRemoved", event.Name)
+	require.Equal(t, uint64(0x1234), event.Data.(contract.ContractOperatorRemoved).OperatorId)
+
+	// Events which the node doesn't handle are skipped.
+	event, err = localevents.FromLog(parser, ethtypes.Log{Topics: []ethcommon.Hash{{1}}})
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
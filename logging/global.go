@@ -48,8 +48,10 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	levelEncoder := parseConfigLevelEncoder(levelEncoderName)
 
+	// The level is enforced by levels, so that it can be changed at runtime.
+	levels.SetLevel(level)
 	lv := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= level
+		return true
 	})
 
 	cfg := zap.Config{
@@ -80,6 +82,10 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 		usedcore = zapcore.NewCore(zapcore.NewJSONEncoder(cfg.EncoderConfig), os.Stdout, lv)
 	}
 
+	if usedcore != nil {
+		usedcore = levels.Core(usedcore)
+	}
+
 	if fileOptions == nil {
 		zap.ReplaceGlobals(zap.New(usedcore))
 		return nil
@@ -0,0 +1,330 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Field keys which the filter matches against.
+// They mirror fields.FieldPubKey, fields.FieldValidator and fields.FieldDutyID,
+// which can't be imported here without an import cycle.
+const (
+	filterKeyPubKey    = "pubkey"
+	filterKeyValidator = "validator"
+	filterKeyDutyID    = "duty_id"
+)
+
+// Filter enables logs at a lower level for a single validator or duty until it expires.
+type Filter struct {
+	// PubKey is the hex-encoded public key of the validator, without the 0x prefix.
+	PubKey string
+	// DutyID is a prefix of the duty IDs to match, such as "PROPOSER-e1000".
+	DutyID string
+	// Level is the minimum level of matching logs.
+	Level zapcore.Level
+	// Expires is when the filter stops applying.
+	Expires time.Time
+}
+
+func (f *Filter) active(now time.Time) bool {
+	return f != nil && now.Before(f.Expires)
+}
+
+func (f *Filter) matches(key, value string) bool {
+	switch key {
+	case filterKeyPubKey, filterKeyValidator:
+		return f.PubKey != "" && strings.EqualFold(value, f.PubKey)
+	case filterKeyDutyID:
+		return f.DutyID != "" && strings.HasPrefix(value, f.DutyID)
+	default:
+		return false
+	}
+}
+
+// LevelController controls the level of logs at runtime: globally, per named logger
+// (see names.go) and for the logs of a single validator or duty.
+type LevelController struct {
+	mu     sync.RWMutex
+	level  zapcore.Level
+	named  map[string]zapcore.Level
+	filter *Filter
+
+	// minLevel is the lowest level any log could be enabled at,
+	// so that disabled logs are discarded without locking.
+	minLevel atomic.Int32
+
+	// state is read by enabled logs without locking, and replaced whenever the levels change.
+	state atomic.Pointer[levelState]
+}
+
+// levelState is a copy of the levels, with the resolved levels of the logger names logged so far.
+// It's replaced rather than modified.
+type levelState struct {
+	level    zapcore.Level
+	filter   *Filter
+	hasNamed bool
+	names    map[string]zapcore.Level
+}
+
+func NewLevelController(level zapcore.Level) *LevelController {
+	lc := &LevelController{
+		level: level,
+		named: make(map[string]zapcore.Level),
+	}
+	lc.update()
+	return lc
+}
+
+// levels is the LevelController of the global logger.
+var levels = NewLevelController(zapcore.InfoLevel)
+
+// Levels returns the LevelController of the global logger set by SetGlobalLogger.
+func Levels() *LevelController {
+	return levels
+}
+
+// Level returns the global level.
+func (lc *LevelController) Level() zapcore.Level {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.level
+}
+
+// SetLevel sets the global level, which applies to loggers without a level of their own.
+func (lc *LevelController) SetLevel(level zapcore.Level) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.level = level
+	lc.update()
+}
+
+// NamedLevels returns the levels set per logger name.
+func (lc *LevelController) NamedLevels() map[string]zapcore.Level {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	named := make(map[string]zapcore.Level, len(lc.named))
+	for name, level := range lc.named {
+		named[name] = level
+	}
+	return named
+}
+
+// SetNamedLevel sets the level of the logger with the given name and of its descendants,
+// unless they have a level of their own.
+func (lc *LevelController) SetNamedLevel(name string, level zapcore.Level) error {
+	if !isName(name) {
+		return fmt.Errorf("unknown logger name: %s", name)
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.named[name] = level
+	lc.update()
+	return nil
+}
+
+// ResetNamedLevel makes the logger with the given name follow the global level again.
+func (lc *LevelController) ResetNamedLevel(name string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	delete(lc.named, name)
+	lc.update()
+}
+
+// Filter returns a copy of the active filter, or nil if there's none.
+func (lc *LevelController) Filter() *Filter {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	if !lc.filter.active(time.Now()) {
+		return nil
+	}
+	filter := *lc.filter
+	return &filter
+}
+
+// SetFilter replaces the filter.
+func (lc *LevelController) SetFilter(filter Filter) error {
+	if filter.PubKey == "" && filter.DutyID == "" {
+		return fmt.Errorf("filter must have either a public key or a duty ID")
+	}
+	filter.PubKey = strings.TrimPrefix(strings.ToLower(filter.PubKey), "0x")
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.filter = &filter
+	lc.update()
+	return nil
+}
+
+// ResetFilter removes the filter.
+func (lc *LevelController) ResetFilter() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.filter = nil
+	lc.update()
+}
+
+// update recomputes minLevel and clears the levels of logger names. It must be called with mu locked.
+func (lc *LevelController) update() {
+	min := lc.level
+	for _, level := range lc.named {
+		if level < min {
+			min = level
+		}
+	}
+	// An expired filter keeps lowering minLevel until it's replaced,
+	// which only costs a check per discarded log.
+	if lc.filter != nil && lc.filter.Level < min {
+		min = lc.filter.Level
+	}
+	lc.minLevel.Store(int32(min))
+	lc.state.Store(&levelState{
+		level:    lc.level,
+		filter:   lc.filter,
+		hasNamed: len(lc.named) > 0,
+		names:    make(map[string]zapcore.Level),
+	})
+}
+
+// levelFor returns the level of the given logger name and the active filter, if any.
+func (lc *LevelController) levelFor(loggerName string) (zapcore.Level, *Filter) {
+	state := lc.state.Load()
+
+	var filter *Filter
+	if state.filter.active(time.Now()) {
+		filter = state.filter
+	}
+
+	if !state.hasNamed || loggerName == "" {
+		return state.level, filter
+	}
+	level, ok := state.names[loggerName]
+	if !ok {
+		level = lc.resolveLevel(loggerName)
+	}
+	return level, filter
+}
+
+// resolveLevel returns the level of the given logger name and adds it to the state.
+// Names of descendant loggers are joined with dots, and the most specific name with a level wins.
+func (lc *LevelController) resolveLevel(loggerName string) zapcore.Level {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	state := lc.state.Load()
+	if level, ok := state.names[loggerName]; ok {
+		return level
+	}
+
+	level := lc.level
+	for name := loggerName; ; {
+		i := strings.LastIndexByte(name, '.')
+		if named, ok := lc.named[name[i+1:]]; ok {
+			level = named
+			break
+		}
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	// Logger names are few, so the map is copied once per name rather than locked by every log.
+	names := make(map[string]zapcore.Level, len(state.names)+1)
+	for name, level := range state.names {
+		names[name] = level
+	}
+	names[loggerName] = level
+	lc.state.Store(&levelState{
+		level:    state.level,
+		filter:   state.filter,
+		hasNamed: state.hasNamed,
+		names:    names,
+	})
+	return level
+}
+
+// Core wraps the given core, so that its entries are enabled by lc.
+func (lc *LevelController) Core(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: lc}
+}
+
+type levelCore struct {
+	zapcore.Core
+	levels *LevelController
+
+	// context holds the values of the filterable fields added with With.
+	context []zapcore.Field
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return int32(level) >= c.levels.minLevel.Load()
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &levelCore{
+		Core:    c.Core.With(fields),
+		levels:  c.levels,
+		context: c.context,
+	}
+	for _, field := range fields {
+		switch field.Key {
+		case filterKeyPubKey, filterKeyValidator, filterKeyDutyID:
+			clone.context = append(clone.context[:len(clone.context):len(clone.context)], field)
+		}
+	}
+	return clone
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	level, filter := c.levels.levelFor(entry.LoggerName)
+	if entry.Level >= level {
+		return ce.AddCore(entry, c)
+	}
+	if filter == nil || entry.Level < filter.Level {
+		return ce
+	}
+	if matchesFilter(filter, c.context) {
+		return ce.AddCore(entry, c)
+	}
+	// The entry's own fields are only known when it's written.
+	return ce.AddCore(entry, &filteredCore{levelCore: c, filter: filter})
+}
+
+// filteredCore writes only the entries whose fields match the filter.
+type filteredCore struct {
+	*levelCore
+	filter *Filter
+}
+
+func (c *filteredCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !matchesFilter(c.filter, fields) {
+		return nil
+	}
+	return c.levelCore.Write(entry, fields)
+}
+
+func matchesFilter(filter *Filter, fields []zapcore.Field) bool {
+	for _, field := range fields {
+		var value string
+		switch field.Type {
+		case zapcore.StringType:
+			value = field.String
+		case zapcore.StringerType:
+			stringer, ok := field.Interface.(fmt.Stringer)
+			if !ok {
+				continue
+			}
+			value = stringer.String()
+		default:
+			continue
+		}
+		if filter.matches(field.Key, value) {
+			return true
+		}
+	}
+	return false
+}
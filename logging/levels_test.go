@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevelController(t *testing.T) {
+	lc := NewLevelController(zapcore.InfoLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(lc.Core(core))
+
+	p2p := logger.Named(NameP2PNetwork)
+	controller := logger.Named(NameOperator).Named(NameController)
+
+	logger.Debug("global debug")
+	logger.Info("global info")
+	require.Equal(t, 1, logs.Len())
+
+	t.Run("named level", func(t *testing.T) {
+		logs.TakeAll()
+		require.NoError(t, lc.SetNamedLevel(NameController, zapcore.DebugLevel))
+		require.NoError(t, lc.SetNamedLevel(NameP2PNetwork, zapcore.ErrorLevel))
+		require.Error(t, lc.SetNamedLevel("Unknown", zapcore.DebugLevel))
+
+		controller.Debug("controller debug")
+		p2p.Warn("p2p warn")
+		p2p.Error("p2p error")
+		logger.Debug("global debug")
+		require.Equal(t, []string{"controller debug", "p2p error"}, messages(logs.TakeAll()))
+
+		lc.ResetNamedLevel(NameController)
+		lc.ResetNamedLevel(NameP2PNetwork)
+		controller.Debug("controller debug")
+		p2p.Warn("p2p warn")
+		require.Equal(t, []string{"p2p warn"}, messages(logs.TakeAll()))
+	})
+
+	t.Run("descendant level", func(t *testing.T) {
+		logs.TakeAll()
+		require.NoError(t, lc.SetNamedLevel(NameOperator, zapcore.DebugLevel))
+		controller.Debug("inherited debug")
+
+		// The level resolved for the logger's name is replaced when the levels change.
+		require.NoError(t, lc.SetNamedLevel(NameController, zapcore.InfoLevel))
+		controller.Debug("controller debug")
+		controller.Info("controller info")
+		require.Equal(t, []string{"inherited debug", "controller info"}, messages(logs.TakeAll()))
+
+		allocs := testing.AllocsPerRun(100, func() {
+			lc.levelFor(NameOperator + "." + NameController)
+		})
+		require.Zero(t, allocs)
+
+		lc.ResetNamedLevel(NameOperator)
+		lc.ResetNamedLevel(NameController)
+	})
+
+	t.Run("global level", func(t *testing.T) {
+		logs.TakeAll()
+		lc.SetLevel(zapcore.WarnLevel)
+		logger.Info("global info")
+		logger.Warn("global warn")
+		require.Equal(t, []string{"global warn"}, messages(logs.TakeAll()))
+		lc.SetLevel(zapcore.InfoLevel)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		logs.TakeAll()
+		require.Error(t, lc.SetFilter(Filter{Level: zapcore.DebugLevel}))
+		require.NoError(t, lc.SetFilter(Filter{
+			PubKey:  "0xABCD",
+			Level:   zapcore.DebugLevel,
+			Expires: time.Now().Add(time.Hour),
+		}))
+		require.Equal(t, "abcd", lc.Filter().PubKey)
+
+		validator := logger.With(zap.String("pubkey", "abcd"))
+		validator.Debug("context match")
+		logger.Debug("field match", zap.String("pubkey", "abcd"))
+		logger.Debug("no match", zap.String("pubkey", "ef01"))
+		logger.Debug("no fields")
+		require.Equal(t, []string{"context match", "field match"}, messages(logs.TakeAll()))
+
+		require.NoError(t, lc.SetFilter(Filter{
+			DutyID:  "PROPOSER-e10-",
+			Level:   zapcore.DebugLevel,
+			Expires: time.Now().Add(time.Hour),
+		}))
+		logger.Debug("duty match", zap.String("duty_id", "PROPOSER-e10-s320-v1"))
+		logger.Debug("duty mismatch", zap.String("duty_id", "ATTESTER-e10-s320-v1"))
+		require.Equal(t, []string{"duty match"}, messages(logs.TakeAll()))
+
+		require.NoError(t, lc.SetFilter(Filter{
+			DutyID:  "PROPOSER",
+			Level:   zapcore.DebugLevel,
+			Expires: time.Now().Add(-time.Second),
+		}))
+		require.Nil(t, lc.Filter())
+		logger.Debug("expired", zap.String("duty_id", "PROPOSER-e10-s320-v1"))
+		require.Zero(t, logs.Len())
+
+		lc.ResetFilter()
+		require.Nil(t, lc.Filter())
+	})
+}
+
+func messages(entries []observer.LoggedEntry) []string {
+	var msgs []string
+	for _, entry := range entries {
+		msgs = append(msgs, entry.Message)
+	}
+	return msgs
+}
@@ -9,6 +9,7 @@ const (
 	NameMetricsHandler   = "MetricsHandler"
 	NameOperator         = "Operator"
 	NameP2PNetwork       = "P2PNetwork"
+	NameRemoteSigner     = "RemoteSigner"
 	NameSignerStorage    = "SignerStorage"
 	NameValidator        = "Validator"
 	NameWSServer         = "WSServer"
@@ -20,8 +21,54 @@ const (
 	NameDiscoveryV5Logger = "DiscoveryV5Logger"
 	NameExportKeys        = "ExportKeys"
 	NameP2PStorage        = "P2PStorage"
+	NamePebbleDBLog       = "PebbleDBLog"
+	NamePebbleDBReporting = "PebbleDBReporting"
 	NamePubsubTrace       = "PubsubTrace"
 	NameScoreInspector    = "ScoreInspector"
 	NameEventHandler      = "EventHandler"
 	NameDutyFetcher       = "DutyFetcher"
 )
+
+// names lists the names above, which can be given their own level by LevelController.
+var names = []string{
+	NameBootNode,
+	NameController,
+	NameDiscoveryService,
+	NameDutyScheduler,
+	NameEthClient,
+	NameMetricsHandler,
+	NameOperator,
+	NameP2PNetwork,
+	NameRemoteSigner,
+	NameSignerStorage,
+	NameValidator,
+	NameWSServer,
+	NameConnHandler,
+
+	NameBadgerDBLog,
+	NameBadgerDBReporting,
+	NameCreateThreshold,
+	NameDiscoveryV5Logger,
+	NameExportKeys,
+	NameP2PStorage,
+	NamePebbleDBLog,
+	NamePebbleDBReporting,
+	NamePubsubTrace,
+	NameScoreInspector,
+	NameEventHandler,
+	NameDutyFetcher,
+}
+
+// Names returns the names of the node's loggers.
+func Names() []string {
+	return append([]string(nil), names...)
+}
+
+func isName(name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -68,6 +68,22 @@ var (
 		Name: "ssv_eth1_sync_count_failed",
 		Help: "Count failed execution client events",
 	}, []string{"etype"})
+	eventTaskFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_eth1_task_count_failed",
+		Help: "Count tasks of execution client events which failed and were queued for retry",
+	}, []string{"type"})
+	eventTaskRetried = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_eth1_task_count_retried",
+		Help: "Count failed tasks of execution client events which succeeded when retried",
+	}, []string{"type"})
+	failedEventTasks = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_eth1_failed_tasks",
+		Help: "Failed tasks of execution client events by state (pending or dead)",
+	}, []string{"state"})
+	unknownEvents = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_eth1_unknown_events",
+		Help: "Count execution client events which aren't in the contract's ABI, so they weren't processed",
+	})
 	operatorIndex = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv:exporter:operator_index",
 		Help: "operator footprint",
@@ -179,6 +195,10 @@ type MetricsReporter interface {
 	ValidatorUnknown(publicKey []byte)
 	EventProcessed(eventName string)
 	EventProcessingFailed(eventName string)
+	EventTaskFailed(taskType string)
+	EventTaskRetried(taskType string)
+	FailedEventTasks(pending, dead int)
+	UnknownEvents(count int)
 	MessagesReceivedFromPeer(peerId peer.ID)
 	MessagesReceivedTotal()
 	MessageValidationRSAVerifications()
@@ -229,6 +249,10 @@ func New(opts ...Option) MetricsReporter {
 		validatorStatus,
 		eventProcessed,
 		eventProcessingFailed,
+		eventTaskFailed,
+		eventTaskRetried,
+		failedEventTasks,
+		unknownEvents,
 		operatorIndex,
 		messageValidationResult,
 		messageValidationSSVType,
@@ -336,6 +360,23 @@ func (m *metricsReporter) EventProcessingFailed(eventName string) {
 	eventProcessingFailed.WithLabelValues(eventName).Inc()
 }
 
+func (m *metricsReporter) EventTaskFailed(taskType string) {
+	eventTaskFailed.WithLabelValues(taskType).Inc()
+}
+
+func (m *metricsReporter) EventTaskRetried(taskType string) {
+	eventTaskRetried.WithLabelValues(taskType).Inc()
+}
+
+func (m *metricsReporter) FailedEventTasks(pending, dead int) {
+	failedEventTasks.WithLabelValues("pending").Set(float64(pending))
+	failedEventTasks.WithLabelValues("dead").Set(float64(dead))
+}
+
+func (m *metricsReporter) UnknownEvents(count int) {
+	unknownEvents.Set(float64(count))
+}
+
 func (m *metricsReporter) MessagesReceivedFromPeer(peerId peer.ID) {
 	messagesReceivedFromPeer.WithLabelValues(peerId.String()).Inc()
 }
@@ -34,6 +34,10 @@ func (n *nopMetrics) ValidatorRemoved(publicKey []byte)
 func (n *nopMetrics) ValidatorUnknown(publicKey []byte)                                             {}
 func (n *nopMetrics) EventProcessed(eventName string)                                               {}
 func (n *nopMetrics) EventProcessingFailed(eventName string)                                        {}
+func (n *nopMetrics) EventTaskFailed(taskType string)                                               {}
+func (n *nopMetrics) EventTaskRetried(taskType string)                                              {}
+func (n *nopMetrics) FailedEventTasks(pending, dead int)                                            {}
+func (n *nopMetrics) UnknownEvents(count int)                                                       {}
 func (n *nopMetrics) MessagesReceivedFromPeer(peerId peer.ID)                                       {}
 func (n *nopMetrics) MessagesReceivedTotal()                                                        {}
 func (n *nopMetrics) MessageValidationRSAVerifications()                                            {}
@@ -302,6 +302,7 @@ func (n *p2pNetwork) setupPubsub(logger *zap.Logger) error {
 		ValidateThrottle:    n.cfg.PubsubValidateThrottle,
 		MsgIDCacheTTL:       n.cfg.PubsubMsgCacheTTL,
 		GetValidatorStats:   n.cfg.GetValidatorStats,
+		Scoring:             topics.DefaultScoringConfig(n.cfg.Network.SlotDurationSec() * time.Duration(n.cfg.Network.SlotsPerEpoch())),
 	}
 
 	if n.cfg.PeerScoreInspector != nil && n.cfg.PeerScoreInspectorInterval > 0 {
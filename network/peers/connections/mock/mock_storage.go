@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
@@ -116,6 +117,16 @@ func (m NodeStorage) DeleteRecipientData(txn basedb.ReadWriter, owner common.Add
 	panic("implement me")
 }
 
+func (m NodeStorage) GetBuilderPolicy(r basedb.Reader, owner common.Address, operatorIDs []uint64) (*beacon.BuilderPolicy, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m NodeStorage) SaveBuilderPolicy(rw basedb.ReadWriter, owner common.Address, operatorIDs []uint64, policy *beacon.BuilderPolicy) error {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m NodeStorage) GetRecipientsPrefix() []byte {
 	//TODO implement me
 	panic("implement me")
@@ -154,6 +165,78 @@ func (m NodeStorage) SavePrivateKeyHash(privKeyHash string) error {
 	panic("implement me")
 }
 
+func (m NodeStorage) SaveProcessedBlock(rw basedb.ReadWriter, block *storage.ProcessedBlock) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) GetProcessedBlocks(r basedb.Reader) ([]*storage.ProcessedBlock, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) DeleteProcessedBlock(rw basedb.ReadWriter, number uint64) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) SaveFailedTask(rw basedb.ReadWriter, task *storage.FailedTask) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) GetFailedTask(r basedb.Reader, id uint64) (*storage.FailedTask, bool, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) GetFailedTasks(r basedb.Reader) ([]*storage.FailedTask, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) DeleteFailedTask(rw basedb.ReadWriter, id uint64) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) SaveUnknownEvent(rw basedb.ReadWriter, event *storage.UnknownEvent) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) GetUnknownEvents(r basedb.Reader) ([]*storage.UnknownEvent, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) SaveRegistryHistoryStart(rw basedb.ReadWriter, blockNumber uint64) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) GetRegistryHistoryStart(r basedb.Reader) (uint64, bool, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) SaveShareVersion(rw basedb.ReadWriter, version *storage.ShareVersion) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) SaveRecipientVersion(rw basedb.ReadWriter, version *storage.RecipientVersion) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) GetSharesAtBlock(r basedb.Reader, blockNumber uint64) ([]*storage.ShareVersion, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) GetRecipientsAtBlock(r basedb.Reader, blockNumber uint64) ([]*storage.RecipientVersion, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) ListRecipients(r basedb.Reader) ([]*registrystorage.RecipientData, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) ExportRegistrySnapshot(network string) (*storage.RegistrySnapshot, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) ImportRegistrySnapshot(snapshot *storage.RegistrySnapshot, operatorPubKey []byte) error {
+	panic("implement me")
+}
+
 func (m NodeStorage) GetConfig(rw basedb.ReadWriter) (*storage.ConfigLock, bool, error) {
 	panic("implement me")
 }
@@ -5,10 +5,13 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
 const (
-	oneEpochDuration = (12 * time.Second) * 32
+	// oneEpochDuration is the default duration of an epoch, which is used when the network's isn't given.
+	oneEpochDuration = beacon.DefaultSlotDuration * beacon.DefaultSlotsPerEpoch
 )
 
 // scoreDecay determines the decay rate from the provided time period till
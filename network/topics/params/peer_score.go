@@ -17,10 +17,9 @@ const (
 	opportunisticGraftThreshold = 5
 
 	// Overall parameters
-	topicScoreCap = 32.72
-	decayInterval = 32 * (time.Second * 12) // One epoch
-	decayToZero   = 0.01
-	retainScore   = 100 * 32 * 12 * time.Second
+	topicScoreCap     = 32.72
+	decayToZero       = 0.01
+	retainScoreEpochs = 100
 
 	// P5
 	appSpecificWeight = 0
@@ -44,14 +43,15 @@ func PeerScoreThresholds() *pubsub.PeerScoreThresholds {
 	}
 }
 
-// PeerScoreParams returns peer score params according to the given options
+// PeerScoreParams returns peer score params according to the given options.
+// Scores decay once every epoch, so oneEpoch must be the epoch duration of the network.
 func PeerScoreParams(oneEpoch, msgIDCacheTTL time.Duration, ipWhilelist ...*net.IPNet) *pubsub.PeerScoreParams {
 	if oneEpoch == 0 {
 		oneEpoch = oneEpochDuration
 	}
 
 	// P7 calculation
-	behaviourPenaltyDecay := scoreDecay(oneEpoch*10, decayInterval)
+	behaviourPenaltyDecay := scoreDecay(oneEpoch*10, oneEpoch)
 	maxAllowedRatePerDecayInterval := 10.0
 	targetVal, _ := decayConvergence(behaviourPenaltyDecay, maxAllowedRatePerDecayInterval)
 	targetVal = targetVal - behaviourPenaltyThreshold
@@ -61,9 +61,9 @@ func PeerScoreParams(oneEpoch, msgIDCacheTTL time.Duration, ipWhilelist ...*net.
 		Topics: make(map[string]*pubsub.TopicScoreParams),
 		// Overall parameters
 		TopicScoreCap: topicScoreCap,
-		DecayInterval: decayInterval,
+		DecayInterval: oneEpoch,
 		DecayToZero:   decayToZero,
-		RetainScore:   retainScore,
+		RetainScore:   retainScoreEpochs * oneEpoch,
 		SeenMsgTTL:    msgIDCacheTTL,
 
 		// P5
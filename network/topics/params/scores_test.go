@@ -19,7 +19,7 @@ func TestTopicScoreParams(t *testing.T) {
 		{
 			"subnet topic 1k validators",
 			func() *Options {
-				opts := NewSubnetTopicOpts(1000, 128)
+				opts := NewSubnetTopicOpts(1000, 128, oneEpochDuration)
 				return &opts
 			},
 			nil,
@@ -27,7 +27,7 @@ func TestTopicScoreParams(t *testing.T) {
 		{
 			"subnet topic 10k validators",
 			func() *Options {
-				opts := NewSubnetTopicOpts(10000, 128)
+				opts := NewSubnetTopicOpts(10000, 128, oneEpochDuration)
 				return &opts
 			},
 			nil,
@@ -35,7 +35,7 @@ func TestTopicScoreParams(t *testing.T) {
 		{
 			"subnet topic 51k validators",
 			func() *Options {
-				opts := NewSubnetTopicOpts(51000, 128)
+				opts := NewSubnetTopicOpts(51000, 128, oneEpochDuration)
 				return &opts
 			},
 			nil,
@@ -70,6 +70,25 @@ func TestPeerScoreParams(t *testing.T) {
 	t.Log("peer score params:\n", raw)
 }
 
+func TestShortEpochScoreParams(t *testing.T) {
+	// A devnet with 2-second slots and 8 slots per epoch.
+	oneEpoch := 8 * 2 * time.Second
+
+	peerScoreParams := PeerScoreParams(oneEpoch, 550*(time.Millisecond*700))
+	require.Equal(t, oneEpoch, peerScoreParams.DecayInterval)
+	require.Equal(t, retainScoreEpochs*oneEpoch, peerScoreParams.RetainScore)
+	require.Equal(t, PeerScoreParams(oneEpochDuration, 550*(time.Millisecond*700)).BehaviourPenaltyDecay, peerScoreParams.BehaviourPenaltyDecay)
+
+	// Decays per epoch are the same as on mainnet, since they're counted in epochs.
+	topicScoreParams, err := TopicParams(NewSubnetTopicOpts(10000, 128, oneEpoch))
+	require.NoError(t, err)
+	mainnetTopicScoreParams, err := TopicParams(NewSubnetTopicOpts(10000, 128, oneEpochDuration))
+	require.NoError(t, err)
+	require.Equal(t, mainnetTopicScoreParams.FirstMessageDeliveriesDecay, topicScoreParams.FirstMessageDeliveriesDecay)
+	require.Equal(t, mainnetTopicScoreParams.InvalidMessageDeliveriesDecay, topicScoreParams.InvalidMessageDeliveriesDecay)
+	require.Equal(t, 3*oneEpoch, topicScoreParams.MeshMessageDeliveriesActivation)
+}
+
 func peerScoreParamsString(psp *pubsub.PeerScoreParams) (string, error) {
 	cp := peerScoreParamsSerializable{
 		TopicScoreCap:               psp.TopicScoreCap,
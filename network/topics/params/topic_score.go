@@ -156,21 +156,22 @@ func (o *Options) maxScore() float64 {
 }
 
 // NewOpts creates new TopicOpts instance
-func NewOpts(activeValidators, subnets int) Options {
+func NewOpts(activeValidators, subnets int, oneEpoch time.Duration) Options {
 	return Options{
 		Network: NetworkOpts{
 			ActiveValidators: activeValidators,
 			Subnets:          subnets,
+			OneEpochDuration: oneEpoch,
 		},
 		Topic: TopicOpts{},
 	}
 }
 
 // NewSubnetTopicOpts creates new TopicOpts for a subnet topic
-func NewSubnetTopicOpts(activeValidators, subnets int) Options {
+func NewSubnetTopicOpts(activeValidators, subnets int, oneEpoch time.Duration) Options {
 
 	// Create options with default values
-	opts := NewOpts(activeValidators, subnets)
+	opts := NewOpts(activeValidators, subnets, oneEpoch)
 	opts.defaults()
 
 	// Set topic weight with equal weights
@@ -196,6 +197,8 @@ func TopicParams(opts Options) (*pubsub.TopicScoreParams, error) {
 	// Set to default if not set
 	opts.defaults()
 
+	// Scores decay once every epoch, as in PeerScoreParams.
+	decayInterval := opts.Network.OneEpochDuration
 	expectedMessagesPerDecayInterval := opts.Topic.ExpectedMsgRate * decayInterval.Seconds()
 
 	// P1
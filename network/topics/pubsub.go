@@ -70,7 +70,9 @@ type PubSubConfig struct {
 type ScoringConfig struct {
 	IPWhilelist        []*net.IPNet
 	IPColocationWeight float64
-	OneEpochDuration   time.Duration
+	// OneEpochDuration is the epoch duration of the network, by which scores decay.
+	// Zero defaults to the epoch duration of mainnet.
+	OneEpochDuration time.Duration
 }
 
 // PubsubBundle includes the pubsub router, plus involved components
@@ -102,7 +104,7 @@ func (cfg *PubSubConfig) init() error {
 // initScoring initializes scoring config
 func (cfg *PubSubConfig) initScoring() {
 	if cfg.Scoring == nil {
-		cfg.Scoring = DefaultScoringConfig()
+		cfg.Scoring = DefaultScoringConfig(0)
 	}
 }
 
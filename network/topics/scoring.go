@@ -15,11 +15,11 @@ import (
 	"github.com/bloxapp/ssv/network/topics/params"
 )
 
-// DefaultScoringConfig returns the default scoring config
-func DefaultScoringConfig() *ScoringConfig {
+// DefaultScoringConfig returns the default scoring config for the given epoch duration
+func DefaultScoringConfig(oneEpoch time.Duration) *ScoringConfig {
 	return &ScoringConfig{
 		IPColocationWeight: -35.11,
-		OneEpochDuration:   (12 * time.Second) * 32,
+		OneEpochDuration:   oneEpoch,
 	}
 }
 
@@ -119,7 +119,7 @@ func topicScoreParams(logger *zap.Logger, cfg *PubSubConfig) func(string) *pubsu
 		logger := logger.With(zap.String("topic", t), zap.Uint64("totalValidators", totalValidators),
 			zap.Uint64("activeValidators", activeValidators), zap.Uint64("myValidators", myValidators))
 		logger.Debug("got validator stats for score params")
-		opts := params.NewSubnetTopicOpts(int(totalValidators), commons.Subnets())
+		opts := params.NewSubnetTopicOpts(int(totalValidators), commons.Subnets(), cfg.Scoring.OneEpochDuration)
 		tp, err := params.TopicParams(opts)
 		if err != nil {
 			logger.Debug("ignoring topic score params", zap.Error(err))
@@ -0,0 +1,208 @@
+package networkconfig
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// networkConfigFile is the YAML (or JSON) representation of a NetworkConfig.
+type networkConfigFile struct {
+	Name string `yaml:"Name"`
+
+	// BeaconNetwork is the name of a beacon chain network known to the spec, which the key manager
+	// requires. GenesisForkVersion and MinGenesisTime optionally override its parameters, for private chains.
+	BeaconNetwork      string `yaml:"BeaconNetwork"`
+	GenesisForkVersion string `yaml:"GenesisForkVersion"`
+	MinGenesisTime     uint64 `yaml:"MinGenesisTime"`
+
+	// The beacon chain spec, whose unset values are taken from the beacon node.
+	SecondsPerSlot               uint64     `yaml:"SecondsPerSlot"`
+	SlotsPerEpoch                uint64     `yaml:"SlotsPerEpoch"`
+	EpochsPerSyncCommitteePeriod uint64     `yaml:"EpochsPerSyncCommitteePeriod"`
+	Forks                        []forkFile `yaml:"Forks"`
+
+	DomainType                    string   `yaml:"DomainType"`
+	GenesisEpoch                  uint64   `yaml:"GenesisEpoch"`
+	RegistrySyncOffset            uint64   `yaml:"RegistrySyncOffset"`
+	RegistryContractAddr          string   `yaml:"RegistryContractAddr"`
+	Bootnodes                     []string `yaml:"Bootnodes"`
+	WhitelistedOperatorKeys       []string `yaml:"WhitelistedOperatorKeys"`
+	PermissionlessActivationEpoch uint64   `yaml:"PermissionlessActivationEpoch"`
+	DiscoveryProtocolID           string   `yaml:"DiscoveryProtocolID"`
+}
+
+// forkFile is the YAML (or JSON) representation of a beacon.Fork.
+type forkFile struct {
+	Name    string `yaml:"Name"`
+	Version string `yaml:"Version"`
+	Epoch   uint64 `yaml:"Epoch"`
+}
+
+// LoadNetworkConfig reads and validates a network config from a YAML or JSON file.
+func LoadNetworkConfig(path string) (NetworkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("failed to read network config file: %w", err)
+	}
+
+	var file networkConfigFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return NetworkConfig{}, fmt.Errorf("failed to parse network config file: %w", err)
+	}
+
+	config, err := file.networkConfig()
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid network config file: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid network config file: %w", err)
+	}
+	return config, nil
+}
+
+func (f *networkConfigFile) networkConfig() (NetworkConfig, error) {
+	if _, ok := SupportedConfigs[f.Name]; ok {
+		return NetworkConfig{}, fmt.Errorf("name %q is taken by a built-in network", f.Name)
+	}
+
+	beaconNetwork, err := f.beaconNetwork()
+	if err != nil {
+		return NetworkConfig{}, err
+	}
+
+	domain, err := decodeHex("domain type", f.DomainType, len(spectypes.DomainType{}))
+	if err != nil {
+		return NetworkConfig{}, err
+	}
+
+	config := NetworkConfig{
+		Name:                          f.Name,
+		Beacon:                        beaconNetwork,
+		Domain:                        spectypes.DomainType(domain),
+		GenesisEpoch:                  spec.Epoch(f.GenesisEpoch),
+		RegistrySyncOffset:            new(big.Int).SetUint64(f.RegistrySyncOffset),
+		RegistryContractAddr:          f.RegistryContractAddr,
+		Bootnodes:                     f.Bootnodes,
+		WhitelistedOperatorKeys:       f.WhitelistedOperatorKeys,
+		PermissionlessActivationEpoch: spec.Epoch(f.PermissionlessActivationEpoch),
+	}
+	if config.WhitelistedOperatorKeys == nil {
+		config.WhitelistedOperatorKeys = []string{}
+	}
+
+	// The discovery protocol ID is optional, and defaults to the one of the SSV network.
+	if f.DiscoveryProtocolID != "" {
+		protocolID, err := decodeHex("discovery protocol ID", f.DiscoveryProtocolID, len(config.DiscoveryProtocolID))
+		if err != nil {
+			return NetworkConfig{}, err
+		}
+		copy(config.DiscoveryProtocolID[:], protocolID)
+	}
+
+	return config, nil
+}
+
+func (f *networkConfigFile) beaconNetwork() (beacon.Network, error) {
+	network := spectypes.BeaconNetwork(f.BeaconNetwork)
+	if network == "" {
+		return beacon.Network{}, fmt.Errorf("missing beacon network")
+	}
+
+	// The key manager only supports the spec's networks, and would exit on the first signature otherwise.
+	if !knownBeaconNetwork(network) {
+		return beacon.Network{}, fmt.Errorf("beacon network %q is unknown, it must be one of mainnet, holesky or prater", network)
+	}
+
+	beaconNetwork := beacon.NewNetwork(network)
+	if f.GenesisForkVersion != "" || f.MinGenesisTime != 0 {
+		forkVersion, err := decodeHex("genesis fork version", f.GenesisForkVersion, 4)
+		if err != nil {
+			return beacon.Network{}, err
+		}
+		if f.MinGenesisTime == 0 {
+			return beacon.Network{}, fmt.Errorf("missing min genesis time")
+		}
+		params := beacon.NetworkParams{MinGenesisTime: f.MinGenesisTime}
+		copy(params.GenesisForkVersion[:], forkVersion)
+		beaconNetwork = beacon.NewCustomNetwork(network, params)
+	}
+
+	beaconSpec := beacon.Spec{
+		SlotDuration:                 time.Duration(f.SecondsPerSlot) * time.Second,
+		SlotsPerEpoch:                f.SlotsPerEpoch,
+		EpochsPerSyncCommitteePeriod: f.EpochsPerSyncCommitteePeriod,
+	}
+	for _, fork := range f.Forks {
+		version, err := decodeHex(fork.Name+" fork version", fork.Version, 4)
+		if err != nil {
+			return beacon.Network{}, err
+		}
+		beaconFork := beacon.Fork{Name: fork.Name, Epoch: spec.Epoch(fork.Epoch)}
+		copy(beaconFork.Version[:], version)
+		beaconSpec.Forks = append(beaconSpec.Forks, beaconFork)
+	}
+	if err := beaconSpec.Validate(); err != nil {
+		return beacon.Network{}, err
+	}
+	return beaconNetwork.WithSpec(beaconSpec), nil
+}
+
+// knownBeaconNetwork returns whether both the spec and the key manager know the beacon network.
+func knownBeaconNetwork(network spectypes.BeaconNetwork) bool {
+	switch network {
+	case spectypes.MainNetwork, spectypes.HoleskyNetwork, spectypes.PraterNetwork:
+		return true
+	default:
+		return false
+	}
+}
+
+func decodeHex(field, s string, size int) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing %s", field)
+	}
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a 0x-prefixed hex string: %w", field, err)
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("%s must be %d bytes", field, size)
+	}
+	return b, nil
+}
+
+// Validate returns an error if the network config is malformed.
+func (n NetworkConfig) Validate() error {
+	if n.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if n.Beacon == nil {
+		return fmt.Errorf("missing beacon network")
+	}
+	if n.Beacon.MinGenesisTime() == 0 {
+		return fmt.Errorf("missing min genesis time")
+	}
+	if !common.IsHexAddress(n.RegistryContractAddr) {
+		return fmt.Errorf("invalid registry contract address %q", n.RegistryContractAddr)
+	}
+	if n.RegistrySyncOffset != nil && n.RegistrySyncOffset.Sign() < 0 {
+		return fmt.Errorf("negative registry sync offset")
+	}
+	for _, bootnode := range n.Bootnodes {
+		if _, err := enode.Parse(enode.ValidSchemes, bootnode); err != nil {
+			return fmt.Errorf("invalid bootnode %q: %w", bootnode, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package networkconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+const testBootnode = "enr:-Li4QFIQzamdvTxGJhvcXG_DFmCeyggSffDnllY5DiU47pd_K_1MRnSaJimWtfKJ-MD46jUX9TwgW5Jqe0t4pH41RYWGAYuFnlyth2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__________gmlkgnY0gmlwhCLdu_SJc2VjcDI1NmsxoQN4v-N9zFYwEqzGPBBX37q24QPFvAVUtokIo1fblIsmTIN0Y3CCE4uDdWRwgg-j"
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestSupportedConfigs_Valid(t *testing.T) {
+	for name, config := range SupportedConfigs {
+		require.NoError(t, config.Validate(), name)
+	}
+}
+
+func TestLoadNetworkConfig(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		path := writeConfigFile(t, "network.yaml", `
+Name: devnet
+BeaconNetwork: holesky
+GenesisForkVersion: "0x10000038"
+MinGenesisTime: 1700000000
+SecondsPerSlot: 2
+SlotsPerEpoch: 8
+Forks:
+  - Name: capella
+    Version: "0x40000038"
+    Epoch: 1
+DomainType: "0x000005ff"
+GenesisEpoch: 1
+RegistrySyncOffset: 181612
+RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"
+Bootnodes:
+  - `+testBootnode+`
+PermissionlessActivationEpoch: 10
+DiscoveryProtocolID: "0x737376647636"
+`)
+		config, err := LoadNetworkConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, "devnet", config.Name)
+		require.Equal(t, spectypes.HoleskyNetwork, config.Beacon.GetBeaconNetwork())
+		require.True(t, config.Beacon.GetNetwork().Custom())
+		require.Equal(t, [4]byte{0x10, 0x00, 0x00, 0x38}, config.ForkVersion())
+		require.EqualValues(t, 1700000000, config.GetGenesisTime().Unix())
+		require.Equal(t, 2*time.Second, config.SlotDurationSec())
+		require.EqualValues(t, 8, config.SlotsPerEpoch())
+		require.EqualValues(t, beacon.DefaultEpochsPerSyncCommitteePeriod, config.Beacon.EpochsPerSyncCommitteePeriod())
+		capella, ok := config.Beacon.GetNetwork().Fork("capella")
+		require.True(t, ok)
+		require.Equal(t, beacon.Fork{Name: "capella", Version: [4]byte{0x40, 0x00, 0x00, 0x38}, Epoch: 1}, capella)
+		require.Equal(t, spectypes.DomainType{0x0, 0x0, 0x5, 0xff}, config.Domain)
+		require.EqualValues(t, 1, config.GenesisEpoch)
+		require.EqualValues(t, 181612, config.RegistrySyncOffset.Uint64())
+		require.Equal(t, []string{testBootnode}, config.Bootnodes)
+		require.Empty(t, config.WhitelistedOperatorKeys)
+		require.EqualValues(t, 10, config.PermissionlessActivationEpoch)
+		require.Equal(t, [6]byte{'s', 's', 'v', 'd', 'v', '6'}, config.DiscoveryProtocolID)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := writeConfigFile(t, "network.json", `{
+			"Name": "holesky-devnet",
+			"BeaconNetwork": "holesky",
+			"DomainType": "0x000005ff",
+			"RegistryContractAddr": "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"
+		}`)
+		config, err := LoadNetworkConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, Holesky.ForkVersion(), config.ForkVersion())
+		require.Equal(t, Holesky.GetGenesisTime(), config.GetGenesisTime())
+		require.False(t, config.Beacon.GetNetwork().Custom())
+		require.Equal(t, beacon.Spec{}, config.Beacon.GetNetwork().Spec())
+		require.Equal(t, [6]byte{}, config.DiscoveryProtocolID)
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "built-in name",
+			content: `{"Name": "holesky", "BeaconNetwork": "holesky"}`,
+			err:     `name "holesky" is taken by a built-in network`,
+		},
+		{
+			name:    "unknown beacon network",
+			content: `{"Name": "devnet", "BeaconNetwork": "devnet", "DomainType": "0x000005ff"}`,
+			err:     `beacon network "devnet" is unknown`,
+		},
+		{
+			name: "unknown beacon network with genesis",
+			content: `{"Name": "devnet", "BeaconNetwork": "devnet", "GenesisForkVersion": "0x10000038", "MinGenesisTime": 1700000000,
+				"DomainType": "0x000005ff"}`,
+			err: `beacon network "devnet" is unknown`,
+		},
+		{
+			name:    "missing min genesis time",
+			content: `{"Name": "devnet", "BeaconNetwork": "holesky", "GenesisForkVersion": "0x10000038"}`,
+			err:     "missing min genesis time",
+		},
+		{
+			name:    "invalid slots per epoch",
+			content: `{"Name": "devnet", "BeaconNetwork": "holesky", "SlotsPerEpoch": 2}`,
+			err:     "slots per epoch 2 are less than 4",
+		},
+		{
+			name:    "invalid fork version",
+			content: `{"Name": "devnet", "BeaconNetwork": "holesky", "Forks": [{"Name": "capella", "Version": "0x40"}]}`,
+			err:     "capella fork version must be 4 bytes",
+		},
+		{
+			name:    "invalid domain type",
+			content: `{"Name": "devnet", "BeaconNetwork": "holesky", "DomainType": "0x0005ff"}`,
+			err:     "domain type must be 4 bytes",
+		},
+		{
+			name:    "invalid discovery protocol ID",
+			content: `{"Name": "devnet", "BeaconNetwork": "holesky", "DomainType": "0x000005ff", "DiscoveryProtocolID": "ssvdv5"}`,
+			err:     "discovery protocol ID must be a 0x-prefixed hex string",
+		},
+		{
+			name:    "invalid registry contract address",
+			content: `{"Name": "devnet", "BeaconNetwork": "holesky", "DomainType": "0x000005ff", "RegistryContractAddr": "0x38A4"}`,
+			err:     `invalid registry contract address "0x38A4"`,
+		},
+		{
+			name: "invalid bootnode",
+			content: `{"Name": "devnet", "BeaconNetwork": "holesky", "DomainType": "0x000005ff",
+				"RegistryContractAddr": "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA", "Bootnodes": ["enr:invalid"]}`,
+			err: `invalid bootnode "enr:invalid"`,
+		},
+		{
+			name:    "malformed",
+			content: `{"Name": "devnet"`,
+			err:     "failed to parse network config file",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadNetworkConfig(writeConfigFile(t, "network.json", test.content))
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
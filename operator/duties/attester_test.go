@@ -927,3 +927,51 @@ func TestScheduler_Attester_Fetch_Execute_Next_Epoch_Duty(t *testing.T) {
 	cancel()
 	require.NoError(t, schedulerPool.Wait())
 }
+
+func TestScheduler_Attester_Fetch_Execute_Next_Epoch_Duty_Short_Epoch(t *testing.T) {
+	var (
+		handler     = NewAttesterHandler(dutystore.NewDuties[eth2apiv1.AttesterDuty]())
+		currentSlot = &SlotValue{}
+		dutiesMap   = hashmap.New[phase0.Epoch, []*eth2apiv1.AttesterDuty]()
+	)
+	// A devnet with 8 slots per epoch, so the next epoch's duties are fetched after slot 2.
+	currentSlot.SetSlot(phase0.Slot(1))
+	scheduler, logger, mockTicker, timeout, cancel, schedulerPool, startFn := setupSchedulerAndMocksWithSlotsPerEpoch(t, handler, currentSlot, 8)
+	fetchDutiesCall, executeDutiesCall := setupAttesterDutiesMock(scheduler, dutiesMap)
+	startFn()
+
+	dutiesMap.Set(phase0.Epoch(1), []*eth2apiv1.AttesterDuty{
+		{
+			PubKey:         phase0.BLSPubKey{1, 2, 3},
+			Slot:           phase0.Slot(8),
+			ValidatorIndex: phase0.ValidatorIndex(1),
+		},
+	})
+
+	// STEP 1: wait for no action to be taken
+	mockTicker.Send(currentSlot.GetSlot())
+	waitForNoAction(t, logger, fetchDutiesCall, executeDutiesCall, timeout)
+
+	// STEP 2: wait for no action to be taken
+	currentSlot.SetSlot(phase0.Slot(2))
+	mockTicker.Send(currentSlot.GetSlot())
+	waitForNoAction(t, logger, fetchDutiesCall, executeDutiesCall, timeout)
+
+	// STEP 3: wait for attester duties of the next epoch to be fetched
+	currentSlot.SetSlot(phase0.Slot(3))
+	mockTicker.Send(currentSlot.GetSlot())
+	waitForDutiesFetch(t, logger, fetchDutiesCall, executeDutiesCall, timeout)
+
+	// STEP 4: wait for attester duties to be executed in the first slot of the next epoch
+	currentSlot.SetSlot(phase0.Slot(8))
+	duties, _ := dutiesMap.Get(phase0.Epoch(1))
+	expected := expectedExecutedAttesterDuties(handler, duties)
+	setExecuteDutyFunc(scheduler, executeDutiesCall, len(expected))
+
+	mockTicker.Send(currentSlot.GetSlot())
+	waitForDutiesExecution(t, logger, fetchDutiesCall, executeDutiesCall, timeout, expected)
+
+	// Stop scheduler & wait for graceful exit.
+	cancel()
+	require.NoError(t, schedulerPool.Wait())
+}
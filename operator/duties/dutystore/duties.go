@@ -51,6 +51,27 @@ func (d *Duties[D]) CommitteeSlotDuties(epoch phase0.Epoch, slot phase0.Slot) []
 	return duties
 }
 
+func (d *Duties[D]) CommitteeEpochDuties(epoch phase0.Epoch) []*D {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	slotMap, ok := d.m[epoch]
+	if !ok {
+		return nil
+	}
+
+	var duties []*D
+	for _, descriptorMap := range slotMap {
+		for _, descriptor := range descriptorMap {
+			if descriptor.inCommittee {
+				duties = append(duties, descriptor.duty)
+			}
+		}
+	}
+
+	return duties
+}
+
 func (d *Duties[D]) ValidatorDuty(epoch phase0.Epoch, slot phase0.Slot, validatorIndex phase0.ValidatorIndex) *D {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
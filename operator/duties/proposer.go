@@ -64,7 +64,7 @@ func (h *ProposerHandler) HandleDuties(ctx context.Context) {
 			slot := h.ticker.Slot()
 			next = h.ticker.Next()
 			currentEpoch := h.network.Beacon.EstimatedEpochAtSlot(slot)
-			buildStr := fmt.Sprintf("e%v-s%v-#%v", currentEpoch, slot, slot%32+1)
+			buildStr := fmt.Sprintf("e%v-s%v-#%v", currentEpoch, slot, uint64(slot)%h.network.Beacon.SlotsPerEpoch()+1)
 			h.logger.Debug("🛠 ticker event", zap.String("epoch_slot_seq", buildStr))
 
 			ctx, cancel := context.WithDeadline(ctx, h.network.Beacon.GetSlotStartTime(slot+1).Add(100*time.Millisecond))
@@ -90,7 +90,7 @@ func (h *ProposerHandler) HandleDuties(ctx context.Context) {
 
 		case reorgEvent := <-h.reorg:
 			currentEpoch := h.network.Beacon.EstimatedEpochAtSlot(reorgEvent.Slot)
-			buildStr := fmt.Sprintf("e%v-s%v-#%v", currentEpoch, reorgEvent.Slot, reorgEvent.Slot%32+1)
+			buildStr := fmt.Sprintf("e%v-s%v-#%v", currentEpoch, reorgEvent.Slot, uint64(reorgEvent.Slot)%h.network.Beacon.SlotsPerEpoch()+1)
 			h.logger.Info("🔀 reorg event received", zap.String("epoch_slot_seq", buildStr), zap.Any("event", reorgEvent))
 
 			// reset current epoch duties
@@ -102,7 +102,7 @@ func (h *ProposerHandler) HandleDuties(ctx context.Context) {
 		case <-h.indicesChange:
 			slot := h.network.Beacon.EstimatedCurrentSlot()
 			currentEpoch := h.network.Beacon.EstimatedEpochAtSlot(slot)
-			buildStr := fmt.Sprintf("e%v-s%v-#%v", currentEpoch, slot, slot%32+1)
+			buildStr := fmt.Sprintf("e%v-s%v-#%v", currentEpoch, slot, uint64(slot)%h.network.Beacon.SlotsPerEpoch()+1)
 			h.logger.Info("🔁 indices change received", zap.String("epoch_slot_seq", buildStr))
 
 			h.indicesChanged = true
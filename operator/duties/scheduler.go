@@ -284,7 +284,7 @@ func (s *Scheduler) HandleHeadEvent(logger *zap.Logger) func(event *eth2apiv1.Ev
 
 		// check for reorg
 		epoch := s.network.Beacon.EstimatedEpochAtSlot(data.Slot)
-		buildStr := fmt.Sprintf("e%v-s%v-#%v", epoch, data.Slot, data.Slot%32+1)
+		buildStr := fmt.Sprintf("e%v-s%v-#%v", epoch, data.Slot, uint64(data.Slot)%s.network.Beacon.SlotsPerEpoch()+1)
 		logger := logger.With(zap.String("epoch_slot_seq", buildStr))
 		if s.lastBlockEpoch != 0 {
 			if epoch > s.lastBlockEpoch {
@@ -81,6 +81,20 @@ func setupSchedulerAndMocks(t *testing.T, handler dutyHandler, currentSlot *Slot
 	context.CancelFunc,
 	*pool.ContextPool,
 	func(),
+) {
+	return setupSchedulerAndMocksWithSlotsPerEpoch(t, handler, currentSlot, 32)
+}
+
+// setupSchedulerAndMocksWithSlotsPerEpoch is setupSchedulerAndMocks for a network with the given slots per epoch,
+// such as the shorter epochs of devnets.
+func setupSchedulerAndMocksWithSlotsPerEpoch(t *testing.T, handler dutyHandler, currentSlot *SlotValue, slotsPerEpoch uint64) (
+	*Scheduler,
+	*zap.Logger,
+	*mockSlotTickerService,
+	time.Duration,
+	context.CancelFunc,
+	*pool.ContextPool,
+	func(),
 ) {
 	ctrl := gomock.NewController(t)
 	// A 200ms timeout ensures the test passes, even with mockSlotTicker overhead.
@@ -119,7 +133,7 @@ func setupSchedulerAndMocks(t *testing.T, handler dutyHandler, currentSlot *Slot
 
 	mockNetworkConfig.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().MinGenesisTime().Return(uint64(0)).AnyTimes()
 	mockNetworkConfig.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().SlotDurationSec().Return(150 * time.Millisecond).AnyTimes()
-	mockNetworkConfig.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().SlotsPerEpoch().Return(uint64(32)).AnyTimes()
+	mockNetworkConfig.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().SlotsPerEpoch().Return(slotsPerEpoch).AnyTimes()
 	mockNetworkConfig.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().GetSlotStartTime(gomock.Any()).DoAndReturn(
 		func(slot phase0.Slot) time.Time {
 			return time.Now()
@@ -78,7 +78,7 @@ func (h *SyncCommitteeHandler) HandleDuties(ctx context.Context) {
 			next = h.ticker.Next()
 			epoch := h.network.Beacon.EstimatedEpochAtSlot(slot)
 			period := h.network.Beacon.EstimatedSyncCommitteePeriodAtEpoch(epoch)
-			buildStr := fmt.Sprintf("p%v-%v-s%v-#%v", period, epoch, slot, slot%32+1)
+			buildStr := fmt.Sprintf("p%v-%v-s%v-#%v", period, epoch, slot, uint64(slot)%h.network.Beacon.SlotsPerEpoch()+1)
 			h.logger.Debug("🛠 ticker event", zap.String("period_epoch_slot_seq", buildStr))
 
 			ctx, cancel := context.WithDeadline(ctx, h.network.Beacon.GetSlotStartTime(slot+1).Add(100*time.Millisecond))
@@ -110,7 +110,7 @@ func (h *SyncCommitteeHandler) HandleDuties(ctx context.Context) {
 			epoch := h.network.Beacon.EstimatedEpochAtSlot(reorgEvent.Slot)
 			period := h.network.Beacon.EstimatedSyncCommitteePeriodAtEpoch(epoch)
 
-			buildStr := fmt.Sprintf("p%v-e%v-s%v-#%v", period, epoch, reorgEvent.Slot, reorgEvent.Slot%32+1)
+			buildStr := fmt.Sprintf("p%v-e%v-s%v-#%v", period, epoch, reorgEvent.Slot, uint64(reorgEvent.Slot)%h.network.Beacon.SlotsPerEpoch()+1)
 			h.logger.Info("🔀 reorg event received", zap.String("period_epoch_slot_seq", buildStr), zap.Any("event", reorgEvent))
 
 			// reset current epoch duties
@@ -123,7 +123,7 @@ func (h *SyncCommitteeHandler) HandleDuties(ctx context.Context) {
 			slot := h.network.Beacon.EstimatedCurrentSlot()
 			epoch := h.network.Beacon.EstimatedEpochAtSlot(slot)
 			period := h.network.Beacon.EstimatedSyncCommitteePeriodAtEpoch(epoch)
-			buildStr := fmt.Sprintf("p%v-e%v-s%v-#%v", period, epoch, slot, slot%32+1)
+			buildStr := fmt.Sprintf("p%v-e%v-s%v-#%v", period, epoch, slot, uint64(slot)%h.network.Beacon.SlotsPerEpoch()+1)
 			h.logger.Info("🔁 indices change received", zap.String("period_epoch_slot_seq", buildStr))
 
 			h.fetchCurrentPeriod = true
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+const backupVersion = 1
+
+// BackupHeader describes the node which a backup was made of. It's encoded as JSON
+// into the header of a dump of the entire database (see kv.Dump), so it's covered by the dump's checksum.
+type BackupHeader struct {
+	Version            int         `json:"version"`
+	CreatedAt          time.Time   `json:"created_at"`
+	Config             *ConfigLock `json:"config"`
+	PrivateKeyHash     string      `json:"private_key_hash"`
+	LastProcessedBlock *big.Int    `json:"last_processed_block,omitempty"`
+}
+
+// Backup writes a backup of the database to w. It reads from a single read transaction,
+// so it's consistent even while the node is running. It returns the number of backed up items.
+func Backup(db basedb.Database, w io.Writer) (*BackupHeader, int, error) {
+	txn := db.BeginRead()
+	defer txn.Discard()
+
+	header := &BackupHeader{
+		Version:   backupVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+	config, found, err := getConfig(txn)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get config: %w", err)
+	}
+	if !found {
+		return nil, 0, fmt.Errorf("database has no config, the node must be started before it's backed up")
+	}
+	header.Config = config
+
+	obj, found, err := txn.Get(storagePrefix, []byte(HashedPrivateKey))
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get private key hash: %w", err)
+	}
+	if !found {
+		return nil, 0, fmt.Errorf("database has no private key hash, the node must be started before it's backed up")
+	}
+	header.PrivateKeyHash = string(obj.Value)
+
+	obj, found, err = txn.Get(storagePrefix, lastProcessedBlockKey)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get last processed block: %w", err)
+	}
+	if found {
+		header.LastProcessedBlock = new(big.Int).SetBytes(obj.Value)
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not encode backup header: %w", err)
+	}
+	count, err := kv.Dump(txn, headerJSON, w)
+	if err != nil {
+		return nil, 0, err
+	}
+	return header, count, nil
+}
+
+// Restore writes a backup made by Backup into the empty database. Before writing anything,
+// it verifies the whole backup, and refuses backups of another network, or of another operator,
+// whose private key hashes to none of the given hashes. It returns the number of restored items.
+func Restore(db basedb.Database, r io.Reader, current *ConfigLock, privateKeyHashes ...string) (*BackupHeader, int, error) {
+	var header BackupHeader
+	verifyHeader := func(headerJSON []byte) error {
+		if err := json.Unmarshal(headerJSON, &header); err != nil {
+			return fmt.Errorf("could not decode backup header: %w", err)
+		}
+		if header.Version != backupVersion {
+			return fmt.Errorf("unsupported backup version %d", header.Version)
+		}
+
+		if header.Config == nil {
+			return fmt.Errorf("backup has no config")
+		}
+		if err := header.Config.EnsureSameWith(current); err != nil {
+			return fmt.Errorf("backup is incompatible with the current config: %w", err)
+		}
+		if header.PrivateKeyHash == "" || !slices.Contains(privateKeyHashes, header.PrivateKeyHash) {
+			return fmt.Errorf("backup belongs to another operator private key")
+		}
+		return nil
+	}
+
+	count, err := kv.Load(r, db, verifyHeader)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &header, count, nil
+}
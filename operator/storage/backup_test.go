@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestBackupRestore(t *testing.T) {
+	logger := logging.TestLogger(t)
+	config := &ConfigLock{NetworkName: "holesky"}
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+	nodeStorage, err := NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	// A node which was never started can't be backed up.
+	_, _, err = Backup(db, &bytes.Buffer{})
+	require.ErrorContains(t, err, "has no config")
+
+	require.NoError(t, nodeStorage.SaveConfig(nil, config))
+	require.NoError(t, nodeStorage.SavePrivateKeyHash("hash"))
+	require.NoError(t, nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(123)))
+	_, err = nodeStorage.SaveOperatorData(nil, &registrystorage.OperatorData{ID: 1, PublicKey: []byte("pk")})
+	require.NoError(t, err)
+
+	var backup bytes.Buffer
+	header, count, err := Backup(db, &backup)
+	require.NoError(t, err)
+	require.Equal(t, config, header.Config)
+	require.Equal(t, "hash", header.PrivateKeyHash)
+	require.Equal(t, big.NewInt(123), header.LastProcessedBlock)
+	require.Equal(t, 4, count)
+
+	tests := map[string]struct {
+		config *ConfigLock
+		hashes []string
+		err    string
+	}{
+		"restored": {
+			config: config,
+			hashes: []string{"legacy", "hash"},
+		},
+		"other network": {
+			config: &ConfigLock{NetworkName: "mainnet"},
+			hashes: []string{"hash"},
+			err:    `can't change network from "holesky" to "mainnet"`,
+		},
+		"local events": {
+			config: &ConfigLock{NetworkName: "holesky", UsingLocalEvents: true},
+			hashes: []string{"hash"},
+			err:    "can't switch on localevents",
+		},
+		"other operator": {
+			config: config,
+			hashes: []string{"other"},
+			err:    "another operator private key",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			target, err := kv.NewPebbleInMemory(logger, basedb.Options{})
+			require.NoError(t, err)
+			defer target.Close()
+
+			restoredHeader, restored, err := Restore(target, bytes.NewReader(backup.Bytes()), test.config, test.hashes...)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+
+				// Nothing is written to the target.
+				count, err := target.CountPrefix(nil)
+				require.NoError(t, err)
+				require.Zero(t, count)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, count, restored)
+			require.Equal(t, header.Config, restoredHeader.Config)
+
+			restoredStorage, err := NewNodeStorage(logger, target)
+			require.NoError(t, err)
+			block, found, err := restoredStorage.GetLastProcessedBlock(nil)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, big.NewInt(123), block)
+			_, found, err = restoredStorage.GetOperatorData(nil, 1)
+			require.NoError(t, err)
+			require.True(t, found)
+		})
+	}
+}
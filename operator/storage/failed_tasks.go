@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	failedTasksPrefix  = []byte("failed_tasks/")
+	failedTaskCountKey = []byte("failed_task_count")
+)
+
+// FailedTask is a task of a registry event which failed to execute, and is retried later.
+// It describes the task rather than its state, which is read again when it's retried.
+type FailedTask struct {
+	ID          uint64 `json:"id"`
+	Type        string `json:"type"`
+	BlockNumber uint64 `json:"block_number"`
+
+	ValidatorPubKeys [][]byte       `json:"validator_pubkeys,omitempty"`
+	Owner            common.Address `json:"owner"`
+	OperatorIDs      []uint64       `json:"operator_ids,omitempty"`
+	Recipient        common.Address `json:"recipient"`
+	ValidatorIndex   uint64         `json:"validator_index,omitempty"`
+
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error"`
+	CreatedAt   time.Time `json:"created_at"`
+	NextAttempt time.Time `json:"next_attempt"`
+	// Dead tasks ran out of attempts, and are only retried on demand.
+	Dead bool `json:"dead"`
+}
+
+// SaveFailedTask saves the given failed task, and assigns it an ID if it doesn't have one.
+func (s *storage) SaveFailedTask(rw basedb.ReadWriter, task *FailedTask) error {
+	if task.ID == 0 {
+		obj, found, err := s.db.UsingReader(rw).Get(storagePrefix, failedTaskCountKey)
+		if err != nil {
+			return fmt.Errorf("could not get failed task count: %w", err)
+		}
+		var count uint64
+		if found {
+			count = binary.BigEndian.Uint64(obj.Value)
+		}
+		task.ID = count + 1
+		if err := s.db.Using(rw).Set(storagePrefix, failedTaskCountKey, binary.BigEndian.AppendUint64(nil, task.ID)); err != nil {
+			return fmt.Errorf("could not save failed task count: %w", err)
+		}
+	}
+	b, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return s.db.Using(rw).Set(storagePrefix, failedTaskKey(task.ID), b)
+}
+
+// GetFailedTask returns the failed task with the given ID.
+func (s *storage) GetFailedTask(r basedb.Reader, id uint64) (*FailedTask, bool, error) {
+	obj, found, err := s.db.UsingReader(r).Get(storagePrefix, failedTaskKey(id))
+	if err != nil || !found {
+		return nil, found, err
+	}
+	task := &FailedTask{}
+	if err := json.Unmarshal(obj.Value, task); err != nil {
+		return nil, false, fmt.Errorf("unmarshal: %w", err)
+	}
+	return task, true, nil
+}
+
+// GetFailedTasks returns the saved failed tasks, ordered by their ID.
+func (s *storage) GetFailedTasks(r basedb.Reader) ([]*FailedTask, error) {
+	var tasks []*FailedTask
+	prefix := append(append([]byte{}, storagePrefix...), failedTasksPrefix...)
+	err := s.db.UsingReader(r).GetAll(prefix, func(i int, obj basedb.Obj) error {
+		task := &FailedTask{}
+		if err := json.Unmarshal(obj.Value, task); err != nil {
+			return fmt.Errorf("unmarshal: %w", err)
+		}
+		tasks = append(tasks, task)
+		return nil
+	})
+	return tasks, err
+}
+
+// DeleteFailedTask deletes the failed task with the given ID.
+func (s *storage) DeleteFailedTask(rw basedb.ReadWriter, id uint64) error {
+	return s.db.Using(rw).Delete(storagePrefix, failedTaskKey(id))
+}
+
+func (s *storage) dropFailedTasks() error {
+	return s.db.DropPrefix(append(append([]byte{}, storagePrefix...), failedTasksPrefix...))
+}
+
+// failedTaskKey orders failed tasks by their ID.
+func failedTaskKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, failedTasksPrefix...), id)
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var processedBlocksPrefix = []byte("processed_blocks/")
+
+// ProcessedBlock is a recently processed block, with what's needed to roll back
+// its events in case of a reorg.
+type ProcessedBlock struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+
+	// Undo holds the previous values of the keys which were written while processing the block.
+	Undo []UndoEntry `json:"undo,omitempty"`
+
+	// ValidatorPubKeys and Owners are the validators and owners
+	// affected by the tasks which the block's events created.
+	ValidatorPubKeys [][]byte         `json:"validator_pubkeys,omitempty"`
+	Owners           []common.Address `json:"owners,omitempty"`
+}
+
+// UndoEntry is the previous value of a key, which is deleted on rollback if it wasn't found.
+type UndoEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value,omitempty"`
+	Found bool   `json:"found"`
+}
+
+// SaveProcessedBlock saves the given processed block.
+func (s *storage) SaveProcessedBlock(rw basedb.ReadWriter, block *ProcessedBlock) error {
+	b, err := json.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return s.db.Using(rw).Set(storagePrefix, processedBlockKey(block.Number), b)
+}
+
+// GetProcessedBlocks returns the saved processed blocks, ordered by their number.
+func (s *storage) GetProcessedBlocks(r basedb.Reader) ([]*ProcessedBlock, error) {
+	var blocks []*ProcessedBlock
+	prefix := append(append([]byte{}, storagePrefix...), processedBlocksPrefix...)
+	err := s.db.UsingReader(r).GetAll(prefix, func(i int, obj basedb.Obj) error {
+		block := &ProcessedBlock{}
+		if err := json.Unmarshal(obj.Value, block); err != nil {
+			return fmt.Errorf("unmarshal: %w", err)
+		}
+		blocks = append(blocks, block)
+		return nil
+	})
+	return blocks, err
+}
+
+// DeleteProcessedBlock deletes the processed block with the given number.
+func (s *storage) DeleteProcessedBlock(rw basedb.ReadWriter, number uint64) error {
+	return s.db.Using(rw).Delete(storagePrefix, processedBlockKey(number))
+}
+
+func (s *storage) dropProcessedBlocks() error {
+	return s.db.DropPrefix(append(append([]byte{}, storagePrefix...), processedBlocksPrefix...))
+}
+
+// processedBlockKey orders processed blocks by their number.
+func processedBlockKey(number uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, processedBlocksPrefix...), number)
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	registryHistoryPrefix           = []byte("registry_history/")
+	registryHistoryStartKey         = []byte("registry_history/start")
+	registryHistorySharesPrefix     = []byte("registry_history/shares/")
+	registryHistoryRecipientsPrefix = []byte("registry_history/recipients/")
+)
+
+// ErrRegistryHistoryUnavailable is returned when the registry's state at a block
+// is queried from before the node started recording its history.
+var ErrRegistryHistoryUnavailable = errors.New("registry history is unavailable at this block")
+
+// ShareVersion is the state of a validator's share as of the block which changed it.
+type ShareVersion struct {
+	ValidatorPubKey []byte         `json:"validator_pubkey"`
+	BlockNumber     uint64         `json:"block_number"`
+	Owner           common.Address `json:"owner"`
+	OperatorIDs     []uint64       `json:"operator_ids"`
+	Liquidated      bool           `json:"liquidated"`
+	Removed         bool           `json:"removed"`
+}
+
+// RecipientVersion is an owner's fee recipient as of the block which changed it.
+type RecipientVersion struct {
+	Owner        common.Address             `json:"owner"`
+	BlockNumber  uint64                     `json:"block_number"`
+	FeeRecipient bellatrix.ExecutionAddress `json:"fee_recipient"`
+}
+
+// SaveRegistryHistoryStart saves the block from which the registry's history is recorded.
+func (s *storage) SaveRegistryHistoryStart(rw basedb.ReadWriter, blockNumber uint64) error {
+	return s.db.Using(rw).Set(storagePrefix, registryHistoryStartKey, binary.BigEndian.AppendUint64(nil, blockNumber))
+}
+
+// GetRegistryHistoryStart returns the block from which the registry's history is recorded.
+func (s *storage) GetRegistryHistoryStart(r basedb.Reader) (uint64, bool, error) {
+	obj, found, err := s.db.UsingReader(r).Get(storagePrefix, registryHistoryStartKey)
+	if err != nil || !found {
+		return 0, found, err
+	}
+	if len(obj.Value) != 8 {
+		return 0, false, fmt.Errorf("invalid registry history start")
+	}
+	return binary.BigEndian.Uint64(obj.Value), true, nil
+}
+
+// SaveShareVersion saves the given share version, replacing the share's version of the same block.
+func (s *storage) SaveShareVersion(rw basedb.ReadWriter, version *ShareVersion) error {
+	b, err := json.Marshal(version)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	key := registryHistoryKey(registryHistorySharesPrefix, version.ValidatorPubKey, version.BlockNumber)
+	return s.db.Using(rw).Set(storagePrefix, key, b)
+}
+
+// SaveRecipientVersion saves the given recipient version, replacing the owner's version of the same block.
+func (s *storage) SaveRecipientVersion(rw basedb.ReadWriter, version *RecipientVersion) error {
+	b, err := json.Marshal(version)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	key := registryHistoryKey(registryHistoryRecipientsPrefix, version.Owner.Bytes(), version.BlockNumber)
+	return s.db.Using(rw).Set(storagePrefix, key, b)
+}
+
+// GetSharesAtBlock returns the versions of the shares which existed at the given block,
+// ordered by their validator public key.
+func (s *storage) GetSharesAtBlock(r basedb.Reader, blockNumber uint64) ([]*ShareVersion, error) {
+	if err := s.checkRegistryHistory(r, blockNumber); err != nil {
+		return nil, err
+	}
+	var shares []*ShareVersion
+	err := s.getVersionsAtBlock(r, registryHistorySharesPrefix, blockNumber, func(value []byte) error {
+		version := &ShareVersion{}
+		if err := json.Unmarshal(value, version); err != nil {
+			return fmt.Errorf("unmarshal: %w", err)
+		}
+		if n := len(shares); n > 0 && string(shares[n-1].ValidatorPubKey) == string(version.ValidatorPubKey) {
+			shares[n-1] = version
+		} else {
+			shares = append(shares, version)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	existing := shares[:0]
+	for _, share := range shares {
+		if !share.Removed {
+			existing = append(existing, share)
+		}
+	}
+	return existing, nil
+}
+
+// GetRecipientsAtBlock returns the fee recipients of the owners which set one before the given block,
+// ordered by their owner.
+func (s *storage) GetRecipientsAtBlock(r basedb.Reader, blockNumber uint64) ([]*RecipientVersion, error) {
+	if err := s.checkRegistryHistory(r, blockNumber); err != nil {
+		return nil, err
+	}
+	var recipients []*RecipientVersion
+	err := s.getVersionsAtBlock(r, registryHistoryRecipientsPrefix, blockNumber, func(value []byte) error {
+		version := &RecipientVersion{}
+		if err := json.Unmarshal(value, version); err != nil {
+			return fmt.Errorf("unmarshal: %w", err)
+		}
+		if n := len(recipients); n > 0 && recipients[n-1].Owner == version.Owner {
+			recipients[n-1] = version
+		} else {
+			recipients = append(recipients, version)
+		}
+		return nil
+	})
+	return recipients, err
+}
+
+func (s *storage) checkRegistryHistory(r basedb.Reader, blockNumber uint64) error {
+	start, found, err := s.GetRegistryHistoryStart(r)
+	if err != nil {
+		return fmt.Errorf("get registry history start: %w", err)
+	}
+	if !found {
+		return ErrRegistryHistoryUnavailable
+	}
+	if blockNumber < start {
+		return fmt.Errorf("%w: history starts at block %d", ErrRegistryHistoryUnavailable, start)
+	}
+	return nil
+}
+
+// getVersionsAtBlock passes the versions of the given prefix which were saved up to the given block
+// to the given function, ordered by their entity and then by their block.
+func (s *storage) getVersionsAtBlock(r basedb.Reader, prefix []byte, blockNumber uint64, f func(value []byte) error) error {
+	fullPrefix := append(append([]byte{}, storagePrefix...), prefix...)
+	return s.db.UsingReader(r).GetAll(fullPrefix, func(i int, obj basedb.Obj) error {
+		if len(obj.Key) < 8 || binary.BigEndian.Uint64(obj.Key[len(obj.Key)-8:]) > blockNumber {
+			return nil
+		}
+		return f(obj.Value)
+	})
+}
+
+func (s *storage) dropRegistryHistory() error {
+	return s.db.DropPrefix(append(append([]byte{}, storagePrefix...), registryHistoryPrefix...))
+}
+
+// registryHistoryKey orders versions by their entity and then by their block.
+func registryHistoryKey(prefix []byte, id []byte, blockNumber uint64) []byte {
+	key := append(append([]byte{}, prefix...), id...)
+	return binary.BigEndian.AppendUint64(key, blockNumber)
+}
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/operator/keys"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const registrySnapshotVersion = 1
+
+// RegistrySnapshot is the registry state after processing the events up to a block,
+// which a node can import instead of syncing them.
+type RegistrySnapshot struct {
+	Version     int         `json:"version"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Network     string      `json:"network"`
+	BlockNumber uint64      `json:"block_number"`
+	BlockHash   common.Hash `json:"block_hash"`
+
+	Operators  []registrystorage.OperatorData   `json:"operators"`
+	Shares     [][]byte                         `json:"shares"`     // Encoded SSVShare, without an operator's own share.
+	Recipients []*registrystorage.RecipientData `json:"recipients"` // Including the nonces.
+}
+
+// SignedRegistrySnapshot is a RegistrySnapshot signed by an operator key.
+type SignedRegistrySnapshot struct {
+	Snapshot  json.RawMessage `json:"snapshot"`
+	Signer    string          `json:"signer"` // Base64 encoded operator public key.
+	Signature []byte          `json:"signature"`
+}
+
+// ExportRegistrySnapshot returns the registry at the last processed block. The BlockHash isn't set.
+func (s *storage) ExportRegistrySnapshot(network string) (*RegistrySnapshot, error) {
+	txn := s.db.BeginRead()
+	defer txn.Discard()
+
+	lastProcessedBlock, found, err := s.GetLastProcessedBlock(txn)
+	if err != nil {
+		return nil, fmt.Errorf("could not get last processed block: %w", err)
+	}
+	if !found || lastProcessedBlock == nil {
+		return nil, fmt.Errorf("registry isn't synced")
+	}
+
+	snapshot := &RegistrySnapshot{
+		Version:     registrySnapshotVersion,
+		CreatedAt:   time.Now().UTC(),
+		Network:     network,
+		BlockNumber: lastProcessedBlock.Uint64(),
+	}
+	snapshot.Operators, err = s.ListOperators(txn, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not list operators: %w", err)
+	}
+	for _, share := range s.Shares().List(txn) {
+		// The exporter's own share is set for the importer's operator from the committee.
+		exported := *share
+		exported.OperatorID = 0
+		exported.SharePubKey = nil
+		encoded, err := exported.Encode()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Shares = append(snapshot.Shares, encoded)
+	}
+	snapshot.Recipients, err = s.ListRecipients(txn)
+	if err != nil {
+		return nil, fmt.Errorf("could not list recipients: %w", err)
+	}
+	return snapshot, nil
+}
+
+// ListRecipients returns the recipient data of all owners.
+func (s *storage) ListRecipients(r basedb.Reader) ([]*registrystorage.RecipientData, error) {
+	var recipients []*registrystorage.RecipientData
+	err := s.db.UsingReader(r).GetAll(recipientsKeyPrefix(s.recipientStore), func(i int, obj basedb.Obj) error {
+		recipient := &registrystorage.RecipientData{}
+		if err := json.Unmarshal(obj.Value, recipient); err != nil {
+			return fmt.Errorf("could not unmarshal recipient data: %w", err)
+		}
+		recipients = append(recipients, recipient)
+		return nil
+	})
+	return recipients, err
+}
+
+// ImportRegistrySnapshot saves the given registry snapshot into the registry, which must not be synced,
+// so that syncing continues from the block after the snapshot's. The shares of the operator with the given
+// public key are set as its own, as they'd be if it had processed their events.
+func (s *storage) ImportRegistrySnapshot(snapshot *RegistrySnapshot, operatorPubKey []byte) error {
+	txn := s.db.Begin()
+	defer txn.Discard()
+
+	_, found, err := s.GetLastProcessedBlock(txn)
+	if err != nil {
+		return fmt.Errorf("could not get last processed block: %w", err)
+	}
+	if found {
+		return fmt.Errorf("registry is already synced")
+	}
+
+	var operatorID spectypes.OperatorID
+	for i := range snapshot.Operators {
+		if _, err := s.SaveOperatorData(txn, &snapshot.Operators[i]); err != nil {
+			return fmt.Errorf("could not save operator: %w", err)
+		}
+		if bytes.Equal(snapshot.Operators[i].PublicKey, operatorPubKey) {
+			operatorID = snapshot.Operators[i].ID
+		}
+	}
+	shares := make([]*ssvtypes.SSVShare, 0, len(snapshot.Shares))
+	for _, encoded := range snapshot.Shares {
+		share := &ssvtypes.SSVShare{}
+		if err := share.Decode(encoded); err != nil {
+			return err
+		}
+		share.OperatorID = 0
+		share.SharePubKey = nil
+		if operatorID != 0 {
+			for _, operator := range share.Committee {
+				if operator.OperatorID == operatorID {
+					share.OperatorID = operatorID
+					share.SharePubKey = operator.PubKey
+					break
+				}
+			}
+		}
+		shares = append(shares, share)
+	}
+	for _, recipient := range snapshot.Recipients {
+		if _, err := s.SaveRecipientData(txn, recipient); err != nil {
+			return fmt.Errorf("could not save recipient data: %w", err)
+		}
+	}
+
+	// The snapshot's block is verified like any processed block in case of a reorg.
+	if err := s.SaveProcessedBlock(txn, &ProcessedBlock{Number: snapshot.BlockNumber, Hash: snapshot.BlockHash}); err != nil {
+		return fmt.Errorf("could not save processed block: %w", err)
+	}
+	if err := s.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(snapshot.BlockNumber)); err != nil {
+		return fmt.Errorf("could not save last processed block: %w", err)
+	}
+
+	// Shares are saved last, as they're cached as soon as they're saved.
+	if err := s.Shares().Save(txn, shares...); err != nil {
+		return fmt.Errorf("could not save shares: %w", err)
+	}
+	if err := txn.Commit(); err != nil {
+		_ = s.Shares().Reload()
+		return fmt.Errorf("could not commit: %w", err)
+	}
+	return nil
+}
+
+// SignRegistrySnapshot signs the given snapshot with the given operator key.
+func SignRegistrySnapshot(snapshot *RegistrySnapshot, key keys.OperatorPrivateKey) (*SignedRegistrySnapshot, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode snapshot: %w", err)
+	}
+	signature, err := key.Sign(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign snapshot: %w", err)
+	}
+	signer, err := key.Public().Base64()
+	if err != nil {
+		return nil, err
+	}
+	return &SignedRegistrySnapshot{
+		Snapshot:  data,
+		Signer:    string(signer),
+		Signature: signature,
+	}, nil
+}
+
+// Verify returns the snapshot if it's signed by one of the given operator public keys (base64 encoded).
+func (s *SignedRegistrySnapshot) Verify(trustedSigners []string) (*RegistrySnapshot, error) {
+	trusted := false
+	for _, signer := range trustedSigners {
+		if signer == s.Signer {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return nil, fmt.Errorf("snapshot signer isn't trusted")
+	}
+
+	signer, err := keys.PublicKeyFromString(s.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signer: %w", err)
+	}
+	if err := signer.Verify(s.Snapshot, s.Signature); err != nil {
+		return nil, fmt.Errorf("invalid snapshot signature: %w", err)
+	}
+
+	snapshot := &RegistrySnapshot{}
+	if err := json.Unmarshal(s.Snapshot, snapshot); err != nil {
+		return nil, fmt.Errorf("could not decode snapshot: %w", err)
+	}
+	if snapshot.Version != registrySnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+	return snapshot, nil
+}
+
+func recipientsKeyPrefix(recipients registrystorage.Recipients) []byte {
+	return bytes.Join([][]byte{storagePrefix, recipients.GetRecipientsPrefix(), []byte("/")}, nil)
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/operator/keys"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestRegistrySnapshot(t *testing.T) {
+	logger := logging.TestLogger(t)
+	newStorage := func(t *testing.T) Storage {
+		db, err := kv.NewInMemory(logger, basedb.Options{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		s, err := NewNodeStorage(logger, db)
+		require.NoError(t, err)
+		return s
+	}
+
+	// Sync a registry.
+	source := newStorage(t)
+	_, err := source.ExportRegistrySnapshot("testnet")
+	require.Error(t, err, "registry isn't synced")
+
+	for id := uint64(1); id <= 4; id++ {
+		_, err := source.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:           id,
+			PublicKey:    []byte{byte(id)},
+			OwnerAddress: common.Address{byte(id)},
+		})
+		require.NoError(t, err)
+	}
+	for _, pk := range []byte{1, 2} {
+		var committee []*spectypes.Operator
+		for id := uint64(1); id <= 4; id++ {
+			committee = append(committee, &spectypes.Operator{OperatorID: id, PubKey: []byte{byte(id), pk}})
+		}
+		require.NoError(t, source.Shares().Save(nil, &types.SSVShare{
+			Share: spectypes.Share{
+				OperatorID:      1,
+				SharePubKey:     committee[0].PubKey,
+				ValidatorPubKey: spectypes.ValidatorPK{1, pk},
+				Committee:       committee,
+			},
+			Metadata: types.Metadata{OwnerAddress: common.Address{1}},
+		}))
+	}
+	nonce := registrystorage.Nonce(5)
+	_, err = source.SaveRecipientData(nil, &registrystorage.RecipientData{
+		Owner:        common.Address{1},
+		FeeRecipient: bellatrix.ExecutionAddress{2},
+		Nonce:        &nonce,
+	})
+	require.NoError(t, err)
+	require.NoError(t, source.SaveLastProcessedBlock(nil, big.NewInt(100)))
+
+	// Builder policies are the operator's own settings, so they aren't exported.
+	for _, owner := range []common.Address{{1}, {3}} {
+		require.NoError(t, source.SaveBuilderPolicy(nil, owner, nil, &beacon.BuilderPolicy{Mode: beacon.BuilderModeLocal}))
+	}
+
+	// Export and sign it.
+	snapshot, err := source.ExportRegistrySnapshot("testnet")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), snapshot.BlockNumber)
+	require.Len(t, snapshot.Operators, 4)
+	require.Len(t, snapshot.Shares, 2)
+	require.Len(t, snapshot.Recipients, 1)
+	snapshotJSON, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	require.NotContains(t, string(snapshotJSON), `"mode"`)
+
+	// The exporter's own share isn't exported.
+	exportedShare := &types.SSVShare{}
+	require.NoError(t, exportedShare.Decode(snapshot.Shares[0]))
+	require.Zero(t, exportedShare.OperatorID)
+	require.Empty(t, exportedShare.SharePubKey)
+	require.Len(t, source.Shares().List(nil, registrystorage.ByOperatorID(1)), 2)
+	snapshot.BlockHash = common.Hash{100}
+
+	signerKey, err := keys.PrivateKeyFromString(skPem)
+	require.NoError(t, err)
+	signed, err := SignRegistrySnapshot(snapshot, signerKey)
+	require.NoError(t, err)
+	require.Equal(t, pkPem, signed.Signer)
+
+	// Verify it.
+	encoded, err := json.Marshal(signed)
+	require.NoError(t, err)
+	var decoded SignedRegistrySnapshot
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	verified, err := decoded.Verify([]string{pkPem})
+	require.NoError(t, err)
+	require.Equal(t, snapshot.BlockHash, verified.BlockHash)
+
+	t.Run("untrusted signer", func(t *testing.T) {
+		otherKey, err := keys.GeneratePrivateKey()
+		require.NoError(t, err)
+		otherPubKey, err := otherKey.Public().Base64()
+		require.NoError(t, err)
+		_, err = decoded.Verify([]string{string(otherPubKey)})
+		require.ErrorContains(t, err, "isn't trusted")
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := *snapshot
+		tampered.BlockNumber = 101
+		data, err := json.Marshal(&tampered)
+		require.NoError(t, err)
+		_, err = (&SignedRegistrySnapshot{Snapshot: data, Signer: signed.Signer, Signature: signed.Signature}).Verify([]string{pkPem})
+		require.ErrorContains(t, err, "invalid snapshot signature")
+	})
+
+	// Import it.
+	target := newStorage(t)
+	require.NoError(t, target.ImportRegistrySnapshot(verified, []byte{1}))
+
+	lastProcessedBlock, found, err := target.GetLastProcessedBlock(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(100), lastProcessedBlock.Uint64())
+	processedBlocks, err := target.GetProcessedBlocks(nil)
+	require.NoError(t, err)
+	require.Len(t, processedBlocks, 1)
+	require.Equal(t, common.Hash{100}, processedBlocks[0].Hash)
+
+	operators, err := target.ListOperators(nil, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, operators, 4)
+	shares := target.Shares().List(nil, registrystorage.ByOperatorID(1))
+	require.Len(t, shares, 2)
+	require.Equal(t, common.Address{1}, shares[0].OwnerAddress)
+	require.Equal(t, []byte{1, shares[0].ValidatorPubKey[1]}, shares[0].SharePubKey)
+	recipient, found, err := target.GetRecipientData(nil, common.Address{1})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, bellatrix.ExecutionAddress{2}, recipient.FeeRecipient)
+	require.Equal(t, nonce, *recipient.Nonce)
+
+	// A synced registry can't be imported into.
+	require.ErrorContains(t, target.ImportRegistrySnapshot(verified, []byte{1}), "already synced")
+
+	t.Run("another operator", func(t *testing.T) {
+		target := newStorage(t)
+		require.NoError(t, target.ImportRegistrySnapshot(verified, []byte{2}))
+
+		require.Empty(t, target.Shares().List(nil, registrystorage.ByOperatorID(1)))
+		shares := target.Shares().List(nil, registrystorage.ByOperatorID(2))
+		require.Len(t, shares, 2)
+		for _, share := range shares {
+			require.Equal(t, []byte{2, share.ValidatorPubKey[1]}, share.SharePubKey)
+		}
+	})
+
+	t.Run("unregistered operator", func(t *testing.T) {
+		target := newStorage(t)
+		require.NoError(t, target.ImportRegistrySnapshot(verified, []byte{5}))
+
+		require.Len(t, target.Shares().List(nil), 2)
+		require.Empty(t, target.Shares().List(nil, registrystorage.ByOperatorID(1)))
+	})
+}
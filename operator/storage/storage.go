@@ -35,6 +35,29 @@ type Storage interface {
 	SaveLastProcessedBlock(rw basedb.ReadWriter, offset *big.Int) error
 	GetLastProcessedBlock(r basedb.Reader) (*big.Int, bool, error)
 
+	SaveProcessedBlock(rw basedb.ReadWriter, block *ProcessedBlock) error
+	GetProcessedBlocks(r basedb.Reader) ([]*ProcessedBlock, error)
+	DeleteProcessedBlock(rw basedb.ReadWriter, number uint64) error
+
+	SaveFailedTask(rw basedb.ReadWriter, task *FailedTask) error
+	GetFailedTask(r basedb.Reader, id uint64) (*FailedTask, bool, error)
+	GetFailedTasks(r basedb.Reader) ([]*FailedTask, error)
+	DeleteFailedTask(rw basedb.ReadWriter, id uint64) error
+
+	SaveUnknownEvent(rw basedb.ReadWriter, event *UnknownEvent) error
+	GetUnknownEvents(r basedb.Reader) ([]*UnknownEvent, error)
+
+	SaveRegistryHistoryStart(rw basedb.ReadWriter, blockNumber uint64) error
+	GetRegistryHistoryStart(r basedb.Reader) (uint64, bool, error)
+	SaveShareVersion(rw basedb.ReadWriter, version *ShareVersion) error
+	SaveRecipientVersion(rw basedb.ReadWriter, version *RecipientVersion) error
+	GetSharesAtBlock(r basedb.Reader, blockNumber uint64) ([]*ShareVersion, error)
+	GetRecipientsAtBlock(r basedb.Reader, blockNumber uint64) ([]*RecipientVersion, error)
+
+	ListRecipients(r basedb.Reader) ([]*registrystorage.RecipientData, error)
+	ExportRegistrySnapshot(network string) (*RegistrySnapshot, error)
+	ImportRegistrySnapshot(snapshot *RegistrySnapshot, operatorPubKey []byte) error
+
 	GetConfig(rw basedb.ReadWriter) (*ConfigLock, bool, error)
 	SaveConfig(rw basedb.ReadWriter, config *ConfigLock) error
 	DeleteConfig(rw basedb.ReadWriter) error
@@ -130,6 +153,14 @@ func (s *storage) DeleteRecipientData(rw basedb.ReadWriter, owner common.Address
 	return s.recipientStore.DeleteRecipientData(rw, owner)
 }
 
+func (s *storage) GetBuilderPolicy(r basedb.Reader, owner common.Address, operatorIDs []uint64) (*beacon.BuilderPolicy, error) {
+	return s.recipientStore.GetBuilderPolicy(r, owner, operatorIDs)
+}
+
+func (s *storage) SaveBuilderPolicy(rw basedb.ReadWriter, owner common.Address, operatorIDs []uint64, policy *beacon.BuilderPolicy) error {
+	return s.recipientStore.SaveBuilderPolicy(rw, owner, operatorIDs, policy)
+}
+
 func (s *storage) GetNextNonce(r basedb.Reader, owner common.Address) (registrystorage.Nonce, error) {
 	return s.recipientStore.GetNextNonce(r, owner)
 }
@@ -147,6 +178,22 @@ func (s *storage) DropRegistryData() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to drop last processed block")
 	}
+	err = s.dropProcessedBlocks()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop processed blocks")
+	}
+	err = s.dropFailedTasks()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop failed tasks")
+	}
+	err = s.dropUnknownEvents()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop unknown events")
+	}
+	err = s.dropRegistryHistory()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop registry history")
+	}
 	err = s.DropShares()
 	if err != nil {
 		return errors.Wrap(err, "failed to drop operators")
@@ -220,7 +267,11 @@ func (s *storage) UpdateValidatorMetadata(pk string, metadata *beacon.ValidatorM
 }
 
 func (s *storage) GetConfig(rw basedb.ReadWriter) (*ConfigLock, bool, error) {
-	obj, found, err := s.db.Using(rw).Get(storagePrefix, configKey)
+	return getConfig(s.db.Using(rw))
+}
+
+func getConfig(r basedb.Reader) (*ConfigLock, bool, error) {
+	obj, found, err := r.Get(storagePrefix, configKey)
 	if err != nil {
 		return nil, false, fmt.Errorf("db: %w", err)
 	}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -164,3 +166,170 @@ func TestNetworkAndLocalEventsConfig(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, c2, storedCfg)
 }
+
+func TestProcessedBlocks(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	s, err := NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	// Blocks are ordered by number rather than lexicographically.
+	for _, number := range []uint64{256, 2, 1} {
+		require.NoError(t, s.SaveProcessedBlock(nil, &ProcessedBlock{
+			Number: number,
+			Hash:   common.BigToHash(new(big.Int).SetUint64(number)),
+			Undo:   []UndoEntry{{Key: []byte("key"), Value: []byte("value"), Found: true}},
+		}))
+	}
+	blocks, err := s.GetProcessedBlocks(nil)
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	require.Equal(t, []uint64{1, 2, 256}, []uint64{blocks[0].Number, blocks[1].Number, blocks[2].Number})
+	require.Equal(t, []byte("value"), blocks[2].Undo[0].Value)
+
+	require.NoError(t, s.DeleteProcessedBlock(nil, 2))
+	blocks, err = s.GetProcessedBlocks(nil)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	require.NoError(t, s.DropRegistryData())
+	blocks, err = s.GetProcessedBlocks(nil)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+}
+
+func TestFailedTasks(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	s, err := NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	// IDs are assigned in order.
+	for i := 0; i < 3; i++ {
+		task := &FailedTask{Type: "UpdateFeeRecipient", BlockNumber: uint64(i), Owner: common.Address{1}}
+		require.NoError(t, s.SaveFailedTask(nil, task))
+		require.Equal(t, uint64(i+1), task.ID)
+	}
+	tasks, err := s.GetFailedTasks(nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+	require.Equal(t, []uint64{1, 2, 3}, []uint64{tasks[0].ID, tasks[1].ID, tasks[2].ID})
+
+	// Saving an existing task updates it.
+	tasks[1].Attempts = 2
+	require.NoError(t, s.SaveFailedTask(nil, tasks[1]))
+	task, found, err := s.GetFailedTask(nil, 2)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 2, task.Attempts)
+
+	// IDs aren't reused after deletion.
+	require.NoError(t, s.DeleteFailedTask(nil, 3))
+	_, found, err = s.GetFailedTask(nil, 3)
+	require.NoError(t, err)
+	require.False(t, found)
+	task = &FailedTask{Type: "StopValidator"}
+	require.NoError(t, s.SaveFailedTask(nil, task))
+	require.Equal(t, uint64(4), task.ID)
+
+	require.NoError(t, s.DropRegistryData())
+	tasks, err = s.GetFailedTasks(nil)
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+}
+
+func TestUnknownEvents(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	s, err := NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	// Events are ordered by their block and log index, and saving the same log again replaces it.
+	for _, event := range []*UnknownEvent{
+		{Topic: common.Hash{1}, BlockNumber: 300, LogIndex: 0},
+		{Topic: common.Hash{2}, BlockNumber: 2, LogIndex: 256},
+		{Topic: common.Hash{3}, BlockNumber: 2, LogIndex: 1},
+		{Topic: common.Hash{4}, BlockNumber: 2, LogIndex: 1},
+	} {
+		require.NoError(t, s.SaveUnknownEvent(nil, event))
+	}
+	events, err := s.GetUnknownEvents(nil)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, []common.Hash{{4}, {2}, {1}}, []common.Hash{events[0].Topic, events[1].Topic, events[2].Topic})
+
+	require.NoError(t, s.DropRegistryData())
+	events, err = s.GetUnknownEvents(nil)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestRegistryHistory(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	s, err := NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	// History isn't available until it's started.
+	_, err = s.GetSharesAtBlock(nil, 100)
+	require.ErrorIs(t, err, ErrRegistryHistoryUnavailable)
+	require.NoError(t, s.SaveRegistryHistoryStart(nil, 10))
+	_, err = s.GetRecipientsAtBlock(nil, 9)
+	require.ErrorIs(t, err, ErrRegistryHistoryUnavailable)
+
+	pk1, pk2 := bytes.Repeat([]byte{1}, 48), bytes.Repeat([]byte{2}, 48)
+	for _, version := range []*ShareVersion{
+		{ValidatorPubKey: pk1, BlockNumber: 10, OperatorIDs: []uint64{1, 2, 3, 4}},
+		{ValidatorPubKey: pk2, BlockNumber: 12, OperatorIDs: []uint64{1, 2, 3, 4}},
+		{ValidatorPubKey: pk1, BlockNumber: 300, OperatorIDs: []uint64{1, 2, 3, 4}, Liquidated: true},
+		{ValidatorPubKey: pk2, BlockNumber: 400, Removed: true},
+	} {
+		require.NoError(t, s.SaveShareVersion(nil, version))
+	}
+	require.NoError(t, s.SaveRecipientVersion(nil, &RecipientVersion{Owner: common.Address{1}, BlockNumber: 11, FeeRecipient: bellatrix.ExecutionAddress{1}}))
+	require.NoError(t, s.SaveRecipientVersion(nil, &RecipientVersion{Owner: common.Address{1}, BlockNumber: 500, FeeRecipient: bellatrix.ExecutionAddress{2}}))
+
+	shares, err := s.GetSharesAtBlock(nil, 10)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	require.Equal(t, pk1, shares[0].ValidatorPubKey)
+
+	shares, err = s.GetSharesAtBlock(nil, 300)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+	require.True(t, shares[0].Liquidated)
+	require.False(t, shares[1].Liquidated)
+
+	shares, err = s.GetSharesAtBlock(nil, 400)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	require.Equal(t, pk1, shares[0].ValidatorPubKey)
+
+	recipients, err := s.GetRecipientsAtBlock(nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, recipients)
+	recipients, err = s.GetRecipientsAtBlock(nil, 499)
+	require.NoError(t, err)
+	require.Len(t, recipients, 1)
+	require.Equal(t, bellatrix.ExecutionAddress{1}, recipients[0].FeeRecipient)
+	recipients, err = s.GetRecipientsAtBlock(nil, 500)
+	require.NoError(t, err)
+	require.Equal(t, bellatrix.ExecutionAddress{2}, recipients[0].FeeRecipient)
+
+	require.NoError(t, s.DropRegistryData())
+	_, found, err := s.GetRegistryHistoryStart(nil)
+	require.NoError(t, err)
+	require.False(t, found)
+}
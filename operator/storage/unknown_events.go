@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var unknownEventsPrefix = []byte("unknown_events/")
+
+// UnknownEvent is a registry contract event which isn't in the contract's ABI,
+// presumably because the contract was upgraded, so it wasn't processed.
+type UnknownEvent struct {
+	Topic       common.Hash `json:"topic"`
+	BlockNumber uint64      `json:"block_number"`
+	BlockHash   common.Hash `json:"block_hash"`
+	TxHash      common.Hash `json:"tx_hash"`
+	LogIndex    uint        `json:"log_index"`
+	SeenAt      time.Time   `json:"seen_at"`
+}
+
+// SaveUnknownEvent saves the given unknown event, replacing the one of the same log if it was seen before.
+func (s *storage) SaveUnknownEvent(rw basedb.ReadWriter, event *UnknownEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return s.db.Using(rw).Set(storagePrefix, unknownEventKey(event.BlockNumber, event.LogIndex), b)
+}
+
+// GetUnknownEvents returns the saved unknown events, ordered by their block and log index.
+func (s *storage) GetUnknownEvents(r basedb.Reader) ([]*UnknownEvent, error) {
+	var events []*UnknownEvent
+	prefix := append(append([]byte{}, storagePrefix...), unknownEventsPrefix...)
+	err := s.db.UsingReader(r).GetAll(prefix, func(i int, obj basedb.Obj) error {
+		event := &UnknownEvent{}
+		if err := json.Unmarshal(obj.Value, event); err != nil {
+			return fmt.Errorf("unmarshal: %w", err)
+		}
+		events = append(events, event)
+		return nil
+	})
+	return events, err
+}
+
+func (s *storage) dropUnknownEvents() error {
+	return s.db.DropPrefix(append(append([]byte{}, storagePrefix...), unknownEventsPrefix...))
+}
+
+// unknownEventKey orders unknown events by their block and log index.
+func unknownEventKey(blockNumber uint64, logIndex uint) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, unknownEventsPrefix...), blockNumber)
+	return binary.BigEndian.AppendUint64(key, uint64(logIndex))
+}
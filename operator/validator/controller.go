@@ -27,6 +27,7 @@ import (
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/duties"
 	nodestorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validatorevents"
 	"github.com/bloxapp/ssv/operator/validatorsmap"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/message"
@@ -78,6 +79,7 @@ type ControllerOptions struct {
 	Metrics                    validator.Metrics
 	MessageValidator           validation.MessageValidator
 	ValidatorsMap              *validatorsmap.ValidatorsMap
+	ValidatorEvents            *validatorevents.Feed
 	Graffiti                   []byte
 
 	// worker flags
@@ -158,6 +160,8 @@ type controller struct {
 
 	validatorOptions        validator.Options
 	validatorsMap           *validatorsmap.ValidatorsMap
+	validatorEvents         *validatorevents.Feed
+	validatorStatuses       sync.Map
 	validatorStartFunc      func(validator *validator.Validator) (bool, error)
 	committeeValidatorSetup chan struct{}
 
@@ -209,6 +213,9 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		MessageValidator:  options.MessageValidator,
 		Metrics:           options.Metrics,
 		Graffiti:          options.Graffiti,
+		BuilderPolicy: func(owner common.Address, operatorIDs []uint64) (*beaconprotocol.BuilderPolicy, error) {
+			return options.RegistryStorage.GetBuilderPolicy(nil, owner, operatorIDs)
+		},
 	}
 
 	// If full node, increase queue size to make enough room
@@ -239,6 +246,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		network:           options.Network,
 
 		validatorsMap:    options.ValidatorsMap,
+		validatorEvents:  options.ValidatorEvents,
 		validatorOptions: validatorOptions,
 
 		metadataUpdateInterval: options.MetadataUpdateInterval,
@@ -302,7 +310,7 @@ func (c *controller) GetValidatorStats() (uint64, uint64, uint64, error) {
 		if ok := s.BelongsToOperator(c.operatorDataStore.GetOperatorID()); ok {
 			operatorShares++
 		}
-		if s.IsAttesting(c.beacon.GetBeaconNetwork().EstimatedCurrentEpoch()) {
+		if s.IsAttesting(c.validatorOptions.BeaconNetwork.EstimatedCurrentEpoch()) {
 			active++
 		}
 	}
@@ -377,7 +385,7 @@ func (c *controller) handleWorkerMessages(msg *queue.DecodedSSVMessage) error {
 			c.nonCommitteeValidators.Set(
 				msg.GetID(),
 				ncv,
-				time.Duration(ttlSlots)*c.beacon.GetBeaconNetwork().SlotDurationSec(),
+				time.Duration(ttlSlots)*c.validatorOptions.BeaconNetwork.SlotDurationSec(),
 			)
 		}
 
@@ -548,12 +556,16 @@ func (c *controller) UpdateValidatorMetadata(pk string, metadata *beaconprotocol
 
 	// Start validator (if not already started).
 	if v, found := c.validatorsMap.GetValidator(pk); found {
+		if v.Share.BeaconMetadata == nil || !v.Share.BeaconMetadata.Equals(metadata) {
+			c.publishEvent(validatorevents.MetadataUpdated, share)
+		}
 		v.Share.BeaconMetadata = metadata
 		_, err := c.startValidator(v)
 		if err != nil {
 			c.logger.Warn("could not start validator", zap.Error(err))
 		}
 	} else {
+		c.publishEvent(validatorevents.MetadataUpdated, share)
 		c.logger.Info("starting new validator", zap.String("pubKey", pk))
 
 		started, err := c.onShareStart(share)
@@ -672,8 +684,9 @@ func (c *controller) onMetadataUpdated(pk string, meta *beaconprotocol.Validator
 	}
 }
 
-// onShareStop is called when a validator was removed or liquidated
-func (c *controller) onShareStop(pubKey spectypes.ValidatorPK) {
+// onShareStop is called when a validator was removed or liquidated,
+// it returns the stopped validator or nil if it wasn't running.
+func (c *controller) onShareStop(pubKey spectypes.ValidatorPK) *validator.Validator {
 	// remove from ValidatorsMap
 	v := c.validatorsMap.RemoveValidator(hex.EncodeToString(pubKey))
 
@@ -681,6 +694,8 @@ func (c *controller) onShareStop(pubKey spectypes.ValidatorPK) {
 	if v != nil {
 		v.Stop()
 	}
+	c.validatorStatuses.Delete(hex.EncodeToString(pubKey))
+	return v
 }
 
 func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator, error) {
@@ -772,7 +787,8 @@ func (c *controller) validatorStart(validator *validator.Validator) (bool, error
 
 // startValidator will start the given validator if applicable
 func (c *controller) startValidator(v *validator.Validator) (bool, error) {
-	c.reportValidatorStatus(v.Share.ValidatorPubKey, v.Share.BeaconMetadata)
+	status := c.reportValidatorStatus(v.Share.ValidatorPubKey, v.Share.BeaconMetadata)
+	c.publishStatus(v.Share, status)
 	if v.Share.BeaconMetadata.Index == 0 {
 		return false, errors.New("could not start validator: index not found")
 	}
@@ -783,6 +799,7 @@ func (c *controller) startValidator(v *validator.Validator) (bool, error) {
 	}
 	if started {
 		c.recentlyStartedValidators++
+		c.publishEvent(validatorevents.Started, v.Share)
 	}
 	return true, nil
 }
@@ -850,7 +867,7 @@ func (c *controller) UpdateValidatorMetaDataLoop() {
 func (c *controller) updateValidatorsMetadata(logger *zap.Logger, pks [][]byte, storage beaconprotocol.ValidatorMetadataStorage, beacon beaconprotocol.BeaconNode, onMetadataUpdated func(pk string, meta *beaconprotocol.ValidatorMetadata)) error {
 	// Fetch metadata for all validators.
 	c.recentlyStartedValidators = 0
-	beforeUpdate := c.AllActiveIndices(c.beacon.GetBeaconNetwork().EstimatedCurrentEpoch(), false)
+	beforeUpdate := c.AllActiveIndices(c.validatorOptions.BeaconNetwork.EstimatedCurrentEpoch(), false)
 
 	err := beaconprotocol.UpdateValidatorsMetadata(logger, pks, storage, beacon, onMetadataUpdated)
 	if err != nil {
@@ -858,7 +875,7 @@ func (c *controller) updateValidatorsMetadata(logger *zap.Logger, pks [][]byte,
 	}
 
 	// Refresh duties if there are any new active validators.
-	afterUpdate := c.AllActiveIndices(c.beacon.GetBeaconNetwork().EstimatedCurrentEpoch(), false)
+	afterUpdate := c.AllActiveIndices(c.validatorOptions.BeaconNetwork.EstimatedCurrentEpoch(), false)
 	if c.recentlyStartedValidators > 0 || hasNewValidators(beforeUpdate, afterUpdate) {
 		c.logger.Debug("new validators found after metadata update",
 			zap.Int("before", len(beforeUpdate)),
@@ -867,7 +884,7 @@ func (c *controller) updateValidatorsMetadata(logger *zap.Logger, pks [][]byte,
 		)
 		select {
 		case c.indicesChange <- struct{}{}:
-		case <-time.After(2 * c.beacon.GetBeaconNetwork().SlotDurationSec()):
+		case <-time.After(2 * c.validatorOptions.BeaconNetwork.SlotDurationSec()):
 			c.logger.Warn("timed out while notifying DutyScheduler of new validators")
 		}
 	}
@@ -935,28 +952,39 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 		case spectypes.BNRoleAttester:
 			valCheck := specssv.AttesterValueCheckF(options.Signer, options.BeaconNetwork.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey)
 			qbftCtrl := buildController(spectypes.BNRoleAttester, valCheck)
-			runners[role] = runner.NewAttesterRunnner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, valCheck, 0)
+			runners[role] = runner.NewAttesterRunnner(options.BeaconNetwork.GetNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, valCheck, 0)
 		case spectypes.BNRoleProposer:
 			proposedValueCheck := specssv.ProposerValueCheckF(options.Signer, options.BeaconNetwork.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey)
 			qbftCtrl := buildController(spectypes.BNRoleProposer, proposedValueCheck)
-			runners[role] = runner.NewProposerRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, proposedValueCheck, 0, options.Graffiti)
+			proposerRunner := runner.NewProposerRunner(options.BeaconNetwork.GetNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, proposedValueCheck, 0, options.Graffiti)
+			if options.BuilderPolicy != nil {
+				share := options.SSVShare
+				proposerRunner.(*runner.ProposerRunner).BuilderPolicy = func() (*beaconprotocol.BuilderPolicy, error) {
+					operatorIDs := make([]uint64, len(share.Committee))
+					for i, operator := range share.Committee {
+						operatorIDs[i] = operator.OperatorID
+					}
+					return options.BuilderPolicy(share.OwnerAddress, operatorIDs)
+				}
+			}
+			runners[role] = proposerRunner
 		case spectypes.BNRoleAggregator:
 			aggregatorValueCheckF := specssv.AggregatorValueCheckF(options.Signer, options.BeaconNetwork.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.BNRoleAggregator, aggregatorValueCheckF)
-			runners[role] = runner.NewAggregatorRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, aggregatorValueCheckF, 0)
+			runners[role] = runner.NewAggregatorRunner(options.BeaconNetwork.GetNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, aggregatorValueCheckF, 0)
 		case spectypes.BNRoleSyncCommittee:
 			syncCommitteeValueCheckF := specssv.SyncCommitteeValueCheckF(options.Signer, options.BeaconNetwork.GetBeaconNetwork(), options.SSVShare.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.BNRoleSyncCommittee, syncCommitteeValueCheckF)
-			runners[role] = runner.NewSyncCommitteeRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, syncCommitteeValueCheckF, 0)
+			runners[role] = runner.NewSyncCommitteeRunner(options.BeaconNetwork.GetNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, syncCommitteeValueCheckF, 0)
 		case spectypes.BNRoleSyncCommitteeContribution:
 			syncCommitteeContributionValueCheckF := specssv.SyncCommitteeContributionValueCheckF(options.Signer, options.BeaconNetwork.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.BNRoleSyncCommitteeContribution, syncCommitteeContributionValueCheckF)
-			runners[role] = runner.NewSyncCommitteeAggregatorRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, syncCommitteeContributionValueCheckF, 0)
+			runners[role] = runner.NewSyncCommitteeAggregatorRunner(options.BeaconNetwork.GetNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, syncCommitteeContributionValueCheckF, 0)
 		case spectypes.BNRoleValidatorRegistration:
 			qbftCtrl := buildController(spectypes.BNRoleValidatorRegistration, nil)
-			runners[role] = runner.NewValidatorRegistrationRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer)
+			runners[role] = runner.NewValidatorRegistrationRunner(options.BeaconNetwork.GetNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer)
 		case spectypes.BNRoleVoluntaryExit:
-			runners[role] = runner.NewVoluntaryExitRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, options.Beacon, options.Network, options.Signer)
+			runners[role] = runner.NewVoluntaryExitRunner(options.BeaconNetwork.GetNetwork(), &options.SSVShare.Share, options.Beacon, options.Network, options.Signer)
 		}
 	}
 	return runners
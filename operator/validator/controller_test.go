@@ -245,7 +245,8 @@ func TestSetupValidatorsExporter(t *testing.T) {
 				recipientsStorage: recipientStorage,
 				validatorsMap:     mockValidatorsMap,
 				validatorOptions: validator.Options{
-					Exporter: true,
+					Exporter:      true,
+					BeaconNetwork: networkconfig.Mainnet.Beacon,
 				},
 				metrics:             validator.NopMetrics{},
 				metadataLastUpdated: map[string]time.Time{},
@@ -773,6 +774,9 @@ func TestGetValidatorStats(t *testing.T) {
 			validatorsMap:     validatorsmap.New(context.TODO()),
 			operatorDataStore: operatordatastore.New(buildOperatorData(1, "67Ce5c69260bd819B4e0AD13f4b873074D479811")),
 			beacon:            bc,
+			validatorOptions: validator.Options{
+				BeaconNetwork: netCfg.Beacon,
+			},
 		}
 
 		ctr := setupController(logger, controllerOptions)
@@ -823,6 +827,9 @@ func TestGetValidatorStats(t *testing.T) {
 			validatorsMap:     validatorsmap.New(context.TODO()),
 			operatorDataStore: operatordatastore.New(buildOperatorData(1, "67Ce5c69260bd819B4e0AD13f4b873074D479811")),
 			beacon:            bc,
+			validatorOptions: validator.Options{
+				BeaconNetwork: netCfg.Beacon,
+			},
 		}
 		ctr := setupController(logger, controllerOptions)
 
@@ -861,6 +868,9 @@ func TestGetValidatorStats(t *testing.T) {
 			validatorsMap:     validatorsmap.New(context.TODO()),
 			operatorDataStore: operatordatastore.New(buildOperatorData(1, "67Ce5c69260bd819B4e0AD13f4b873074D479811")),
 			beacon:            bc,
+			validatorOptions: validator.Options{
+				BeaconNetwork: netCfg.Beacon,
+			},
 		}
 		ctr := setupController(logger, controllerOptions)
 
@@ -921,6 +931,9 @@ func TestGetValidatorStats(t *testing.T) {
 			validatorsMap:     validatorsmap.New(context.TODO()),
 			operatorDataStore: operatordatastore.New(buildOperatorData(1, "67Ce5c69260bd819B4e0AD13f4b873074D479811")),
 			beacon:            bc,
+			validatorOptions: validator.Options{
+				BeaconNetwork: netCfg.Beacon,
+			},
 		}
 		ctr := setupController(logger, controllerOptions)
 
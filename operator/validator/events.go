@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"encoding/hex"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/operator/validatorevents"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// publishEvent publishes a lifecycle event of the given share's validator, if events are enabled.
+func (c *controller) publishEvent(eventType validatorevents.Type, share *ssvtypes.SSVShare) {
+	if c.validatorEvents == nil {
+		return
+	}
+	c.validatorEvents.Publish(shareEvent(eventType, share))
+}
+
+// publishStatus publishes a StatusChanged event if the given status differs from the last published one.
+func (c *controller) publishStatus(share *ssvtypes.SSVShare, status string) {
+	if c.validatorEvents == nil {
+		return
+	}
+	previous, loaded := c.validatorStatuses.Swap(hex.EncodeToString(share.ValidatorPubKey), status)
+	if loaded && previous.(string) == status {
+		return
+	}
+	event := shareEvent(validatorevents.StatusChanged, share)
+	event.Status = status
+	c.validatorEvents.Publish(event)
+}
+
+func shareEvent(eventType validatorevents.Type, share *ssvtypes.SSVShare) validatorevents.Event {
+	committee := make([]spectypes.OperatorID, len(share.Committee))
+	for i, operator := range share.Committee {
+		committee[i] = operator.OperatorID
+	}
+	return validatorevents.Event{
+		Type:      eventType,
+		PubKey:    share.ValidatorPubKey,
+		Owner:     share.OwnerAddress,
+		Committee: committee,
+	}
+}
@@ -5,31 +5,41 @@ import (
 	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
-func (c *controller) reportValidatorStatus(pk []byte, meta *beacon.ValidatorMetadata) {
+// reportValidatorStatus reports the validator's status to metrics and returns its name.
+func (c *controller) reportValidatorStatus(pk []byte, meta *beacon.ValidatorMetadata) string {
 	logger := c.logger.With(fields.PubKey(pk), fields.ValidatorMetadata(meta))
-	if meta == nil {
+	switch {
+	case meta == nil:
 		logger.Debug("validator metadata not found")
 		c.metrics.ValidatorNotFound(pk)
-	} else if meta.IsActive() {
+		return "not_found"
+	case meta.IsActive():
 		logger.Debug("validator is ready")
 		c.metrics.ValidatorReady(pk)
-	} else if meta.Slashed() {
+		return "ready"
+	case meta.Slashed():
 		logger.Debug("validator slashed")
 		c.metrics.ValidatorSlashed(pk)
-	} else if meta.Exiting() {
+		return "slashed"
+	case meta.Exiting():
 		logger.Debug("validator exiting / exited")
 		c.metrics.ValidatorExiting(pk)
-	} else if !meta.Activated() {
+		return "exiting"
+	case !meta.Activated():
 		logger.Debug("validator not activated")
 		c.metrics.ValidatorNotActivated(pk)
-	} else if meta.Pending() {
+		return "not_activated"
+	case meta.Pending():
 		logger.Debug("validator pending")
 		c.metrics.ValidatorPending(pk)
-	} else if meta.Index == 0 {
+		return "pending"
+	case meta.Index == 0:
 		logger.Debug("validator index not found")
 		c.metrics.ValidatorNoIndex(pk)
-	} else {
+		return "no_index"
+	default:
 		logger.Debug("validator is unknown")
 		c.metrics.ValidatorUnknown(pk)
+		return "unknown"
 	}
 }
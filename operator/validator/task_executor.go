@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"encoding/hex"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/operator/duties"
+	"github.com/bloxapp/ssv/operator/validatorevents"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/validator"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
@@ -35,7 +37,9 @@ func (c *controller) StopValidator(pubKey spectypes.ValidatorPK) error {
 	logger := c.taskLogger("StopValidator", fields.PubKey(pubKey))
 
 	c.metrics.ValidatorRemoved(pubKey)
-	c.onShareStop(pubKey)
+	if v := c.onShareStop(pubKey); v != nil {
+		c.publishEvent(validatorevents.Stopped, v.Share)
+	}
 
 	logger.Info("removed validator")
 
@@ -47,6 +51,7 @@ func (c *controller) LiquidateCluster(owner common.Address, operatorIDs []specty
 
 	for _, share := range toLiquidate {
 		c.onShareStop(share.ValidatorPubKey)
+		c.publishEvent(validatorevents.Liquidated, share)
 		logger.With(fields.PubKey(share.ValidatorPubKey)).Debug("liquidated share")
 	}
 
@@ -72,7 +77,7 @@ func (c *controller) ReactivateCluster(owner common.Address, operatorIDs []spect
 		go func() {
 			select {
 			case c.indicesChange <- struct{}{}:
-			case <-time.After(12 * time.Second):
+			case <-time.After(c.validatorOptions.BeaconNetwork.SlotDurationSec()):
 				logger.Error("failed to notify indices change")
 			}
 		}()
@@ -118,7 +123,10 @@ func (c *controller) ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64,
 		select {
 		case c.validatorExitCh <- exitDesc:
 			logger.Debug("added voluntary exit task to pipeline")
-		case <-time.After(2 * c.beacon.GetBeaconNetwork().SlotDurationSec()):
+			if v, found := c.validatorsMap.GetValidator(hex.EncodeToString(pubKey[:])); found {
+				c.publishEvent(validatorevents.Exited, v.Share)
+			}
+		case <-time.After(2 * c.validatorOptions.BeaconNetwork.SlotDurationSec()):
 			logger.Error("failed to schedule ExitValidator duty!")
 		}
 	}()
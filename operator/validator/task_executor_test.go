@@ -7,6 +7,7 @@ import (
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/golang/mock/gomock"
@@ -16,6 +17,8 @@ import (
 	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
 	"github.com/bloxapp/ssv/networkconfig"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/operator/duties"
+	"github.com/bloxapp/ssv/operator/validatorevents"
 	"github.com/bloxapp/ssv/operator/validatorsmap"
 	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
@@ -144,6 +147,74 @@ func TestController_StopValidator(t *testing.T) {
 	require.False(t, ok, "validator still exists")
 }
 
+func TestController_ExitValidator(t *testing.T) {
+	secretKey := &bls.SecretKey{}
+	require.NoError(t, secretKey.SetHexString(sk1Str))
+	share := &types.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: secretKey.GetPublicKey().Serialize(),
+		},
+	}
+
+	ctrl, logger, sharesStorage, network, _, recipientStorage, bc := setupCommonTestComponents(t)
+	defer ctrl.Finish()
+
+	testValidatorsMap := map[string]*validator.Validator{
+		secretKey.GetPublicKey().SerializeToHexStr(): {Share: share},
+	}
+	controllerOptions := MockControllerOptions{
+		beacon:            bc,
+		network:           network,
+		sharesStorage:     sharesStorage,
+		recipientsStorage: recipientStorage,
+		validatorsMap:     validatorsmap.New(context.TODO(), validatorsmap.WithInitialState(testValidatorsMap)),
+		validatorOptions: validator.Options{
+			BeaconNetwork: beacon.NewNetwork(spectypes.PraterNetwork).WithSpec(beacon.Spec{SlotDuration: 50 * time.Millisecond}),
+		},
+		metrics:             validator.NopMetrics{},
+		metadataLastUpdated: map[string]time.Time{},
+	}
+	ctr := setupController(logger, controllerOptions)
+	ctr.validatorEvents = validatorevents.New()
+	ctr.validatorExitCh = make(chan duties.ExitDescriptor)
+
+	events, unsubscribe := ctr.validatorEvents.Subscribe(1)
+	defer unsubscribe()
+
+	pubKey := phase0.BLSPubKey(share.ValidatorPubKey)
+
+	t.Run("exit not scheduled", func(t *testing.T) {
+		require.NoError(t, ctr.ExitValidator(pubKey, 1, 1))
+
+		select {
+		case event := <-events:
+			require.FailNow(t, "unexpected event", "%v", event)
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("exit scheduled", func(t *testing.T) {
+		require.NoError(t, ctr.ExitValidator(pubKey, 2, 1))
+
+		select {
+		case event := <-events:
+			require.FailNow(t, "event published before the exit was scheduled", "%v", event)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		exitDesc := <-ctr.validatorExitCh
+		require.Equal(t, uint64(2), exitDesc.BlockNumber)
+
+		select {
+		case event := <-events:
+			require.Equal(t, validatorevents.Exited, event.Type)
+			require.Equal(t, share.ValidatorPubKey, event.PubKey)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for the exited event")
+		}
+	})
+}
+
 func TestController_ReactivateCluster(t *testing.T) {
 	storageMap := ibftstorage.NewStores()
 
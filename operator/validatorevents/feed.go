@@ -0,0 +1,102 @@
+package validatorevents
+
+import (
+	"sync"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultBufferSize is the number of events buffered for each subscriber
+// before further events are dropped for it.
+const DefaultBufferSize = 256
+
+// Type is the kind of transition a validator went through.
+type Type string
+
+const (
+	Started         Type = "started"
+	Stopped         Type = "stopped"
+	Liquidated      Type = "liquidated"
+	MetadataUpdated Type = "metadata_updated"
+	Exited          Type = "exited"
+	StatusChanged   Type = "status_changed"
+)
+
+// Event describes a single validator lifecycle transition.
+type Event struct {
+	Type      Type
+	Time      time.Time
+	PubKey    spectypes.ValidatorPK
+	Owner     common.Address
+	Committee []spectypes.OperatorID
+
+	// Status is the validator's beacon status, as reported by the metrics hooks (e.g. "ready").
+	// Only set for StatusChanged events.
+	Status string
+}
+
+// Feed fans out validator lifecycle events to its subscribers.
+// Publishing never blocks: events are dropped for subscribers which don't keep up.
+type Feed struct {
+	mu          sync.RWMutex
+	subscribers map[*subscription]struct{}
+}
+
+func New() *Feed {
+	return &Feed{
+		subscribers: make(map[*subscription]struct{}),
+	}
+}
+
+type subscription struct {
+	ch chan Event
+}
+
+// Subscribe returns a channel of events and a function to unsubscribe from the feed.
+// The channel is closed once unsubscribed.
+func (f *Feed) Subscribe(bufferSize int) (<-chan Event, func()) {
+	sub := &subscription{
+		ch: make(chan Event, bufferSize),
+	}
+
+	f.mu.Lock()
+	f.subscribers[sub] = struct{}{}
+	f.mu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			f.mu.Lock()
+			delete(f.subscribers, sub)
+			f.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+}
+
+// Publish sends the event to all subscribers without blocking.
+func (f *Feed) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for sub := range f.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of current subscribers.
+func (f *Feed) Subscribers() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return len(f.subscribers)
+}
@@ -0,0 +1,32 @@
+package validatorevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed(t *testing.T) {
+	feed := New()
+
+	events, unsubscribe := feed.Subscribe(1)
+	require.Equal(t, 1, feed.Subscribers())
+
+	feed.Publish(Event{Type: Started, PubKey: []byte{1}})
+	// The subscriber's buffer is full, so this event must be dropped rather than block.
+	feed.Publish(Event{Type: Stopped, PubKey: []byte{1}})
+
+	event := <-events
+	require.Equal(t, Started, event.Type)
+	require.False(t, event.Time.IsZero())
+
+	unsubscribe()
+	unsubscribe()
+	require.Equal(t, 0, feed.Subscribers())
+
+	_, ok := <-events
+	require.False(t, ok)
+
+	// Publishing without subscribers is a no-op.
+	feed.Publish(Event{Type: Exited})
+}
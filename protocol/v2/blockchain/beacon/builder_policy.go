@@ -0,0 +1,72 @@
+package beacon
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// BuilderMode decides between locally built and builder (MEV relay) execution payloads.
+type BuilderMode string
+
+const (
+	// BuilderModeDefault leaves the choice to the beacon node.
+	BuilderModeDefault BuilderMode = ""
+	// BuilderModeLocal always proposes locally built payloads.
+	BuilderModeLocal BuilderMode = "local"
+	// BuilderModeBuilder always proposes builder payloads, when the beacon node has one.
+	BuilderModeBuilder BuilderMode = "builder"
+	// BuilderModeMinBid proposes builder payloads only if they're worth at least MinBid.
+	BuilderModeMinBid BuilderMode = "min-bid"
+)
+
+// BuilderPolicy is the builder preference of an owner or a cluster.
+type BuilderPolicy struct {
+	Mode BuilderMode `json:"mode"`
+	// MinBid is the minimum value, in wei, of a builder payload in BuilderModeMinBid.
+	MinBid *big.Int `json:"minBid,omitempty"`
+}
+
+// Validate returns an error if the policy is malformed.
+func (p *BuilderPolicy) Validate() error {
+	switch p.Mode {
+	case BuilderModeDefault, BuilderModeLocal, BuilderModeBuilder:
+		if p.MinBid != nil {
+			return fmt.Errorf("minimum bid is only supported in %q mode", BuilderModeMinBid)
+		}
+		return nil
+	case BuilderModeMinBid:
+		if p.MinBid == nil || p.MinBid.Sign() < 0 {
+			return fmt.Errorf("%q mode requires a non-negative minimum bid", BuilderModeMinBid)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown builder mode %q", p.Mode)
+	}
+}
+
+// BoostFactor returns the builder boost factor to request proposals with, or nil for the beacon node's default.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/produceBlockV3
+func (p *BuilderPolicy) BoostFactor() *uint64 {
+	var factor uint64
+	switch {
+	case p == nil:
+		return nil
+	case p.Mode == BuilderModeLocal:
+		factor = 0
+	case p.Mode == BuilderModeBuilder:
+		factor = math.MaxUint64
+	default:
+		return nil
+	}
+	return &factor
+}
+
+// BuilderPolicyProposer is implemented by beacon nodes which can apply a builder policy to proposals.
+type BuilderPolicyProposer interface {
+	GetBeaconBlockWithBuilderPolicy(slot phase0.Slot, graffiti, randao []byte, policy *BuilderPolicy) (ssz.Marshaler, spec.DataVersion, error)
+}
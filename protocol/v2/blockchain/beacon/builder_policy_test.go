@@ -0,0 +1,26 @@
+package beacon
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderPolicy(t *testing.T) {
+	require.NoError(t, (&BuilderPolicy{}).Validate())
+	require.NoError(t, (&BuilderPolicy{Mode: BuilderModeLocal}).Validate())
+	require.NoError(t, (&BuilderPolicy{Mode: BuilderModeMinBid, MinBid: big.NewInt(0)}).Validate())
+	require.ErrorContains(t, (&BuilderPolicy{Mode: BuilderModeMinBid}).Validate(), "requires a non-negative minimum bid")
+	require.ErrorContains(t, (&BuilderPolicy{Mode: BuilderModeMinBid, MinBid: big.NewInt(-1)}).Validate(), "requires a non-negative minimum bid")
+	require.ErrorContains(t, (&BuilderPolicy{Mode: BuilderModeBuilder, MinBid: big.NewInt(1)}).Validate(), "minimum bid is only supported")
+	require.ErrorContains(t, (&BuilderPolicy{Mode: "relay"}).Validate(), "unknown builder mode")
+
+	var nilPolicy *BuilderPolicy
+	require.Nil(t, nilPolicy.BoostFactor())
+	require.Nil(t, (&BuilderPolicy{}).BoostFactor())
+	require.Nil(t, (&BuilderPolicy{Mode: BuilderModeMinBid, MinBid: big.NewInt(1)}).BoostFactor())
+	require.Equal(t, uint64(0), *(&BuilderPolicy{Mode: BuilderModeLocal}).BoostFactor())
+	require.Equal(t, uint64(math.MaxUint64), *(&BuilderPolicy{Mode: BuilderModeBuilder}).BoostFactor())
+}
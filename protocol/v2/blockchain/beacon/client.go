@@ -60,9 +60,13 @@ type BeaconNode interface {
 type Options struct {
 	Context        context.Context
 	Network        Network
-	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
+	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"Beacon node URL, or multiple semicolon separated URLs in order of preference"`
 	Graffiti       []byte
 	GasLimit       uint64
 	CommonTimeout  time.Duration // Optional.
 	LongTimeout    time.Duration // Optional.
+
+	// AttestationDataCheck compares the attestation data of multiple beacon nodes before it's used.
+	AttestationDataCheck     string `yaml:"AttestationDataCheck" env:"ATTESTATION_DATA_CHECK" env-description:"Compare attestation data across beacon nodes: empty to disable, 'flag' to only report disagreements, 'refuse' to also refuse the data"`
+	AttestationDataCheckHead bool   `yaml:"AttestationDataCheckHead" env:"ATTESTATION_DATA_CHECK_HEAD" env-description:"Whether disagreeing head block roots are treated as disagreements, in addition to source and target checkpoints"`
 }
@@ -1,6 +1,8 @@
 package beacon
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -13,6 +15,106 @@ import (
 type Network struct {
 	spectypes.BeaconNetwork
 	LocalTestNet bool
+
+	// params overrides the genesis parameters of the spec network, for private chains which reuse its name.
+	params *NetworkParams
+	// spec overrides the timing and forks of the network.
+	spec *Spec
+}
+
+// NetworkParams are the parameters of a custom beacon chain network.
+type NetworkParams struct {
+	GenesisForkVersion [4]byte
+	MinGenesisTime     uint64
+}
+
+// Default beacon chain spec values, which are those of mainnet.
+const (
+	DefaultSlotDuration                 = 12 * time.Second
+	DefaultSlotsPerEpoch                = 32
+	DefaultEpochsPerSyncCommitteePeriod = 256
+)
+
+// Spec is the part of the beacon chain spec which may differ between networks, such as devnets.
+// Its zero values are unset, and default to the values of mainnet.
+type Spec struct {
+	SlotDuration                 time.Duration
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
+	Forks                        []Fork
+}
+
+// Fork is a scheduled beacon chain fork.
+type Fork struct {
+	Name    string
+	Version [4]byte
+	Epoch   phase0.Epoch
+}
+
+// Merge returns the spec with its unset values taken from the other spec,
+// or an error if the specs set different values.
+func (s Spec) Merge(other Spec) (Spec, error) {
+	if s.SlotDuration == 0 {
+		s.SlotDuration = other.SlotDuration
+	} else if other.SlotDuration != 0 && other.SlotDuration != s.SlotDuration {
+		return Spec{}, fmt.Errorf("slot duration %v differs from %v", s.SlotDuration, other.SlotDuration)
+	}
+	if s.SlotsPerEpoch == 0 {
+		s.SlotsPerEpoch = other.SlotsPerEpoch
+	} else if other.SlotsPerEpoch != 0 && other.SlotsPerEpoch != s.SlotsPerEpoch {
+		return Spec{}, fmt.Errorf("slots per epoch %d differ from %d", s.SlotsPerEpoch, other.SlotsPerEpoch)
+	}
+	if s.EpochsPerSyncCommitteePeriod == 0 {
+		s.EpochsPerSyncCommitteePeriod = other.EpochsPerSyncCommitteePeriod
+	} else if other.EpochsPerSyncCommitteePeriod != 0 && other.EpochsPerSyncCommitteePeriod != s.EpochsPerSyncCommitteePeriod {
+		return Spec{}, fmt.Errorf("epochs per sync committee period %d differ from %d", s.EpochsPerSyncCommitteePeriod, other.EpochsPerSyncCommitteePeriod)
+	}
+	if len(s.Forks) == 0 {
+		s.Forks = other.Forks
+	} else {
+		for _, fork := range other.Forks {
+			if f, ok := s.Fork(fork.Name); ok && f != fork {
+				return Spec{}, fmt.Errorf("%s fork %#x at epoch %d differs from %#x at epoch %d", fork.Name, f.Version, f.Epoch, fork.Version, fork.Epoch)
+			}
+		}
+	}
+	return s, nil
+}
+
+// Validate returns an error if the spec's values are unsupported.
+func (s Spec) Validate() error {
+	if s.SlotDuration%time.Second != 0 {
+		return fmt.Errorf("slot duration %v isn't a whole number of seconds", s.SlotDuration)
+	}
+	if s.SlotDuration < 0 {
+		return fmt.Errorf("negative slot duration %v", s.SlotDuration)
+	}
+	// Duties are scheduled at fractions of an epoch, which requires a few slots per epoch.
+	if s.SlotsPerEpoch != 0 && s.SlotsPerEpoch < 4 {
+		return fmt.Errorf("slots per epoch %d are less than 4", s.SlotsPerEpoch)
+	}
+	names := make(map[string]bool, len(s.Forks))
+	for _, fork := range s.Forks {
+		name := strings.ToLower(fork.Name)
+		if name == "" {
+			return fmt.Errorf("missing fork name")
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate %s fork", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// Fork returns the fork with the given name, such as "capella".
+func (s Spec) Fork(name string) (Fork, bool) {
+	for _, fork := range s.Forks {
+		if strings.EqualFold(fork.Name, name) {
+			return fork, true
+		}
+	}
+	return Fork{}, false
 }
 
 type BeaconNetwork interface {
@@ -58,8 +160,53 @@ func NewLocalTestNetwork(network spectypes.BeaconNetwork) Network {
 	}
 }
 
+// NewCustomNetwork creates a new beacon chain network with the given parameters.
+func NewCustomNetwork(network spectypes.BeaconNetwork, params NetworkParams) Network {
+	return Network{
+		BeaconNetwork: network,
+		LocalTestNet:  false,
+		params:        &params,
+	}
+}
+
+// Custom returns whether the network's genesis parameters differ from the spec network's,
+// in which case the spec network's other constants, such as its genesis validators root, don't apply.
+func (n Network) Custom() bool {
+	return n.params != nil
+}
+
+// WithSpec returns the network with the given spec.
+func (n Network) WithSpec(spec Spec) Network {
+	n.spec = &spec
+	return n
+}
+
+// Spec returns the spec of the network, whose unset values are the defaults.
+func (n Network) Spec() Spec {
+	if n.spec == nil {
+		return Spec{}
+	}
+	return *n.spec
+}
+
+// Fork returns the scheduled fork with the given name, such as "capella", if the network's spec is known.
+func (n Network) Fork(name string) (Fork, bool) {
+	return n.Spec().Fork(name)
+}
+
+// ForkVersion returns the genesis fork version of the network
+func (n Network) ForkVersion() [4]byte {
+	if n.params != nil {
+		return n.params.GenesisForkVersion
+	}
+	return n.BeaconNetwork.ForkVersion()
+}
+
 // MinGenesisTime returns min genesis time value
 func (n Network) MinGenesisTime() uint64 {
+	if n.params != nil {
+		return n.params.MinGenesisTime
+	}
 	if n.LocalTestNet {
 		return 1689072978
 	}
@@ -76,6 +223,22 @@ func (n Network) GetBeaconNetwork() spectypes.BeaconNetwork {
 	return n.BeaconNetwork
 }
 
+// SlotDurationSec returns slot duration
+func (n Network) SlotDurationSec() time.Duration {
+	if n.spec != nil && n.spec.SlotDuration != 0 {
+		return n.spec.SlotDuration
+	}
+	return DefaultSlotDuration
+}
+
+// SlotsPerEpoch returns number of slots per one epoch
+func (n Network) SlotsPerEpoch() uint64 {
+	if n.spec != nil && n.spec.SlotsPerEpoch != 0 {
+		return n.spec.SlotsPerEpoch
+	}
+	return DefaultSlotsPerEpoch
+}
+
 // GetSlotStartTime returns the start time for the given slot
 func (n Network) GetSlotStartTime(slot phase0.Slot) time.Time {
 	timeSinceGenesisStart := uint64(slot) * uint64(n.SlotDurationSec().Seconds())
@@ -102,6 +265,11 @@ func (n Network) EstimatedSlotAtTime(time int64) phase0.Slot {
 	return phase0.Slot(uint64(time-genesis) / uint64(n.SlotDurationSec().Seconds()))
 }
 
+// EstimatedTimeAtSlot estimates the unix time at the start of the given slot
+func (n Network) EstimatedTimeAtSlot(slot phase0.Slot) int64 {
+	return n.GetSlotStartTime(slot).Unix()
+}
+
 // EstimatedCurrentEpoch estimates the current epoch
 // https://github.com/ethereum/eth2.0-specs/blob/dev/specs/phase0/beacon-chain.md#compute_start_slot_at_epoch
 func (n Network) EstimatedCurrentEpoch() phase0.Epoch {
@@ -123,9 +291,22 @@ func (n Network) GetEpochFirstSlot(epoch phase0.Epoch) phase0.Slot {
 	return phase0.Slot(uint64(epoch) * n.SlotsPerEpoch())
 }
 
+// FirstSlotAtEpoch returns the first slot of the given epoch
+func (n Network) FirstSlotAtEpoch(epoch phase0.Epoch) phase0.Slot {
+	return n.GetEpochFirstSlot(epoch)
+}
+
+// EpochStartTime returns the start time of the given epoch
+func (n Network) EpochStartTime(epoch phase0.Epoch) time.Time {
+	return n.GetSlotStartTime(n.GetEpochFirstSlot(epoch))
+}
+
 // EpochsPerSyncCommitteePeriod returns the number of epochs per sync committee period.
 func (n Network) EpochsPerSyncCommitteePeriod() uint64 {
-	return 256
+	if n.spec != nil && n.spec.EpochsPerSyncCommitteePeriod != 0 {
+		return n.spec.EpochsPerSyncCommitteePeriod
+	}
+	return DefaultEpochsPerSyncCommitteePeriod
 }
 
 // EstimatedSyncCommitteePeriodAtEpoch estimates the current sync committee period at the given Epoch
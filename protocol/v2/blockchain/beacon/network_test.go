@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"testing"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -17,3 +18,80 @@ func TestNetwork_GetSlotEndTime(t *testing.T) {
 
 	require.Equal(t, n.SlotDurationSec(), slotEnd.Sub(slotStart))
 }
+
+func TestNetwork_Custom(t *testing.T) {
+	n := NewCustomNetwork("devnet", NetworkParams{
+		GenesisForkVersion: [4]byte{0x10, 0x00, 0x00, 0x38},
+		MinGenesisTime:     1700000000,
+	})
+	require.Equal(t, [4]byte{0x10, 0x00, 0x00, 0x38}, n.ForkVersion())
+	require.EqualValues(t, 1700000000, n.MinGenesisTime())
+	require.EqualValues(t, 1700000000+32*12, n.EstimatedTimeAtSlot(32))
+	require.Equal(t, time.Unix(1700000000+32*12, 0), n.EpochStartTime(1))
+	require.Equal(t, phase0.Slot(32), n.EstimatedSlotAtTime(1700000000+32*12))
+}
+
+func TestNetwork_Spec(t *testing.T) {
+	n := NewNetwork(spectypes.MainNetwork)
+	require.Equal(t, DefaultSlotDuration, n.SlotDurationSec())
+	require.EqualValues(t, DefaultSlotsPerEpoch, n.SlotsPerEpoch())
+	require.EqualValues(t, DefaultEpochsPerSyncCommitteePeriod, n.EpochsPerSyncCommitteePeriod())
+
+	n = n.WithSpec(Spec{
+		SlotDuration:                 2 * time.Second,
+		SlotsPerEpoch:                8,
+		EpochsPerSyncCommitteePeriod: 4,
+		Forks:                        []Fork{{Name: "capella", Version: [4]byte{0x30}, Epoch: 1}},
+	})
+	require.Equal(t, 2*time.Second, n.SlotDurationSec())
+	require.Equal(t, phase0.Slot(8), n.FirstSlotAtEpoch(1))
+	require.Equal(t, phase0.Epoch(2), n.EstimatedEpochAtSlot(17))
+	require.Equal(t, n.GetSlotStartTime(0).Add(16*time.Second), n.EpochStartTime(1))
+	require.Equal(t, phase0.Epoch(8), n.FirstEpochOfSyncPeriod(2))
+	require.Equal(t, phase0.Slot(8*4-2), n.LastSlotOfSyncPeriod(0))
+
+	fork, ok := n.Fork("CAPELLA")
+	require.True(t, ok)
+	require.Equal(t, phase0.Epoch(1), fork.Epoch)
+	_, ok = n.Fork("deneb")
+	require.False(t, ok)
+}
+
+func TestSpec_Merge(t *testing.T) {
+	capella := Fork{Name: "capella", Version: [4]byte{0x30}, Epoch: 1}
+	deneb := Fork{Name: "deneb", Version: [4]byte{0x40}, Epoch: 2}
+	node := Spec{
+		SlotDuration:                 2 * time.Second,
+		SlotsPerEpoch:                8,
+		EpochsPerSyncCommitteePeriod: 4,
+		Forks:                        []Fork{capella, deneb},
+	}
+
+	// Unset values are taken from the other spec.
+	spec, err := Spec{SlotsPerEpoch: 8}.Merge(node)
+	require.NoError(t, err)
+	require.Equal(t, node, spec)
+
+	// Configured forks are kept, as long as they don't conflict.
+	spec, err = Spec{Forks: []Fork{capella}}.Merge(node)
+	require.NoError(t, err)
+	require.Equal(t, []Fork{capella}, spec.Forks)
+
+	_, err = Spec{SlotDuration: 12 * time.Second}.Merge(node)
+	require.ErrorContains(t, err, "slot duration 12s differs from 2s")
+	_, err = Spec{SlotsPerEpoch: 32}.Merge(node)
+	require.ErrorContains(t, err, "slots per epoch 32 differ from 8")
+	_, err = Spec{EpochsPerSyncCommitteePeriod: 256}.Merge(node)
+	require.ErrorContains(t, err, "epochs per sync committee period 256 differ from 4")
+	_, err = Spec{Forks: []Fork{{Name: "deneb", Version: [4]byte{0x40}, Epoch: 3}}}.Merge(node)
+	require.ErrorContains(t, err, "deneb fork")
+}
+
+func TestSpec_Validate(t *testing.T) {
+	require.NoError(t, Spec{}.Validate())
+	require.NoError(t, Spec{SlotDuration: 2 * time.Second, SlotsPerEpoch: 4}.Validate())
+	require.ErrorContains(t, Spec{SlotDuration: 1500 * time.Millisecond}.Validate(), "isn't a whole number of seconds")
+	require.ErrorContains(t, Spec{SlotsPerEpoch: 2}.Validate(), "slots per epoch 2 are less than 4")
+	require.ErrorContains(t, Spec{Forks: []Fork{{Name: "capella"}, {Name: "Capella"}}}.Validate(), "duplicate capella fork")
+	require.ErrorContains(t, Spec{Forks: []Fork{{}}}.Validate(), "missing fork name")
+}
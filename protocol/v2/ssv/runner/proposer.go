@@ -22,6 +22,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
 )
@@ -30,6 +31,8 @@ type ProposerRunner struct {
 	BaseRunner *BaseRunner
 	// ProducesBlindedBlocks is true when the runner will only produce blinded blocks
 	ProducesBlindedBlocks bool
+	// BuilderPolicy returns the builder policy of the validator's cluster, if the beacon node supports it.
+	BuilderPolicy func() (*beacon.BuilderPolicy, error) `json:"-"`
 
 	beacon   specssv.BeaconNode
 	network  specssv.Network
@@ -41,7 +44,7 @@ type ProposerRunner struct {
 }
 
 func NewProposerRunner(
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	share *spectypes.Share,
 	qbftController *controller.Controller,
 	beacon specssv.BeaconNode,
@@ -120,7 +123,7 @@ func (r *ProposerRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spec
 		}
 	} else {
 		// get block data
-		obj, ver, err = r.GetBeaconNode().GetBeaconBlock(duty.Slot, r.graffiti, fullSig)
+		obj, ver, err = r.getBeaconBlock(logger, duty.Slot, fullSig)
 		if err != nil {
 			return errors.Wrap(err, "failed to get beacon block")
 		}
@@ -153,6 +156,23 @@ func (r *ProposerRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spec
 	return nil
 }
 
+// getBeaconBlock requests the block with the builder policy of the validator's cluster,
+// if the beacon node supports builder policies.
+func (r *ProposerRunner) getBeaconBlock(logger *zap.Logger, slot phase0.Slot, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	policyProposer, ok := r.GetBeaconNode().(beacon.BuilderPolicyProposer)
+	if !ok || r.BuilderPolicy == nil {
+		return r.GetBeaconNode().GetBeaconBlock(slot, r.graffiti, randao)
+	}
+
+	policy, err := r.BuilderPolicy()
+	if err != nil {
+		// Missing the proposal is worse than ignoring the policy.
+		logger.Warn("could not get builder policy, leaving the choice to the beacon node", zap.Error(err))
+		policy = nil
+	}
+	return policyProposer.GetBeaconBlockWithBuilderPolicy(slot, r.graffiti, randao, policy)
+}
+
 func (r *ProposerRunner) ProcessConsensus(logger *zap.Logger, signedMsg *specqbft.SignedMessage) error {
 	decided, decidedValue, err := r.BaseRunner.baseConsensusMsgProcessing(logger, r, signedMsg)
 	if err != nil {
@@ -334,7 +354,7 @@ func (r *ProposerRunner) executeDuty(logger *zap.Logger, duty *spectypes.Duty) e
 	r.metrics.StartPreConsensus()
 
 	// sign partial randao
-	epoch := r.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(duty.Slot)
+	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(duty.Slot)
 	msg, err := r.BaseRunner.signBeaconObject(r, spectypes.SSZUint64(epoch), duty.Slot, spectypes.DomainRandao)
 	if err != nil {
 		return errors.Wrap(err, "could not sign randao")
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 )
 
@@ -50,7 +51,7 @@ type BaseRunner struct {
 	State          *State
 	Share          *spectypes.Share
 	QBFTController *controller.Controller
-	BeaconNetwork  spectypes.BeaconNetwork
+	BeaconNetwork  beacon.Network
 	BeaconRoleType spectypes.BeaconRole
 
 	// implementation vars
@@ -81,7 +82,7 @@ func NewBaseRunner(
 	state *State,
 	share *spectypes.Share,
 	controller *controller.Controller,
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	beaconRoleType spectypes.BeaconRole,
 	highestDecidedSlot spec.Slot,
 ) *BaseRunner {
@@ -11,6 +11,12 @@ import (
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
 
+// slotSigner is implemented by signers which need the duty's slot to sign beacon objects
+// which don't carry it, such as the block root of a sync committee message.
+type slotSigner interface {
+	SignBeaconObjectAtSlot(obj ssz.HashRoot, slot spec.Slot, domain spec.Domain, pk []byte, domainType spec.DomainType) (spectypes.Signature, [32]byte, error)
+}
+
 func (b *BaseRunner) signBeaconObject(
 	runner Runner,
 	obj ssz.HashRoot,
@@ -22,7 +28,15 @@ func (b *BaseRunner) signBeaconObject(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get beacon domain")
 	}
-	sig, r, err := runner.GetSigner().SignBeaconObject(obj, domain, runner.GetBaseRunner().Share.SharePubKey, domainType)
+	var (
+		sig spectypes.Signature
+		r   [32]byte
+	)
+	if signer, ok := runner.GetSigner().(slotSigner); ok {
+		sig, r, err = signer.SignBeaconObjectAtSlot(obj, slot, domain, runner.GetBaseRunner().Share.SharePubKey, domainType)
+	} else {
+		sig, r, err = runner.GetSigner().SignBeaconObject(obj, domain, runner.GetBaseRunner().Share.SharePubKey, domainType)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not sign beacon object")
 	}
@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
 )
 
@@ -32,7 +33,7 @@ type VoluntaryExitRunner struct {
 }
 
 func NewVoluntaryExitRunner(
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	share *spectypes.Share,
 	beacon specssv.BeaconNode,
 	network specssv.Network,
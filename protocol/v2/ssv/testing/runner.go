@@ -8,6 +8,7 @@ import (
 	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/testing"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
 )
@@ -75,7 +76,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 	switch role {
 	case spectypes.BNRoleAttester:
 		return runner.NewAttesterRunnner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -86,7 +87,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleAggregator:
 		return runner.NewAggregatorRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -97,7 +98,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleProposer:
 		return runner.NewProposerRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -109,7 +110,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleSyncCommittee:
 		return runner.NewSyncCommitteeRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -120,7 +121,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleSyncCommitteeContribution:
 		return runner.NewSyncCommitteeAggregatorRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -131,7 +132,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleValidatorRegistration:
 		return runner.NewValidatorRegistrationRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -140,7 +141,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleVoluntaryExit:
 		return runner.NewVoluntaryExitRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			spectestingutils.NewTestingBeaconNode(),
 			net,
@@ -148,7 +149,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectestingutils.UnknownDutyType:
 		ret := runner.NewAttesterRunnner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
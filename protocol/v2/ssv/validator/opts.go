@@ -4,6 +4,7 @@ import (
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	specssv "github.com/bloxapp/ssv-spec/ssv"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/bloxapp/ssv/ibft/storage"
 	"github.com/bloxapp/ssv/message/validation"
@@ -34,6 +35,8 @@ type Options struct {
 	MessageValidator  validation.MessageValidator
 	Metrics           Metrics
 	Graffiti          []byte
+	// BuilderPolicy returns the builder policy of the given cluster, if any.
+	BuilderPolicy func(owner common.Address, operatorIDs []uint64) (*beacon.BuilderPolicy, error)
 }
 
 func (o *Options) defaults() {
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"sync"
 
@@ -10,11 +11,14 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
 
 var (
-	recipientsPrefix = []byte("recipients")
+	recipientsPrefix      = []byte("recipients")
+	builderPoliciesPrefix = []byte("builder_policies")
 )
 
 type Nonce uint16
@@ -58,6 +62,15 @@ type recipientDataJSON struct {
 	Nonce        *Nonce         `json:"nonce"`
 }
 
+// builderPolicies are the builder preferences of an owner. They're set by the operator rather than by
+// registry events, so they're stored apart from the recipient data, out of snapshots and reorg rollbacks.
+type builderPolicies struct {
+	// Owner is the owner's builder preference, used by clusters without their own.
+	Owner *beaconprotocol.BuilderPolicy `json:"owner,omitempty"`
+	// Clusters are the builder preferences of the owner's clusters, by hex-encoded cluster ID.
+	Clusters map[string]*beaconprotocol.BuilderPolicy `json:"clusters,omitempty"`
+}
+
 // Recipients is the interface for managing recipients data
 type Recipients interface {
 	GetRecipientData(r basedb.Reader, owner common.Address) (*RecipientData, bool, error)
@@ -66,6 +79,8 @@ type Recipients interface {
 	BumpNonce(rw basedb.ReadWriter, owner common.Address) error
 	SaveRecipientData(rw basedb.ReadWriter, recipientData *RecipientData) (*RecipientData, error)
 	DeleteRecipientData(rw basedb.ReadWriter, owner common.Address) error
+	GetBuilderPolicy(r basedb.Reader, owner common.Address, operatorIDs []uint64) (*beaconprotocol.BuilderPolicy, error)
+	SaveBuilderPolicy(rw basedb.ReadWriter, owner common.Address, operatorIDs []uint64, policy *beaconprotocol.BuilderPolicy) error
 	DropRecipients() error
 	GetRecipientsPrefix() []byte
 }
@@ -224,6 +239,80 @@ func (s *recipientsStorage) DeleteRecipientData(rw basedb.ReadWriter, owner comm
 	return s.db.Using(rw).Delete(s.prefix, buildRecipientKey(owner))
 }
 
+// GetBuilderPolicy returns the builder policy of the given cluster, falling back to the owner's policy,
+// or only the owner's policy if operatorIDs is empty. Returns nil if no policy applies.
+func (s *recipientsStorage) GetBuilderPolicy(r basedb.Reader, owner common.Address, operatorIDs []uint64) (*beaconprotocol.BuilderPolicy, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	policies, err := s.getBuilderPolicies(r, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(operatorIDs) > 0 {
+		if policy, ok := policies.Clusters[clusterKey(owner, operatorIDs)]; ok {
+			return policy, nil
+		}
+	}
+	return policies.Owner, nil
+}
+
+// SaveBuilderPolicy sets the builder policy of the given cluster, or of the owner if operatorIDs is empty.
+// A nil policy removes it.
+func (s *recipientsStorage) SaveBuilderPolicy(rw basedb.ReadWriter, owner common.Address, operatorIDs []uint64, policy *beaconprotocol.BuilderPolicy) error {
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return errors.Wrap(err, "invalid builder policy")
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	policies, err := s.getBuilderPolicies(rw, owner)
+	if err != nil {
+		return err
+	}
+
+	if len(operatorIDs) == 0 {
+		policies.Owner = policy
+	} else {
+		key := clusterKey(owner, operatorIDs)
+		if policy == nil {
+			delete(policies.Clusters, key)
+		} else {
+			if policies.Clusters == nil {
+				policies.Clusters = make(map[string]*beaconprotocol.BuilderPolicy)
+			}
+			policies.Clusters[key] = policy
+		}
+	}
+
+	if policies.Owner == nil && len(policies.Clusters) == 0 {
+		return s.db.Using(rw).Delete(s.prefix, buildBuilderPoliciesKey(owner))
+	}
+	raw, err := json.Marshal(policies)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal builder policies")
+	}
+	return s.db.Using(rw).Set(s.prefix, buildBuilderPoliciesKey(owner), raw)
+}
+
+func (s *recipientsStorage) getBuilderPolicies(r basedb.Reader, owner common.Address) (*builderPolicies, error) {
+	policies := &builderPolicies{}
+	obj, found, err := s.db.UsingReader(r).Get(s.prefix, buildBuilderPoliciesKey(owner))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get builder policies")
+	}
+	if !found {
+		return policies, nil
+	}
+	if err := json.Unmarshal(obj.Value, policies); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal builder policies")
+	}
+	return policies, nil
+}
+
 func (s *recipientsStorage) DropRecipients() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -234,6 +323,18 @@ func (s *recipientsStorage) DropRecipients() error {
 	))
 }
 
+// clusterKey returns the hex-encoded ID of the owner's cluster with the given operators.
+func clusterKey(owner common.Address, operatorIDs []uint64) string {
+	ids := make([]uint64, len(operatorIDs))
+	copy(ids, operatorIDs)
+	return hex.EncodeToString(types.ComputeClusterIDHash(owner, ids))
+}
+
+// buildBuilderPoliciesKey builds the key of the owner's builder policies, e.g. "builder_policies/0x00..01"
+func buildBuilderPoliciesKey(owner common.Address) []byte {
+	return bytes.Join([][]byte{builderPoliciesPrefix, owner.Bytes()}, []byte("/"))
+}
+
 // buildRecipientKey builds recipient key using recipientsPrefix & owner address, e.g. "recipients/0x00..01"
 func buildRecipientKey(owner common.Address) []byte {
 	return bytes.Join([][]byte{recipientsPrefix, owner.Bytes()}, []byte("/"))
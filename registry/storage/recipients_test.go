@@ -2,6 +2,7 @@ package storage_test
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -10,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/storage/kv"
@@ -348,3 +350,81 @@ func newRecipientStorageForTest(logger *zap.Logger) (storage.Recipients, func())
 		db.Close()
 	}
 }
+
+func TestStorage_BuilderPolicy(t *testing.T) {
+	logger := logging.TestLogger(t)
+	storageCollection, done := newRecipientStorageForTest(logger)
+	require.NotNil(t, storageCollection)
+	defer done()
+
+	owner := common.BytesToAddress([]byte("0x1"))
+	ownerPolicy := &beacon.BuilderPolicy{Mode: beacon.BuilderModeLocal}
+	clusterPolicy := &beacon.BuilderPolicy{Mode: beacon.BuilderModeMinBid, MinBid: big.NewInt(1e17)}
+
+	t.Run("no policy", func(t *testing.T) {
+		policy, err := storageCollection.GetBuilderPolicy(nil, owner, []uint64{1, 2, 3, 4})
+		require.NoError(t, err)
+		require.Nil(t, policy)
+	})
+
+	t.Run("owner policy", func(t *testing.T) {
+		require.NoError(t, storageCollection.SaveBuilderPolicy(nil, owner, nil, ownerPolicy))
+
+		policy, err := storageCollection.GetBuilderPolicy(nil, owner, []uint64{1, 2, 3, 4})
+		require.NoError(t, err)
+		require.Equal(t, ownerPolicy, policy)
+
+		// The policies are kept apart from the recipient data, which is managed by registry events.
+		_, found, err := storageCollection.GetRecipientData(nil, owner)
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+
+	t.Run("cluster policy", func(t *testing.T) {
+		require.NoError(t, storageCollection.SaveBuilderPolicy(nil, owner, []uint64{4, 3, 2, 1}, clusterPolicy))
+
+		policy, err := storageCollection.GetBuilderPolicy(nil, owner, []uint64{1, 2, 3, 4})
+		require.NoError(t, err)
+		require.Equal(t, 0, clusterPolicy.MinBid.Cmp(policy.MinBid))
+		require.Equal(t, clusterPolicy.Mode, policy.Mode)
+
+		policy, err = storageCollection.GetBuilderPolicy(nil, owner, []uint64{1, 2, 3, 5})
+		require.NoError(t, err)
+		require.Equal(t, ownerPolicy, policy, "other clusters fall back to the owner's policy")
+
+		policy, err = storageCollection.GetBuilderPolicy(nil, owner, nil)
+		require.NoError(t, err)
+		require.Equal(t, ownerPolicy, policy)
+	})
+
+	t.Run("fee recipient update keeps policies", func(t *testing.T) {
+		data := &storage.RecipientData{Owner: owner}
+		copy(data.FeeRecipient[:], "0x2")
+		_, err := storageCollection.SaveRecipientData(nil, data)
+		require.NoError(t, err)
+		require.NoError(t, storageCollection.BumpNonce(nil, owner))
+		require.NoError(t, storageCollection.DeleteRecipientData(nil, owner))
+		require.NoError(t, storageCollection.DropRecipients())
+
+		policy, err := storageCollection.GetBuilderPolicy(nil, owner, []uint64{1, 2, 3, 4})
+		require.NoError(t, err)
+		require.Equal(t, beacon.BuilderModeMinBid, policy.Mode)
+	})
+
+	t.Run("delete policies", func(t *testing.T) {
+		require.NoError(t, storageCollection.SaveBuilderPolicy(nil, owner, []uint64{1, 2, 3, 4}, nil))
+		policy, err := storageCollection.GetBuilderPolicy(nil, owner, []uint64{1, 2, 3, 4})
+		require.NoError(t, err)
+		require.Equal(t, ownerPolicy, policy)
+
+		require.NoError(t, storageCollection.SaveBuilderPolicy(nil, owner, nil, nil))
+		policy, err = storageCollection.GetBuilderPolicy(nil, owner, []uint64{1, 2, 3, 4})
+		require.NoError(t, err)
+		require.Nil(t, policy)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		err := storageCollection.SaveBuilderPolicy(nil, owner, nil, &beacon.BuilderPolicy{Mode: beacon.BuilderModeMinBid})
+		require.ErrorContains(t, err, "invalid builder policy")
+	})
+}
@@ -46,6 +46,9 @@ type Shares interface {
 	// Drop deletes all shares.
 	Drop() error
 
+	// Reload discards the cached shares and reads them again from the database.
+	Reload() error
+
 	// UpdateValidatorMetadata updates validator metadata.
 	UpdateValidatorMetadata(pk string, metadata *beaconprotocol.ValidatorMetadata) error
 }
@@ -77,6 +80,10 @@ func (s *sharesStorage) load() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	return s.loadLocked()
+}
+
+func (s *sharesStorage) loadLocked() error {
 	return s.db.GetAll(append(s.prefix, sharesPrefix...), func(i int, obj basedb.Obj) error {
 		val := &types.SSVShare{}
 		if err := val.Decode(obj.Value); err != nil {
@@ -186,6 +193,19 @@ func (s *sharesStorage) UpdateValidatorMetadata(pk string, metadata *beaconproto
 }
 
 // Drop deletes all shares.
+func (s *sharesStorage) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shares := s.shares
+	s.shares = make(map[string]*types.SSVShare)
+	if err := s.loadLocked(); err != nil {
+		s.shares = shares
+		return err
+	}
+	return nil
+}
+
 func (s *sharesStorage) Drop() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
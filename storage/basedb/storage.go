@@ -5,9 +5,16 @@ import (
 	"time"
 )
 
+// Storage engines which implement Database.
+const (
+	EngineBadger = "badger"
+	EnginePebble = "pebble"
+)
+
 // Options for creating all db type
 type Options struct {
 	Ctx        context.Context
+	Engine     string        `yaml:"Engine" env:"DB_ENGINE" env-default:"badger" env-description:"Storage engine (badger or pebble). Use 'db convert' to migrate an existing database between engines."`
 	Path       string        `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Path for storage"`
 	Reporting  bool          `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
 	GCInterval time.Duration `yaml:"GCInterval" env:"DB_GC_INTERVAL" env-default:"6m" env-description:"Interval between garbage collection cycles. Set to 0 to disable."`
@@ -127,7 +127,7 @@ func (b *BadgerDB) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, err
 			wb.Cancel()
 			return err
 		}
-		if err := wb.Set(append(prefix, item.Key...), item.Value); err != nil {
+		if err := wb.Set(prefixedKey(prefix, item.Key), item.Value); err != nil {
 			wb.Cancel()
 			return err
 		}
@@ -0,0 +1,326 @@
+package kv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// engines lists the storage engines which must conform to basedb.Database.
+var engines = map[string]func(t *testing.T) basedb.Database{
+	basedb.EngineBadger: func(t *testing.T) basedb.Database {
+		db, err := NewInMemory(logging.TestLogger(t), basedb.Options{})
+		require.NoError(t, err)
+		return db
+	},
+	basedb.EnginePebble: func(t *testing.T) basedb.Database {
+		db, err := NewPebbleInMemory(logging.TestLogger(t), basedb.Options{})
+		require.NoError(t, err)
+		return db
+	},
+}
+
+func TestConformance(t *testing.T) {
+	tests := map[string]func(t *testing.T, db basedb.Database){
+		"SetGetDelete":   testSetGetDelete,
+		"GetAllOrdered":  testGetAllOrdered,
+		"GetMany":        testGetMany,
+		"SetMany":        testSetMany,
+		"Prefixes":       testPrefixes,
+		"Txn":            testTxn,
+		"ReadTxn":        testReadTxn,
+		"Update":         testUpdate,
+		"HandlerErrors":  testHandlerErrors,
+		"EmptyAndBinary": testEmptyAndBinary,
+	}
+	for engine, open := range engines {
+		for name, test := range tests {
+			t.Run(fmt.Sprintf("%s/%s", engine, name), func(t *testing.T) {
+				db := open(t)
+				defer db.Close()
+				test(t, db)
+			})
+		}
+	}
+}
+
+func testSetGetDelete(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+
+	_, found, err := db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, db.Set(prefix, []byte("key"), []byte("value")))
+	obj, found, err := db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("key"), obj.Key)
+	require.Equal(t, []byte("value"), obj.Value)
+
+	require.NoError(t, db.Set(prefix, []byte("key"), []byte("value2")))
+	obj, _, err = db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), obj.Value)
+
+	require.NoError(t, db.Delete(prefix, []byte("key")))
+	_, found, err = db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Deleting a missing key is not an error.
+	require.NoError(t, db.Delete(prefix, []byte("missing")))
+}
+
+func testGetAllOrdered(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+	keys := []string{"c", "a", "b", "ab"}
+	for _, k := range keys {
+		require.NoError(t, db.Set(prefix, []byte(k), []byte(k+"-value")))
+	}
+	require.NoError(t, db.Set([]byte("prefiy"), []byte("other"), []byte("other")))
+
+	var got []string
+	err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		require.Equal(t, len(got), i)
+		require.Equal(t, string(obj.Key)+"-value", string(obj.Value))
+		got = append(got, string(obj.Key))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "ab", "b", "c"}, got)
+
+	count, err := db.CountPrefix(prefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, count)
+}
+
+func testGetMany(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.Set(prefix, []byte{byte(i)}, []byte{byte(i)}))
+	}
+
+	var got []basedb.Obj
+	err := db.GetMany(prefix, [][]byte{{1}, {5}, {42}, {9}}, func(obj basedb.Obj) error {
+		got = append(got, obj)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []basedb.Obj{{Key: []byte{1}, Value: []byte{1}}, {Key: []byte{5}, Value: []byte{5}}, {Key: []byte{9}, Value: []byte{9}}}, got)
+
+	require.NoError(t, db.GetMany(prefix, nil, func(obj basedb.Obj) error {
+		t.Fatal("unexpected item")
+		return nil
+	}))
+}
+
+func testSetMany(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+	err := db.SetMany(prefix, 100, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte(fmt.Sprintf("%03d", i)), Value: []byte{byte(i)}}, nil
+	})
+	require.NoError(t, err)
+
+	count, err := db.CountPrefix(prefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, count)
+
+	// Keys don't alias the spare capacity of the prefix.
+	spare := append(make([]byte, 0, 64), 's')
+	err = db.SetMany(spare, 10, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte{byte(i)}, Value: []byte{byte(i)}}, nil
+	})
+	require.NoError(t, err)
+	count, err = db.CountPrefix(spare)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, count)
+
+	// A failing SetMany writes nothing.
+	err = db.SetMany([]byte("failed"), 10, func(i int) (basedb.Obj, error) {
+		if i == 5 {
+			return basedb.Obj{}, fmt.Errorf("failed")
+		}
+		return basedb.Obj{Key: []byte{byte(i)}, Value: []byte{byte(i)}}, nil
+	})
+	require.Error(t, err)
+	count, err = db.CountPrefix([]byte("failed"))
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func testPrefixes(t *testing.T, db basedb.Database) {
+	for _, prefix := range []string{"a", "ab", "b", "\xff"} {
+		for i := 0; i < 3; i++ {
+			require.NoError(t, db.Set([]byte(prefix), []byte{byte(i)}, []byte("value")))
+		}
+	}
+
+	count, err := db.CountPrefix([]byte("a"))
+	require.NoError(t, err)
+	require.EqualValues(t, 6, count, "prefix a also matches prefix ab")
+
+	deleted, err := db.DeletePrefix([]byte("ab"))
+	require.NoError(t, err)
+	require.Equal(t, 3, deleted)
+
+	require.NoError(t, db.DropPrefix([]byte("\xff")))
+	count, err = db.CountPrefix([]byte("\xff"))
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	require.NoError(t, db.DropPrefix([]byte("a")))
+	for prefix, expected := range map[string]int64{"a": 0, "ab": 0, "b": 3} {
+		count, err := db.CountPrefix([]byte(prefix))
+		require.NoError(t, err)
+		require.Equal(t, expected, count, prefix)
+	}
+}
+
+func testTxn(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+	require.NoError(t, db.Set(prefix, []byte("deleted"), []byte("value")))
+
+	txn := db.Begin()
+	require.NoError(t, txn.Set(prefix, []byte("key"), []byte("value")))
+	require.NoError(t, txn.SetMany(prefix, 2, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte{byte(i)}, Value: []byte{byte(i)}}, nil
+	}))
+	require.NoError(t, txn.Delete(prefix, []byte("deleted")))
+
+	// The transaction reads its own writes.
+	obj, found, err := txn.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+	var keys []string
+	require.NoError(t, txn.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		keys = append(keys, string(obj.Key))
+		return nil
+	}))
+	require.Equal(t, []string{"\x00", "\x01", "key"}, keys)
+	var many int
+	require.NoError(t, txn.GetMany(prefix, [][]byte{[]byte("key"), []byte("deleted")}, func(obj basedb.Obj) error {
+		many++
+		return nil
+	}))
+	require.Equal(t, 1, many)
+
+	// Others don't, until it's committed.
+	_, found, err = db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, txn.Commit())
+	txn.Discard()
+
+	_, found, err = db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	_, found, err = db.Get(prefix, []byte("deleted"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// A discarded transaction writes nothing.
+	txn = db.Begin()
+	require.NoError(t, txn.Set(prefix, []byte("discarded"), []byte("value")))
+	txn.Discard()
+	txn.Discard()
+	_, found, err = db.Get(prefix, []byte("discarded"))
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func testReadTxn(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+	require.NoError(t, db.Set(prefix, []byte("key"), []byte("value")))
+
+	txn := db.BeginRead()
+	defer txn.Discard()
+
+	require.NoError(t, db.Set(prefix, []byte("key"), []byte("value2")))
+	require.NoError(t, db.Set(prefix, []byte("key2"), []byte("value2")))
+
+	// The transaction reads a snapshot.
+	obj, found, err := txn.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+	count := 0
+	require.NoError(t, txn.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		count++
+		return nil
+	}))
+	require.Equal(t, 1, count)
+	require.NoError(t, txn.GetMany(prefix, [][]byte{[]byte("key2")}, func(obj basedb.Obj) error {
+		t.Fatal("unexpected item")
+		return nil
+	}))
+}
+
+func testUpdate(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+	require.NoError(t, db.Update(func(txn basedb.Txn) error {
+		return txn.Set(prefix, []byte("key"), []byte("value"))
+	}))
+	_, found, err := db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+
+	err = db.Update(func(txn basedb.Txn) error {
+		require.NoError(t, txn.Set(prefix, []byte("failed"), []byte("value")))
+		return fmt.Errorf("failed")
+	})
+	require.Error(t, err)
+	_, found, err = db.Get(prefix, []byte("failed"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Using falls back to the database.
+	require.Equal(t, db, db.Using(nil))
+	require.Equal(t, db, db.UsingReader(nil))
+	txn := db.Begin()
+	defer txn.Discard()
+	require.Equal(t, txn, db.Using(txn))
+}
+
+func testHandlerErrors(t *testing.T, db basedb.Database) {
+	prefix := []byte("prefix")
+	for i := 0; i < 3; i++ {
+		require.NoError(t, db.Set(prefix, []byte{byte(i)}, []byte{byte(i)}))
+	}
+
+	calls := 0
+	err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		calls++
+		return fmt.Errorf("stop")
+	})
+	require.EqualError(t, err, "stop")
+	require.Equal(t, 1, calls)
+
+	err = db.GetMany(prefix, [][]byte{{0}, {1}}, func(obj basedb.Obj) error {
+		return fmt.Errorf("stop")
+	})
+	require.EqualError(t, err, "stop")
+}
+
+func testEmptyAndBinary(t *testing.T, db basedb.Database) {
+	require.NoError(t, db.Set(nil, []byte{0xff, 0x00}, nil))
+	require.NoError(t, db.Set([]byte{0x00}, []byte{0xff}, []byte{}))
+
+	obj, found, err := db.Get(nil, []byte{0xff, 0x00})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Empty(t, obj.Value)
+
+	var keys [][]byte
+	require.NoError(t, db.GetAll(nil, func(i int, obj basedb.Obj) error {
+		keys = append(keys, obj.Key)
+		return nil
+	}))
+	require.Equal(t, [][]byte{{0x00, 0xff}, {0xff, 0x00}}, keys)
+}
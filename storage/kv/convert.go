@@ -0,0 +1,85 @@
+package kv
+
+import (
+	"fmt"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// convertBatchSize is the number of items written to the target database at once by Convert.
+const convertBatchSize = 10000
+
+// Convert copies all items from the source database into the empty target database,
+// which may use a different storage engine. It returns the number of copied items.
+func Convert(src, dst basedb.Database) (int, error) {
+	if err := ensureEmpty(dst); err != nil {
+		return 0, err
+	}
+
+	w := newBatchWriter(dst)
+	copied := 0
+	err := src.GetAll(nil, func(i int, obj basedb.Obj) error {
+		copied++
+		return w.add(obj)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not copy items: %w", err)
+	}
+	if err := w.flush(); err != nil {
+		return 0, fmt.Errorf("could not copy items: %w", err)
+	}
+
+	// Verify that nothing was lost.
+	count, err := dst.CountPrefix(nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not count target items: %w", err)
+	}
+	if count != int64(copied) {
+		return 0, fmt.Errorf("copied %d items but target database has %d", copied, count)
+	}
+	return copied, nil
+}
+
+// ensureEmpty returns an error if the target database has any items.
+func ensureEmpty(db basedb.Database) error {
+	existing, err := db.CountPrefix(nil)
+	if err != nil {
+		return fmt.Errorf("could not count target items: %w", err)
+	}
+	if existing > 0 {
+		return fmt.Errorf("target database is not empty (%d items)", existing)
+	}
+	return nil
+}
+
+// batchWriter writes items to a database in batches of convertBatchSize.
+type batchWriter struct {
+	db    basedb.Database
+	batch []basedb.Obj
+}
+
+func newBatchWriter(db basedb.Database) *batchWriter {
+	return &batchWriter{
+		db:    db,
+		batch: make([]basedb.Obj, 0, convertBatchSize),
+	}
+}
+
+func (w *batchWriter) add(obj basedb.Obj) error {
+	w.batch = append(w.batch, obj)
+	if len(w.batch) == convertBatchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *batchWriter) flush() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	err := w.db.SetMany(nil, len(w.batch), func(i int) (basedb.Obj, error) {
+		return w.batch[i], nil
+	})
+	w.batch = w.batch[:0]
+	return err
+}
@@ -0,0 +1,41 @@
+package kv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestConvert(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	src, err := NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer src.Close()
+	dst, err := NewPebbleInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer dst.Close()
+
+	n := convertBatchSize + 10
+	for _, prefix := range []string{"a", "b"} {
+		require.NoError(t, src.SetMany([]byte(prefix), n, func(i int) (basedb.Obj, error) {
+			return basedb.Obj{Key: []byte(fmt.Sprintf("%06d", i)), Value: []byte(fmt.Sprint(i))}, nil
+		}))
+	}
+
+	copied, err := Convert(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, 2*n, copied)
+
+	obj, found, err := dst.Get([]byte("b"), []byte("000042"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("42"), obj.Value)
+
+	_, err = Convert(src, dst)
+	require.ErrorContains(t, err, "not empty")
+}
@@ -0,0 +1,244 @@
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// dumpMagic starts every dump, followed by the version of its format and the dump's header.
+var dumpMagic = []byte("ssvdb")
+
+const (
+	dumpVersion = 1
+
+	// dumpItem precedes every item of a dump, and dumpEnd precedes its trailer,
+	// which is the number of items followed by the CRC-32 checksum of everything before it.
+	dumpItem = 1
+	dumpEnd  = 0
+
+	// maxDumpFieldSize limits the size of a key or value read from a dump.
+	maxDumpFieldSize = 64 << 20
+)
+
+// Dump writes the given header and all the items visible to the given reader to w,
+// in a format which can be loaded with Load. The checksum of the dump covers its header too.
+// To dump a consistent snapshot of a running database, pass a read transaction.
+// It returns the number of dumped items.
+func Dump(r basedb.Reader, header []byte, w io.Writer) (int, error) {
+	bw := bufio.NewWriter(w)
+	crc := crc32.NewIEEE()
+	out := io.MultiWriter(bw, crc)
+
+	if _, err := out.Write(dumpMagic); err != nil {
+		return 0, err
+	}
+	if _, err := out.Write([]byte{dumpVersion}); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	writeField := func(b []byte) error {
+		n := binary.PutUvarint(lenBuf, uint64(len(b)))
+		if _, err := out.Write(lenBuf[:n]); err != nil {
+			return err
+		}
+		_, err := out.Write(b)
+		return err
+	}
+	if err := writeField(header); err != nil {
+		return 0, err
+	}
+	err := r.GetAll(nil, func(i int, obj basedb.Obj) error {
+		if _, err := out.Write([]byte{dumpItem}); err != nil {
+			return err
+		}
+		if err := writeField(obj.Key); err != nil {
+			return err
+		}
+		if err := writeField(obj.Value); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not dump items: %w", err)
+	}
+
+	trailer := make([]byte, 1+8)
+	trailer[0] = dumpEnd
+	binary.BigEndian.PutUint64(trailer[1:], uint64(count))
+	if _, err := out.Write(trailer); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(bw, binary.BigEndian, crc.Sum32()); err != nil {
+		return 0, err
+	}
+	return count, bw.Flush()
+}
+
+// Load writes the items of a dump made by Dump into the empty database. It returns the number of loaded items.
+//
+// Nothing is written until the whole dump is verified: its checksum, its item count,
+// and its header, which is passed to verifyHeader unless it's nil.
+// To do so, the dump is first staged in a temporary file, since r can only be read once.
+func Load(r io.Reader, db basedb.Database, verifyHeader func(header []byte) error) (int, error) {
+	if err := ensureEmpty(db); err != nil {
+		return 0, err
+	}
+
+	staged, err := os.CreateTemp("", "ssvdb-load-*")
+	if err != nil {
+		return 0, fmt.Errorf("could not create staging file: %w", err)
+	}
+	defer func() {
+		_ = staged.Close()
+		_ = os.Remove(staged.Name())
+	}()
+
+	sw := bufio.NewWriter(staged)
+	header, _, err := readDump(io.TeeReader(r, sw), nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := sw.Flush(); err != nil {
+		return 0, fmt.Errorf("could not stage dump: %w", err)
+	}
+	if verifyHeader != nil {
+		if err := verifyHeader(header); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := staged.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("could not rewind staging file: %w", err)
+	}
+	w := newBatchWriter(db)
+	_, loaded, err := readDump(staged, w.add)
+	if err != nil {
+		return 0, fmt.Errorf("could not write items, the database may be left with some of them: %w", err)
+	}
+	if err := w.flush(); err != nil {
+		return 0, fmt.Errorf("could not write items, the database may be left with some of them: %w", err)
+	}
+
+	// Verify that nothing was lost.
+	existing, err := db.CountPrefix(nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not count target items: %w", err)
+	}
+	if existing != int64(loaded) {
+		return 0, fmt.Errorf("loaded %d items but target database has %d", loaded, existing)
+	}
+	return loaded, nil
+}
+
+// readDump reads a dump from r, passing its items to add unless it's nil,
+// and verifies its checksum and item count. It returns the dump's header and item count.
+func readDump(r io.Reader, add func(basedb.Obj) error) ([]byte, int, error) {
+	br := bufio.NewReader(r)
+	in := &checksumReader{r: br, crc: crc32.NewIEEE()}
+
+	magic := make([]byte, len(dumpMagic)+1)
+	if _, err := io.ReadFull(in, magic); err != nil {
+		return nil, 0, fmt.Errorf("could not read dump header: %w", err)
+	}
+	if !bytes.Equal(magic[:len(dumpMagic)], dumpMagic) {
+		return nil, 0, fmt.Errorf("not a database dump")
+	}
+	if magic[len(dumpMagic)] != dumpVersion {
+		return nil, 0, fmt.Errorf("unsupported dump version %d", magic[len(dumpMagic)])
+	}
+
+	readField := func() ([]byte, error) {
+		size, err := binary.ReadUvarint(in)
+		if err != nil {
+			return nil, err
+		}
+		if size > maxDumpFieldSize {
+			return nil, fmt.Errorf("item of %d bytes exceeds the limit", size)
+		}
+		b := make([]byte, size)
+		_, err = io.ReadFull(in, b)
+		return b, err
+	}
+
+	header, err := readField()
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not read dump header: %w", err)
+	}
+
+	read := 0
+	for {
+		marker, err := in.ReadByte()
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not read item %d: %w", read, err)
+		}
+		if marker == dumpEnd {
+			break
+		}
+		if marker != dumpItem {
+			return nil, 0, fmt.Errorf("unexpected marker %d at item %d", marker, read)
+		}
+		key, err := readField()
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not read key of item %d: %w", read, err)
+		}
+		value, err := readField()
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not read value of item %d: %w", read, err)
+		}
+		if add != nil {
+			if err := add(basedb.Obj{Key: key, Value: value}); err != nil {
+				return nil, 0, err
+			}
+		}
+		read++
+	}
+
+	var count uint64
+	if err := binary.Read(in, binary.BigEndian, &count); err != nil {
+		return nil, 0, fmt.Errorf("could not read dump trailer: %w", err)
+	}
+	expectedChecksum := in.crc.Sum32()
+	var checksum uint32
+	if err := binary.Read(br, binary.BigEndian, &checksum); err != nil {
+		return nil, 0, fmt.Errorf("could not read dump checksum: %w", err)
+	}
+	if checksum != expectedChecksum {
+		return nil, 0, fmt.Errorf("dump checksum mismatch")
+	}
+	if count != uint64(read) {
+		return nil, 0, fmt.Errorf("dump has %d items but its trailer says %d", read, count)
+	}
+	return header, read, nil
+}
+
+// checksumReader computes the checksum of the bytes read through it.
+type checksumReader struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.crc.Write(p[:n])
+	return n, err
+}
+
+func (c *checksumReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.crc.Write([]byte{b})
+	}
+	return b, err
+}
@@ -0,0 +1,122 @@
+package kv
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestDumpLoad(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	src, err := NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer src.Close()
+
+	n := convertBatchSize + 10
+	require.NoError(t, src.SetMany([]byte("a"), n, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte(fmt.Sprintf("%06d", i)), Value: []byte(fmt.Sprint(i))}, nil
+	}))
+	require.NoError(t, src.Set([]byte("b"), []byte("empty"), nil))
+
+	// Dump a snapshot, which doesn't see later writes.
+	txn := src.BeginRead()
+	require.NoError(t, src.Set([]byte("c"), []byte("later"), []byte("value")))
+	var dump bytes.Buffer
+	dumped, err := Dump(txn, []byte("header"), &dump)
+	txn.Discard()
+	require.NoError(t, err)
+	require.Equal(t, n+1, dumped)
+
+	t.Run("load", func(t *testing.T) {
+		dst, err := NewPebbleInMemory(logger, basedb.Options{})
+		require.NoError(t, err)
+		defer dst.Close()
+
+		loaded, err := Load(bytes.NewReader(dump.Bytes()), dst, nil)
+		require.NoError(t, err)
+		require.Equal(t, dumped, loaded)
+
+		obj, found, err := dst.Get([]byte("a"), []byte("000042"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("42"), obj.Value)
+		_, found, err = dst.Get([]byte("b"), []byte("empty"))
+		require.NoError(t, err)
+		require.True(t, found)
+		_, found, err = dst.Get([]byte("c"), []byte("later"))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		_, err = Load(bytes.NewReader(dump.Bytes()), dst, nil)
+		require.ErrorContains(t, err, "not empty")
+	})
+
+	tests := map[string]struct {
+		dump []byte
+		err  string
+	}{
+		"corrupted": {
+			dump: func() []byte {
+				b := bytes.Clone(dump.Bytes())
+				// Flip the last byte of the last key, which is followed by its empty value and the trailer.
+				b[len(b)-15] ^= 0xff
+				return b
+			}(),
+			err: "dump checksum mismatch",
+		},
+		"corrupted header": {
+			dump: func() []byte {
+				b := bytes.Clone(dump.Bytes())
+				// Flip the first byte of the header, which follows the magic, the version and the header's size.
+				b[len(dumpMagic)+2] ^= 0xff
+				return b
+			}(),
+			err: "dump checksum mismatch",
+		},
+		"truncated": {
+			dump: dump.Bytes()[:dump.Len()-2],
+			err:  "could not read dump checksum",
+		},
+		"not a dump": {
+			dump: []byte("something else"),
+			err:  "not a database dump",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dst, err := NewInMemory(logger, basedb.Options{})
+			require.NoError(t, err)
+			defer dst.Close()
+
+			_, err = Load(bytes.NewReader(test.dump), dst, nil)
+			require.Error(t, err)
+			require.ErrorContains(t, err, test.err)
+
+			// Nothing is written from an invalid dump, even though its items come before its checksum.
+			count, err := dst.CountPrefix(nil)
+			require.NoError(t, err)
+			require.Zero(t, count)
+		})
+	}
+
+	t.Run("header", func(t *testing.T) {
+		dst, err := NewInMemory(logger, basedb.Options{})
+		require.NoError(t, err)
+		defer dst.Close()
+
+		_, err = Load(bytes.NewReader(dump.Bytes()), dst, func(header []byte) error {
+			require.Equal(t, []byte("header"), header)
+			return fmt.Errorf("unexpected header")
+		})
+		require.ErrorContains(t, err, "unexpected header")
+		count, err := dst.CountPrefix(nil)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
@@ -0,0 +1,28 @@
+package kv
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// Open creates a persistent DB instance with the storage engine selected in the options.
+func Open(logger *zap.Logger, options basedb.Options) (basedb.Database, error) {
+	switch options.Engine {
+	case basedb.EngineBadger, "":
+		return New(logger, options)
+	case basedb.EnginePebble:
+		return NewPebble(logger, options)
+	default:
+		return nil, fmt.Errorf("unknown storage engine %q", options.Engine)
+	}
+}
+
+// prefixedKey returns the prefix followed by the key, in a new slice,
+// since storage engines may hold on to keys until their transaction is committed.
+func prefixedKey(prefix, key []byte) []byte {
+	k := make([]byte, 0, len(prefix)+len(key))
+	return append(append(k, prefix...), key...)
+}
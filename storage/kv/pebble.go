@@ -0,0 +1,392 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// pebbleWriteOptions don't sync writes to disk, like Badger's default options,
+// so a write is lost only if the machine crashes before the OS flushes it.
+var pebbleWriteOptions = pebble.NoSync
+
+// PebbleDB is a Database backed by Pebble, which compacts in the background
+// and doesn't need the periodic value log garbage collection of Badger.
+type PebbleDB struct {
+	logger *zap.Logger
+
+	db *pebble.DB
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPebble creates a persistent Pebble DB instance.
+func NewPebble(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, false)
+}
+
+// NewPebbleInMemory creates an in-memory Pebble DB instance.
+func NewPebbleInMemory(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, true)
+}
+
+func createPebbleDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*PebbleDB, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	opt := &pebble.Options{
+		Logger: newPebbleLogger(zap.NewNop()),
+	}
+	if options.Reporting {
+		opt.Logger = newPebbleLogger(logger)
+	}
+	path := options.Path
+	if inMemory {
+		opt.FS = vfs.NewMem()
+		path = ""
+	}
+
+	db, err := pebble.Open(path, opt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open pebble")
+	}
+
+	// Set up context/cancel to control background goroutines.
+	parentCtx := options.Ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parentCtx)
+
+	pebbleDB := &PebbleDB{
+		logger: logger,
+		db:     db,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	// Start periodic reporting.
+	if options.Reporting && options.Ctx != nil {
+		pebbleDB.wg.Add(1)
+		go pebbleDB.periodicallyReport(1 * time.Minute)
+	}
+
+	return pebbleDB, nil
+}
+
+// Pebble returns the underlying pebble.DB
+func (p *PebbleDB) Pebble() *pebble.DB {
+	return p.db
+}
+
+// Begin creates a read-write transaction.
+// Unlike Badger's, Pebble transactions aren't checked for conflicts, so the last commit wins.
+func (p *PebbleDB) Begin() basedb.Txn {
+	return &pebbleTxn{batch: p.db.NewIndexedBatch()}
+}
+
+// BeginRead creates a read-only transaction, which reads from a snapshot of the database.
+func (p *PebbleDB) BeginRead() basedb.ReadTxn {
+	return &pebbleReadTxn{snapshot: p.db.NewSnapshot()}
+}
+
+// Set save value with key to storage
+func (p *PebbleDB) Set(prefix []byte, key []byte, value []byte) error {
+	return p.db.Set(prefixedKey(prefix, key), value, pebbleWriteOptions)
+}
+
+// SetMany save many values with the given keys in a single batch
+func (p *PebbleDB) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if err := pebbleSetMany(batch, prefix, n, next); err != nil {
+		return err
+	}
+	return batch.Commit(pebbleWriteOptions)
+}
+
+// Get return value for specified key
+func (p *PebbleDB) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return pebbleGet(p.db, prefix, key)
+}
+
+// GetMany return values for the given keys
+func (p *PebbleDB) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	return pebbleGetMany(p.db, prefix, keys, iterator)
+}
+
+// GetAll returns all the items of a given collection
+func (p *PebbleDB) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	snapshot := p.db.NewSnapshot()
+	defer snapshot.Close()
+	return pebbleGetAll(snapshot, prefix, handler)
+}
+
+// Delete key in specific prefix
+func (p *PebbleDB) Delete(prefix []byte, key []byte) error {
+	return p.db.Delete(prefixedKey(prefix, key), pebbleWriteOptions)
+}
+
+// DeletePrefix all items with this prefix
+func (p *PebbleDB) DeletePrefix(prefix []byte) (int, error) {
+	batch := p.db.NewIndexedBatch()
+	defer batch.Close()
+
+	count := 0
+	err := pebbleIterate(batch, prefix, func(it *pebble.Iterator) error {
+		count++
+		return batch.Delete(it.Key(), nil)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, batch.Commit(pebbleWriteOptions)
+}
+
+// CountPrefix return the object count for all keys under specified prefix(bucket)
+func (p *PebbleDB) CountPrefix(prefix []byte) (int64, error) {
+	var res int64
+	err := pebbleIterate(p.db, prefix, func(it *pebble.Iterator) error {
+		res++
+		return nil
+	})
+	return res, err
+}
+
+// DropPrefix cleans all items in a collection
+func (p *PebbleDB) DropPrefix(prefix []byte) error {
+	upperBound := prefixUpperBound(prefix)
+	if upperBound == nil {
+		// The prefix has no upper bound, so delete its items one by one.
+		_, err := p.DeletePrefix(prefix)
+		return err
+	}
+	return p.db.DeleteRange(prefix, upperBound, pebbleWriteOptions)
+}
+
+// Update runs the given function in a read-write transaction, which is committed if it succeeds.
+func (p *PebbleDB) Update(fn func(basedb.Txn) error) error {
+	txn := p.Begin()
+	defer txn.Discard()
+	if err := fn(txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// Close closes the database.
+func (p *PebbleDB) Close() error {
+	// Stop & wait for background goroutines.
+	p.cancel()
+	p.wg.Wait()
+
+	err := p.db.Close()
+	if err != nil {
+		p.logger.Error("failed to close db", zap.Error(err))
+	}
+	return err
+}
+
+// QuickGC does nothing, since Pebble compacts in the background.
+func (p *PebbleDB) QuickGC(context.Context) error {
+	return nil
+}
+
+// FullGC compacts the entire database to reclaim the disk space of deleted items.
+func (p *PebbleDB) FullGC(context.Context) error {
+	first, last, err := p.keyRange()
+	if err != nil || first == nil {
+		return err
+	}
+	return p.db.Compact(first, append(last, 0), true)
+}
+
+// keyRange returns the first and last keys in the database, or nil if it's empty.
+func (p *PebbleDB) keyRange() (first, last []byte, err error) {
+	it, err := p.db.NewIter(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+	if it.First() {
+		first = append([]byte(nil), it.Key()...)
+	}
+	if it.Last() {
+		last = append([]byte(nil), it.Key()...)
+	}
+	return first, last, it.Error()
+}
+
+// report the db size and metrics
+func (p *PebbleDB) report() {
+	logger := p.logger.Named(logging.NamePebbleDBReporting)
+	metrics := p.db.Metrics()
+
+	logger.Debug("PebbleDBReport",
+		zap.Uint64("disk_usage", metrics.DiskSpaceUsage()),
+		zap.Int64("compactions", metrics.Compact.Count),
+		zap.Float64("read_amp", float64(metrics.ReadAmp())),
+		zap.Int64("block_cache_hits", metrics.BlockCache.Hits),
+		zap.Int64("block_cache_misses", metrics.BlockCache.Misses))
+}
+
+func (p *PebbleDB) periodicallyReport(interval time.Duration) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.report()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// Using returns the given ReadWriter, falling back to the database if it's nil.
+func (p *PebbleDB) Using(rw basedb.ReadWriter) basedb.ReadWriter {
+	if rw == nil {
+		return p
+	}
+	return rw
+}
+
+// UsingReader returns the given Reader, falling back to the database if it's nil.
+func (p *PebbleDB) UsingReader(r basedb.Reader) basedb.Reader {
+	if r == nil {
+		return p
+	}
+	return r
+}
+
+func pebbleGet(r pebble.Reader, prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	value, closer, err := r.Get(prefixedKey(prefix, key))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return basedb.Obj{}, false, nil
+		}
+		return basedb.Obj{}, true, err
+	}
+	defer closer.Close()
+
+	return basedb.Obj{
+		Key:   key,
+		Value: append([]byte(nil), value...),
+	}, true, nil
+}
+
+func pebbleGetMany(r pebble.Reader, prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	for _, k := range keys {
+		obj, found, err := pebbleGet(r, prefix, k)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		if err := iterator(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pebbleGetAll(r pebble.Reader, prefix []byte, handler func(int, basedb.Obj) error) error {
+	i := 0
+	return pebbleIterate(r, prefix, func(it *pebble.Iterator) error {
+		obj := basedb.Obj{
+			Key:   append([]byte(nil), it.Key()[len(prefix):]...),
+			Value: append([]byte(nil), it.Value()...),
+		}
+		if err := handler(i, obj); err != nil {
+			return err
+		}
+		i++
+		return nil
+	})
+}
+
+func pebbleSetMany(w pebble.Writer, prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	for i := 0; i < n; i++ {
+		item, err := next(i)
+		if err != nil {
+			return err
+		}
+		if err := w.Set(prefixedKey(prefix, item.Key), item.Value, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pebbleIterate calls fn with an iterator positioned at each key with the given prefix, in order.
+func pebbleIterate(r pebble.Reader, prefix []byte, fn func(*pebble.Iterator) error) error {
+	it, err := r.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	})
+	if err != nil {
+		return err
+	}
+	for it.First(); it.Valid(); it.Next() {
+		if err := fn(it); err != nil {
+			_ = it.Close()
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		_ = it.Close()
+		return err
+	}
+	return it.Close()
+}
+
+// prefixUpperBound returns the smallest key which is greater than all keys with the given prefix,
+// or nil if there's none.
+func prefixUpperBound(prefix []byte) []byte {
+	upperBound := append([]byte(nil), prefix...)
+	for i := len(upperBound) - 1; i >= 0; i-- {
+		upperBound[i]++
+		if upperBound[i] != 0 {
+			return upperBound[:i+1]
+		}
+	}
+	return nil
+}
+
+// pebbleLogger is a wrapper for pebble.Logger
+type pebbleLogger struct {
+	logger *zap.Logger
+}
+
+func newPebbleLogger(l *zap.Logger) pebble.Logger {
+	return &pebbleLogger{l.Named(logging.NamePebbleDBLog)}
+}
+
+// Infof implements pebble.Logger
+func (pl *pebbleLogger) Infof(s string, i ...interface{}) {
+	pl.logger.Info(fmt.Sprintf(s, i...))
+}
+
+// Errorf implements pebble.Logger
+func (pl *pebbleLogger) Errorf(s string, i ...interface{}) {
+	pl.logger.Error(fmt.Sprintf(s, i...))
+}
+
+// Fatalf implements pebble.Logger
+func (pl *pebbleLogger) Fatalf(s string, i ...interface{}) {
+	pl.logger.Fatal(fmt.Sprintf(s, i...))
+}
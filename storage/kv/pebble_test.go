@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestPebbleDb_Persistence(t *testing.T) {
+	logger := logging.TestLogger(t)
+	options := basedb.Options{
+		Engine: basedb.EnginePebble,
+		Path:   t.TempDir(),
+	}
+
+	db, err := Open(logger, options)
+	require.NoError(t, err)
+	require.IsType(t, &PebbleDB{}, db)
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+	for i := 0; i < 100; i++ {
+		require.NoError(t, db.Set([]byte("deleted"), []byte{byte(i)}, []byte("value")))
+	}
+	require.NoError(t, db.DropPrefix([]byte("deleted")))
+	require.NoError(t, db.(basedb.GarbageCollector).FullGC(context.Background()))
+	require.NoError(t, db.Close())
+
+	db, err = Open(logger, options)
+	require.NoError(t, err)
+	defer db.Close()
+	obj, found, err := db.Get([]byte("prefix"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+	count, err := db.CountPrefix([]byte("deleted"))
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestPebbleDb_GetAll(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	db, err := NewPebbleInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	getAllTest(t, 10000, db)
+}
+
+func TestOpen_UnknownEngine(t *testing.T) {
+	_, err := Open(logging.TestLogger(t), basedb.Options{Engine: "leveldb", Path: t.TempDir()})
+	require.EqualError(t, err, `unknown storage engine "leveldb"`)
+}
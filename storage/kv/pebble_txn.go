@@ -0,0 +1,75 @@
+package kv
+
+import (
+	"github.com/cockroachdb/pebble"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// pebbleTxn is a read-write transaction, which reads its own writes from an indexed batch.
+type pebbleTxn struct {
+	batch  *pebble.Batch
+	closed bool
+}
+
+func (t *pebbleTxn) Commit() error {
+	return t.batch.Commit(pebbleWriteOptions)
+}
+
+func (t *pebbleTxn) Discard() {
+	if t.closed {
+		return
+	}
+	t.closed = true
+	_ = t.batch.Close()
+}
+
+func (t *pebbleTxn) Set(prefix []byte, key []byte, value []byte) error {
+	return t.batch.Set(prefixedKey(prefix, key), value, nil)
+}
+
+func (t *pebbleTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	return pebbleSetMany(t.batch, prefix, n, next)
+}
+
+func (t *pebbleTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return pebbleGet(t.batch, prefix, key)
+}
+
+func (t *pebbleTxn) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	return pebbleGetMany(t.batch, prefix, keys, iterator)
+}
+
+func (t *pebbleTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return pebbleGetAll(t.batch, prefix, handler)
+}
+
+func (t *pebbleTxn) Delete(prefix []byte, key []byte) error {
+	return t.batch.Delete(prefixedKey(prefix, key), nil)
+}
+
+// pebbleReadTxn is a read-only transaction, which reads from a snapshot.
+type pebbleReadTxn struct {
+	snapshot *pebble.Snapshot
+	closed   bool
+}
+
+func (t *pebbleReadTxn) Discard() {
+	if t.closed {
+		return
+	}
+	t.closed = true
+	_ = t.snapshot.Close()
+}
+
+func (t *pebbleReadTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return pebbleGet(t.snapshot, prefix, key)
+}
+
+func (t *pebbleReadTxn) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	return pebbleGetMany(t.snapshot, prefix, keys, iterator)
+}
+
+func (t *pebbleReadTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return pebbleGetAll(t.snapshot, prefix, handler)
+}
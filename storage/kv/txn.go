@@ -29,7 +29,7 @@ func (t badgerTxn) Discard() {
 }
 
 func (t badgerTxn) Set(prefix []byte, key []byte, value []byte) error {
-	return t.txn.Set(append(prefix, key...), value)
+	return t.txn.Set(prefixedKey(prefix, key), value)
 }
 
 func (t badgerTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
@@ -39,7 +39,7 @@ func (t badgerTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, err
 			return err
 		}
 
-		if err := t.txn.Set(append(prefix, item.Key...), item.Value); err != nil {
+		if err := t.txn.Set(prefixedKey(prefix, item.Key), item.Value); err != nil {
 			return err
 		}
 	}
@@ -49,7 +49,7 @@ func (t badgerTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, err
 
 func (t badgerTxn) Get(prefix []byte, key []byte) (obj basedb.Obj, found bool, err error) {
 	var resValue []byte
-	item, err := t.txn.Get(append(prefix, key...))
+	item, err := t.txn.Get(prefixedKey(prefix, key))
 	if err != nil {
 		if errors.Is(err, badger.ErrKeyNotFound) { // in order to couple the not found errors together
 			return basedb.Obj{}, false, nil
@@ -79,5 +79,5 @@ func (t badgerTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) er
 }
 
 func (t badgerTxn) Delete(prefix []byte, key []byte) error {
-	return t.txn.Delete(append(prefix, key...))
+	return t.txn.Delete(prefixedKey(prefix, key))
 }
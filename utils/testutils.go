@@ -37,8 +37,11 @@ func SetupMockBeaconNetwork(t *testing.T, currentSlot *SlotValue) *mocknetwork.M
 		currentSlot.SetSlot(32)
 	}
 
+	slotsPerEpoch := networkconfig.TestNetwork.Beacon.SlotsPerEpoch()
+
 	mockBeaconNetwork := mocknetwork.NewMockBeaconNetwork(ctrl)
 	mockBeaconNetwork.EXPECT().GetBeaconNetwork().Return(networkconfig.TestNetwork.Beacon.GetBeaconNetwork()).AnyTimes()
+	mockBeaconNetwork.EXPECT().SlotsPerEpoch().Return(slotsPerEpoch).AnyTimes()
 
 	mockBeaconNetwork.EXPECT().EstimatedCurrentSlot().DoAndReturn(
 		func() phase0.Slot {
@@ -47,7 +50,17 @@ func SetupMockBeaconNetwork(t *testing.T, currentSlot *SlotValue) *mocknetwork.M
 	).AnyTimes()
 	mockBeaconNetwork.EXPECT().EstimatedEpochAtSlot(gomock.Any()).DoAndReturn(
 		func(slot phase0.Slot) phase0.Epoch {
-			return phase0.Epoch(slot / 32)
+			return phase0.Epoch(uint64(slot) / slotsPerEpoch)
+		},
+	).AnyTimes()
+	mockBeaconNetwork.EXPECT().FirstSlotAtEpoch(gomock.Any()).DoAndReturn(
+		func(epoch phase0.Epoch) phase0.Slot {
+			return phase0.Slot(uint64(epoch) * slotsPerEpoch)
+		},
+	).AnyTimes()
+	mockBeaconNetwork.EXPECT().EstimatedCurrentEpoch().DoAndReturn(
+		func() phase0.Epoch {
+			return phase0.Epoch(uint64(currentSlot.GetSlot()) / slotsPerEpoch)
 		},
 	).AnyTimes()
 